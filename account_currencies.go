@@ -0,0 +1,66 @@
+package xrpl
+
+// AccountCurrenciesOption configures an account_currencies request
+type AccountCurrenciesOption func(req BaseRequest)
+
+// AccountCurrenciesLedgerIndex selects the ledger to read trust lines from,
+// see LedgerSpecifier. The default is the latest validated ledger.
+func AccountCurrenciesLedgerIndex(ledger LedgerSpecifier) AccountCurrenciesOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// AccountCurrenciesStrict only accepts an address or public key as account
+func AccountCurrenciesStrict(strict bool) AccountCurrenciesOption {
+	return func(req BaseRequest) {
+		req["strict"] = strict
+	}
+}
+
+// Retrieve the currencies an account can send, those of trust lines with a
+// positive balance, and receive, those of trust lines whose limit is not
+// reached yet. Currency codes in hex are decoded with DecodeCurrency, so
+// standard codes read e.g. "USD".
+//
+// Example usage:
+//
+//	send, receive, err := client.AccountCurrencies("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("can send", send, "can receive", receive)
+func (c *Client) AccountCurrencies(account string, opts ...AccountCurrenciesOption) (sendCurrencies, receiveCurrencies []string, err error) {
+	req := BaseRequest{
+		"command":      "account_currencies",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		SendCurrencies    []string `json:"send_currencies"`
+		ReceiveCurrencies []string `json:"receive_currencies"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, nil, err
+	}
+	return decodeCurrencies(result.SendCurrencies), decodeCurrencies(result.ReceiveCurrencies), nil
+}
+
+// decodeCurrencies decodes the 40 character hex codes of a list of
+// currencies, keeping other codes, and codes that fail to decode, as they are
+func decodeCurrencies(codes []string) []string {
+	decoded := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if len(code) == 40 {
+			if d, err := DecodeCurrency(code); err == nil {
+				code = d
+			}
+		}
+		decoded = append(decoded, code)
+	}
+	return decoded
+}
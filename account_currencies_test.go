@@ -0,0 +1,98 @@
+package xrpl_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	xrpl "github.com/andreimerlescu/xrpl-go"
+	"github.com/andreimerlescu/xrpl-go/xrpltest"
+)
+
+func TestAccountCurrencies(t *testing.T) {
+	mock := xrpltest.NewMockClient()
+	mock.Result("account_currencies", map[string]interface{}{
+		"send_currencies": []interface{}{
+			"USD",
+			// "EUR" in the standard format
+			"0000000000000000000000004555520000000000",
+		},
+		"receive_currencies": []interface{}{
+			"BTC",
+			// Non-standard codes stay in hex, in uppercase
+			"015841551a748ad2c1f76ff6ecb0cccd00000000",
+			// As do codes that fail to decode
+			"ZZZZ000000000000000000000000000000000000",
+		},
+		"ledger_index": 1000,
+		"validated":    true,
+	})
+
+	send, receive, err := mock.AccountCurrencies(testAccount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"USD", "EUR"}; !reflect.DeepEqual(send, want) {
+		t.Errorf("send currencies %v, want %v", send, want)
+	}
+	want := []string{"BTC", "015841551A748AD2C1F76FF6ECB0CCCD00000000", "ZZZZ000000000000000000000000000000000000"}
+	if !reflect.DeepEqual(receive, want) {
+		t.Errorf("receive currencies %v, want %v", receive, want)
+	}
+
+	req := mock.RequestsFor("account_currencies")[0]
+	if req["account"] != testAccount || req["ledger_index"] != "validated" {
+		t.Errorf("request %v", req)
+	}
+	if _, ok := req["strict"]; ok {
+		t.Errorf("strict set by default: %v", req)
+	}
+}
+
+func TestAccountCurrenciesOptions(t *testing.T) {
+	mock := xrpltest.NewMockClient()
+	mock.Result("account_currencies", map[string]interface{}{
+		"send_currencies":    []interface{}{},
+		"receive_currencies": []interface{}{},
+	})
+
+	send, receive, err := mock.AccountCurrencies(testAccount,
+		xrpl.AccountCurrenciesLedgerIndex(xrpl.LedgerIndex(12345)),
+		xrpl.AccountCurrenciesStrict(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(send) != 0 || len(receive) != 0 {
+		t.Errorf("currencies %v, %v, want none", send, receive)
+	}
+	req := mock.RequestsFor("account_currencies")[0]
+	if req["ledger_index"] != float64(12345) || req["strict"] != true {
+		t.Errorf("request %v", req)
+	}
+
+	if _, _, err := mock.AccountCurrencies(testAccount, xrpl.AccountCurrenciesLedgerIndex(xrpl.LedgerCurrent())); err != nil {
+		t.Fatal(err)
+	}
+	if req := mock.RequestsFor("account_currencies")[1]; req["ledger_index"] != "current" {
+		t.Errorf("ledger_index %v, want current", req["ledger_index"])
+	}
+
+	// A ledger hash replaces the default ledger_index
+	const hash = "4109c6f2045fc7eff4cde8f9905d19c28820d86304080ff886b299f0206e42b5"
+	if _, _, err := mock.AccountCurrencies(testAccount, xrpl.AccountCurrenciesLedgerIndex(xrpl.LedgerHash(hash))); err != nil {
+		t.Fatal(err)
+	}
+	req = mock.RequestsFor("account_currencies")[2]
+	if _, ok := req["ledger_index"]; ok || req["ledger_hash"] != strings.ToUpper(hash) {
+		t.Errorf("request %v, want ledger_hash %s only", req, strings.ToUpper(hash))
+	}
+}
+
+func TestAccountCurrenciesError(t *testing.T) {
+	mock := xrpltest.NewMockClient()
+	mock.Fail("account_currencies", "actNotFound")
+	if _, _, err := mock.AccountCurrencies(testAccount); err == nil {
+		t.Error("actNotFound: no error")
+	}
+}
@@ -0,0 +1,131 @@
+package xrpl
+
+import "errors"
+
+// AccountInfoResult holds the account_data object returned by the
+// account_info command for an account, along with the ledger it was read from.
+type AccountInfoResult struct {
+	Account     string         `json:"Account"`
+	Balance     Amount         `json:"Balance"` // XRP balance in drops
+	Sequence    uint32         `json:"Sequence"`
+	OwnerCount  uint32         `json:"OwnerCount"`
+	Flags       uint32         `json:"Flags"`
+	RegularKey  string         `json:"RegularKey,omitempty"`
+	Domain      string         `json:"Domain,omitempty"`
+	LedgerIndex uint32         `json:"-"` // ledger_index, or ledger_current_index for the open ledger
+	Validated   bool           `json:"-"`
+	SignerLists []BaseResponse `json:"-"` // present when requested with AccountInfoSignerLists
+	QueueData   BaseResponse   `json:"-"` // present when requested with AccountInfoQueue
+}
+
+// AccountExists reports whether account exists in the latest validated
+// ledger, i.e. whether it has been funded. Errors other than the account
+// not being found are returned as they are.
+func (c *Client) AccountExists(account string) (bool, error) {
+	_, err := c.AccountInfo(account)
+	if errors.Is(err, ErrAccountNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// RequiresDestinationTag reports whether account, in the latest validated
+// ledger, has LsfRequireDestTag set, so that payments to it without a
+// DestinationTag are rejected with tecDST_TAG_NEEDED. Accounts that do not
+// exist yet require no tag.
+func (c *Client) RequiresDestinationTag(account string) (bool, error) {
+	info, err := c.AccountInfo(account)
+	if errors.Is(err, ErrAccountNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.Flags&LsfRequireDestTag != 0, nil
+}
+
+// AccountInfoOption configures an account_info request
+type AccountInfoOption func(req BaseRequest)
+
+// AccountInfoLedgerIndex selects the ledger to read the account from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func AccountInfoLedgerIndex(ledger LedgerSpecifier) AccountInfoOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// AccountInfoStrict only accepts an address or public key as account
+func AccountInfoStrict(strict bool) AccountInfoOption {
+	return func(req BaseRequest) {
+		req["strict"] = strict
+	}
+}
+
+// AccountInfoQueue includes queued transactions of the account. It is only
+// valid for the current open ledger.
+func AccountInfoQueue(queue bool) AccountInfoOption {
+	return func(req BaseRequest) {
+		req["queue"] = queue
+	}
+}
+
+// AccountInfoSignerLists includes the account's SignerList objects
+func AccountInfoSignerLists(signerLists bool) AccountInfoOption {
+	return func(req BaseRequest) {
+		req["signer_lists"] = signerLists
+	}
+}
+
+// Retrieve information about an account, its activity and its XRP balance.
+//
+// Example usage:
+//
+//	info, err := client.AccountInfo("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		xrpl.AccountInfoLedgerIndex(xrpl.LedgerCurrent()))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(info.Sequence, info.Balance)
+func (c *Client) AccountInfo(account string, opts ...AccountInfoOption) (*AccountInfoResult, error) {
+	req := BaseRequest{
+		"command":      "account_info",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		AccountData struct {
+			AccountInfoResult
+			// API v1 nests signer lists inside account_data
+			SignerLists []BaseResponse `json:"signer_lists"`
+		} `json:"account_data"`
+		LedgerIndex        uint32         `json:"ledger_index"`
+		LedgerCurrentIndex uint32         `json:"ledger_current_index"`
+		Validated          bool           `json:"validated"`
+		SignerLists        []BaseResponse `json:"signer_lists"`
+		QueueData          BaseResponse   `json:"queue_data"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+
+	info := result.AccountData.AccountInfoResult
+	info.LedgerIndex = result.LedgerIndex
+	if info.LedgerIndex == 0 {
+		info.LedgerIndex = result.LedgerCurrentIndex
+	}
+	info.Validated = result.Validated
+	info.SignerLists = result.SignerLists
+	if info.SignerLists == nil {
+		info.SignerLists = result.AccountData.SignerLists
+	}
+	info.QueueData = result.QueueData
+	return &info, nil
+}
@@ -0,0 +1,165 @@
+package xrpl
+
+// TrustLine is a trust line of an account as returned by the account_lines
+// command. Balance and limits are token values in Currency, from the
+// perspective of the requested account.
+type TrustLine struct {
+	Account        string `json:"account"` // The counterparty of the trust line
+	Currency       string `json:"currency"`
+	Balance        string `json:"balance"` // Negative when the account owes the counterparty
+	Limit          string `json:"limit"`
+	LimitPeer      string `json:"limit_peer"`
+	QualityIn      uint32 `json:"quality_in"`
+	QualityOut     uint32 `json:"quality_out"`
+	NoRipple       bool   `json:"no_ripple"`
+	NoRipplePeer   bool   `json:"no_ripple_peer"`
+	Authorized     bool   `json:"authorized"`
+	PeerAuthorized bool   `json:"peer_authorized"`
+	Freeze         bool   `json:"freeze"`
+	FreezePeer     bool   `json:"freeze_peer"`
+}
+
+// AccountLinesOption configures an account_lines request
+type AccountLinesOption func(req BaseRequest)
+
+// AccountLinesLedgerIndex selects the ledger to read trust lines from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func AccountLinesLedgerIndex(ledger LedgerSpecifier) AccountLinesOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// AccountLinesPeer only returns trust lines between the account and peer
+func AccountLinesPeer(peer string) AccountLinesOption {
+	return func(req BaseRequest) {
+		req["peer"] = peer
+	}
+}
+
+// AccountLinesLimit sets the number of trust lines requested per page. The
+// server enforces its own bounds, 10 to 400 by default.
+func AccountLinesLimit(limit int) AccountLinesOption {
+	return func(req BaseRequest) {
+		req["limit"] = limit
+	}
+}
+
+// AccountLinesMarker resumes listing at marker, as returned in the Marker of
+// an AccountLinesResult. To keep the marker valid, request the same ledger
+// with AccountLinesLedgerIndex.
+func AccountLinesMarker(marker Marker) AccountLinesOption {
+	return func(req BaseRequest) {
+		if !marker.IsZero() {
+			req["marker"] = marker
+		}
+	}
+}
+
+// AccountLinesResult is a page of the trust lines of an account, as returned
+// by AccountLinesPage. Marker is zero on the last page.
+type AccountLinesResult struct {
+	Account     string      `json:"account"`
+	Lines       []TrustLine `json:"lines"`
+	LedgerIndex uint32      `json:"ledger_index"`
+	Marker      Marker      `json:"marker,omitempty"`
+}
+
+// Retrieve all trust lines of an account. Pages are requested one after
+// another, following the server's marker until the last page.
+//
+// Example usage:
+//
+//	lines, err := client.AccountLines("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	if err != nil {
+//		return err
+//	}
+//	for _, line := range lines {
+//		fmt.Println(line.Currency, line.Account, line.Balance)
+//	}
+func (c *Client) AccountLines(account string, opts ...AccountLinesOption) ([]TrustLine, error) {
+	var lines []TrustLine
+	err := c.accountLinesPages(account, opts, func(page []TrustLine) bool {
+		lines = append(lines, page...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// AccountLinesPages iterates over the trust lines of an account a page at a
+// time, without holding more than one page in memory. Iteration stops when
+// yield returns false or after the last page. A failed request also ends the
+// iteration and is logged; use AccountLines where the error must be handled.
+//
+// Example usage:
+//
+//	pages := client.AccountLinesPages("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	pages(func(page []xrpl.TrustLine) bool {
+//		fmt.Println(len(page))
+//		return true
+//	})
+func (c *Client) AccountLinesPages(account string, opts ...AccountLinesOption) func(yield func([]TrustLine) bool) {
+	return func(yield func([]TrustLine) bool) {
+		if err := c.accountLinesPages(account, opts, yield); err != nil {
+			c.logger.Errorf("account_lines error: %s %s", account, err)
+		}
+	}
+}
+
+// Retrieve a single page of the trust lines of an account. The Marker and
+// LedgerIndex of the result, which may be persisted, request the next page.
+//
+// Example usage:
+//
+//	page, err := client.AccountLinesPage(account, xrpl.AccountLinesLimit(100))
+//	if err != nil {
+//		return err
+//	}
+//	// Later, e.g. after restoring page.Marker and page.LedgerIndex:
+//	next, err := client.AccountLinesPage(account,
+//		xrpl.AccountLinesMarker(page.Marker),
+//		xrpl.AccountLinesLedgerIndex(xrpl.LedgerIndex(page.LedgerIndex)))
+func (c *Client) AccountLinesPage(account string, opts ...AccountLinesOption) (*AccountLinesResult, error) {
+	var result AccountLinesResult
+	if err := c.requestResult(accountLinesRequest(account, opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// accountLinesRequest builds an account_lines request for account
+func accountLinesRequest(account string, opts []AccountLinesOption) BaseRequest {
+	req := BaseRequest{
+		"command":      "account_lines",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// accountLinesPages requests pages of trust lines and passes each to yield
+// until the last page or until yield returns false. Later pages are read from
+// the ledger the first page came from, so that the marker stays valid.
+func (c *Client) accountLinesPages(account string, opts []AccountLinesOption, yield func([]TrustLine) bool) error {
+	req := accountLinesRequest(account, opts)
+	for {
+		var result AccountLinesResult
+		if err := c.requestResult(req, &result); err != nil {
+			return err
+		}
+		if !yield(result.Lines) || result.Marker.IsZero() {
+			return nil
+		}
+
+		req["marker"] = result.Marker
+		if result.LedgerIndex != 0 {
+			req["ledger_index"] = result.LedgerIndex
+		}
+	}
+}
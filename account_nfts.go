@@ -0,0 +1,211 @@
+package xrpl
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// NFToken is a non-fungible token held by an account, as returned by the
+// account_nfts command
+type NFToken struct {
+	NFTokenID    string `json:"NFTokenID"`
+	Issuer       string `json:"Issuer"`
+	NFTokenTaxon uint32 `json:"NFTokenTaxon"`
+	Flags        uint16 `json:"Flags"`       // TfBurnable, TfOnlyXRP, TfTrustLine, TfTransferable, TfMutable
+	TransferFee  uint16 `json:"TransferFee"` // in units of 0.001%, 0 to 50000
+	URI          string `json:"URI"`         // hex encoded, empty if not set
+	Serial       uint32 `json:"nft_serial"`
+}
+
+// AccountNFTsOption configures an account_nfts request
+type AccountNFTsOption func(req BaseRequest)
+
+// AccountNFTsLimit sets the number of tokens requested per page. The server
+// enforces its own bounds, 20 to 400 by default.
+func AccountNFTsLimit(limit int) AccountNFTsOption {
+	return func(req BaseRequest) {
+		req["limit"] = limit
+	}
+}
+
+// AccountNFTsLedgerIndex selects the ledger to read tokens from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func AccountNFTsLedgerIndex(ledger LedgerSpecifier) AccountNFTsOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// AccountNFTsMarker resumes listing at marker, as returned in the Marker
+// of an AccountNFTsResult. To keep the marker valid, request the same
+// ledger with AccountNFTsLedgerIndex.
+func AccountNFTsMarker(marker Marker) AccountNFTsOption {
+	return func(req BaseRequest) {
+		if !marker.IsZero() {
+			req["marker"] = marker
+		}
+	}
+}
+
+// AccountNFTsResult is a page of the non-fungible tokens of an account, as
+// returned by AccountNFTsPage. Marker is zero on the last page.
+type AccountNFTsResult struct {
+	Account     string    `json:"account"`
+	AccountNFTs []NFToken `json:"account_nfts"`
+	LedgerIndex uint32    `json:"ledger_index"`
+	Marker      Marker    `json:"marker,omitempty"`
+}
+
+// Retrieve the non-fungible tokens held by an account. Pages are requested
+// one after another, following the server's marker until the last page;
+// later pages are read from the ledger the first page came from.
+//
+// Example usage:
+//
+//	nfts, err := client.AccountNFTs("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	if err != nil {
+//		return err
+//	}
+//	for _, nft := range nfts {
+//		fmt.Println(nft.NFTokenID, nft.Issuer, nft.NFTokenTaxon)
+//	}
+func (c *Client) AccountNFTs(account string, opts ...AccountNFTsOption) ([]NFToken, error) {
+	req := accountNFTsRequest(account, opts)
+
+	var nfts []NFToken
+	for {
+		var result AccountNFTsResult
+		if err := c.requestResult(req, &result); err != nil {
+			return nil, err
+		}
+		nfts = append(nfts, result.AccountNFTs...)
+		if result.Marker.IsZero() {
+			return nfts, nil
+		}
+
+		req["marker"] = result.Marker
+		if result.LedgerIndex != 0 {
+			req["ledger_index"] = result.LedgerIndex
+		}
+	}
+}
+
+// Retrieve a single page of the non-fungible tokens of an account. The Marker
+// and LedgerIndex of the result, which may be persisted, request the next
+// page.
+//
+// Example usage:
+//
+//	page, err := client.AccountNFTsPage(account, xrpl.AccountNFTsLimit(100))
+//	if err != nil {
+//		return err
+//	}
+//	// Later, e.g. after restoring page.Marker and page.LedgerIndex:
+//	next, err := client.AccountNFTsPage(account,
+//		xrpl.AccountNFTsMarker(page.Marker),
+//		xrpl.AccountNFTsLedgerIndex(xrpl.LedgerIndex(page.LedgerIndex)))
+func (c *Client) AccountNFTsPage(account string, opts ...AccountNFTsOption) (*AccountNFTsResult, error) {
+	var result AccountNFTsResult
+	if err := c.requestResult(accountNFTsRequest(account, opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// accountNFTsRequest builds an account_nfts request for account
+func accountNFTsRequest(account string, opts []AccountNFTsOption) BaseRequest {
+	req := BaseRequest{
+		"command":      "account_nfts",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// NFTokenInfo holds the fields encoded in an NFTokenID
+type NFTokenInfo struct {
+	NFTokenID   string
+	Flags       uint16 // TfBurnable, TfOnlyXRP, TfTrustLine, TfTransferable, TfMutable
+	TransferFee uint16 // in units of 0.001%, 0 to 50000
+	Issuer      string // classic address
+	Taxon       uint32
+	Sequence    uint32 // the issuer's mint sequence number, nft_serial
+}
+
+// ParseNFTokenID splits a 64 character hex NFTokenID into its fields. The ID
+// is, in order: 16 bits of flags, a 16 bit transfer fee, the 160 bit issuer
+// AccountID, a 32 bit taxon and a 32 bit sequence number. The taxon is stored
+// scrambled with the sequence, so tokens of one taxon are not stored next to
+// each other in the ledger, and is unscrambled here.
+//
+// Example usage:
+//
+//	info, err := xrpl.ParseNFTokenID("000B013A95F14B0044F78A264E41713C64B5F89242540EE208C3098E00000D65")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(info.Issuer, info.Taxon, info.Sequence)
+func ParseNFTokenID(id string) (*NFTokenInfo, error) {
+	data, err := hex.DecodeString(id)
+	if err != nil || len(data) != 32 {
+		return nil, fmt.Errorf("invalid NFTokenID %q: must be 32 bytes of hex", id)
+	}
+
+	sequence := binary.BigEndian.Uint32(data[28:32])
+	return &NFTokenInfo{
+		NFTokenID:   strings.ToUpper(id),
+		Flags:       binary.BigEndian.Uint16(data[0:2]),
+		TransferFee: binary.BigEndian.Uint16(data[2:4]),
+		Issuer:      encodeAccountID(data[4:24]),
+		Taxon:       binary.BigEndian.Uint32(data[24:28]) ^ nftokenTaxonCipher(sequence),
+		Sequence:    sequence,
+	}, nil
+}
+
+// PredictNFTokenID returns the NFTokenID a mint by issuer will produce,
+// assembled as ParseNFTokenID takes it apart. flags are the NFTokenMint flags,
+// of which the low 16 bits are kept in the token, and sequence is the
+// issuer's next mint sequence: the FirstNFTokenSequence plus MintedNFTokens
+// of its AccountRoot, or MintedNFTokens alone for accounts without
+// FirstNFTokenSequence. A mint by an authorized minter uses the issuer's
+// sequence, not the minter's.
+//
+// Example usage:
+//
+//	root, err := client.LedgerEntry(xrpl.AccountRootEntry(issuer))
+//	if err != nil {
+//		return err
+//	}
+//	fields := root.Object.Fields()
+//	first, _ := fields["FirstNFTokenSequence"].(float64)
+//	minted, _ := fields["MintedNFTokens"].(float64)
+//	id, err := xrpl.PredictNFTokenID(issuer, 0, xrpl.TfTransferable, 2500, uint32(first+minted))
+func PredictNFTokenID(issuer string, taxon uint32, flags uint32, transferFee uint16, sequence uint32) (string, error) {
+	accountID, err := decodeAccountID(issuer)
+	if err != nil {
+		return "", fmt.Errorf("invalid NFToken issuer: %w", err)
+	}
+	if transferFee > nftokenMaxTransferFee {
+		return "", fmt.Errorf("NFToken TransferFee out of bounds: %d, at most %d", transferFee, nftokenMaxTransferFee)
+	}
+
+	data := make([]byte, 32)
+	binary.BigEndian.PutUint16(data[0:2], uint16(flags))
+	binary.BigEndian.PutUint16(data[2:4], transferFee)
+	copy(data[4:24], accountID)
+	binary.BigEndian.PutUint32(data[24:28], taxon^nftokenTaxonCipher(sequence))
+	binary.BigEndian.PutUint32(data[28:32], sequence)
+	return strings.ToUpper(hex.EncodeToString(data)), nil
+}
+
+// nftokenTaxonCipher returns the value the taxon of the token with sequence
+// number sequence is XORed with in its NFTokenID. It comes from a linear
+// congruential generator, with the constants rippled uses.
+func nftokenTaxonCipher(sequence uint32) uint32 {
+	return 384160001*sequence + 2459
+}
@@ -0,0 +1,57 @@
+package xrpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNFTokenID(t *testing.T) {
+	tests := []NFTokenInfo{
+		// parseNFTokenID fixture of xrpl.js
+		{
+			NFTokenID:   "000B0539C35B55AA096BA6D87A6E6C965A6534150DC56E5E12C5D09E0000000C",
+			Flags:       uint16(TfBurnable | TfOnlyXRP | TfTransferable),
+			TransferFee: 1337,
+			Issuer:      "rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthE",
+			Taxon:       1337,
+			Sequence:    12,
+		},
+		// The first token of its issuer, whose taxon 0 is scrambled into the
+		// cipher of sequence 0, 0x0000099B
+		{
+			NFTokenID:   "00081388DF5A9C6B9ED2EC1CFC64DECADA2E7A9723D392B30000099B00000000",
+			Flags:       uint16(TfTransferable),
+			TransferFee: 5000,
+			Issuer:      "rMMzNYSP3WNcfbgK7Ew7XVY7ffqeP5uKGA",
+			Taxon:       0,
+			Sequence:    0,
+		},
+	}
+	for _, test := range tests {
+		info, err := ParseNFTokenID(test.NFTokenID)
+		if err != nil {
+			t.Errorf("ParseNFTokenID(%s): %v", test.NFTokenID, err)
+		} else if !reflect.DeepEqual(*info, test) {
+			t.Errorf("ParseNFTokenID(%s) = %+v, want %+v", test.NFTokenID, *info, test)
+		}
+
+		id, err := PredictNFTokenID(test.Issuer, test.Taxon, uint32(test.Flags), test.TransferFee, test.Sequence)
+		if err != nil {
+			t.Errorf("PredictNFTokenID: %v", err)
+		} else if id != test.NFTokenID {
+			t.Errorf("PredictNFTokenID(%+v) = %s, want %s", test, id, test.NFTokenID)
+		}
+	}
+}
+
+func TestPredictNFTokenIDInvalid(t *testing.T) {
+	if _, err := PredictNFTokenID("rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthE", 0, TfTransferable, 50001, 0); err == nil {
+		t.Error("transfer fee above 50%: no error")
+	}
+	if _, err := PredictNFTokenID("rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthF", 0, 0, 0, 0); err == nil {
+		t.Error("invalid issuer: no error")
+	}
+	if _, err := ParseNFTokenID("000B0539C35B55AA096BA6D87A6E6C965A6534150DC56E5E12C5D09E000000"); err == nil {
+		t.Error("31 byte NFTokenID: no error")
+	}
+}
@@ -0,0 +1,129 @@
+package xrpl
+
+// AccountObjectsOption configures an account_objects request
+type AccountObjectsOption func(req BaseRequest)
+
+// AccountObjectsType only returns objects of one type. The type is given in
+// the short form of the API, e.g. "state" for trust lines, "offer", "escrow",
+// "check", "payment_channel", "signer_list", "deposit_preauth" or "ticket".
+func AccountObjectsType(objectType string) AccountObjectsOption {
+	return func(req BaseRequest) {
+		req["type"] = objectType
+	}
+}
+
+// AccountObjectsDeletionBlockersOnly only returns objects that prevent the
+// account from being deleted. An account without such objects can be deleted
+// with AccountDelete, provided its other requirements are met.
+func AccountObjectsDeletionBlockersOnly(deletionBlockersOnly bool) AccountObjectsOption {
+	return func(req BaseRequest) {
+		req["deletion_blockers_only"] = deletionBlockersOnly
+	}
+}
+
+// AccountObjectsLimit sets the number of objects requested per page. The
+// server enforces its own bounds, 10 to 400 by default.
+func AccountObjectsLimit(limit int) AccountObjectsOption {
+	return func(req BaseRequest) {
+		req["limit"] = limit
+	}
+}
+
+// AccountObjectsLedgerIndex selects the ledger to read objects from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func AccountObjectsLedgerIndex(ledger LedgerSpecifier) AccountObjectsOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// AccountObjectsMarker resumes listing at marker, as returned in the Marker
+// of an AccountObjectsResult. To keep the marker valid, request the same
+// ledger with AccountObjectsLedgerIndex.
+func AccountObjectsMarker(marker Marker) AccountObjectsOption {
+	return func(req BaseRequest) {
+		if !marker.IsZero() {
+			req["marker"] = marker
+		}
+	}
+}
+
+// AccountObjectsResult is a page of the ledger objects of an account, as
+// returned by AccountObjectsPage. Marker is zero on the last page.
+type AccountObjectsResult struct {
+	Account        string         `json:"account"`
+	AccountObjects []LedgerObject `json:"account_objects"`
+	LedgerIndex    uint32         `json:"ledger_index"`
+	Marker         Marker         `json:"marker,omitempty"`
+}
+
+// Retrieve the ledger objects owned by an account. Pages are requested one
+// after another, following the server's marker until the last page; later
+// pages are read from the ledger the first page came from.
+//
+// Example usage:
+//
+//	blockers, err := client.AccountObjects("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		xrpl.AccountObjectsDeletionBlockersOnly(true))
+//	if err != nil {
+//		return err
+//	}
+//	for _, obj := range blockers {
+//		if escrow, ok := obj.AsEscrow(); ok {
+//			fmt.Println("escrow to", escrow.Destination, escrow.Amount)
+//		}
+//	}
+func (c *Client) AccountObjects(account string, opts ...AccountObjectsOption) ([]LedgerObject, error) {
+	req := accountObjectsRequest(account, opts)
+
+	var objects []LedgerObject
+	for {
+		var result AccountObjectsResult
+		if err := c.requestResult(req, &result); err != nil {
+			return nil, err
+		}
+		objects = append(objects, result.AccountObjects...)
+		if result.Marker.IsZero() {
+			return objects, nil
+		}
+
+		req["marker"] = result.Marker
+		if result.LedgerIndex != 0 {
+			req["ledger_index"] = result.LedgerIndex
+		}
+	}
+}
+
+// Retrieve a single page of the ledger objects of an account. The Marker and
+// LedgerIndex of the result, which may be persisted, request the next page.
+//
+// Example usage:
+//
+//	page, err := client.AccountObjectsPage(account, xrpl.AccountObjectsLimit(100))
+//	if err != nil {
+//		return err
+//	}
+//	// Later, e.g. after restoring page.Marker and page.LedgerIndex:
+//	next, err := client.AccountObjectsPage(account,
+//		xrpl.AccountObjectsMarker(page.Marker),
+//		xrpl.AccountObjectsLedgerIndex(xrpl.LedgerIndex(page.LedgerIndex)))
+func (c *Client) AccountObjectsPage(account string, opts ...AccountObjectsOption) (*AccountObjectsResult, error) {
+	var result AccountObjectsResult
+	if err := c.requestResult(accountObjectsRequest(account, opts), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// accountObjectsRequest builds an account_objects request for account
+func accountObjectsRequest(account string, opts []AccountObjectsOption) BaseRequest {
+	req := BaseRequest{
+		"command":      "account_objects",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
@@ -0,0 +1,173 @@
+package xrpl
+
+import (
+	"fmt"
+	"math"
+)
+
+// AccountSetOption configures BuildAccountSet
+type AccountSetOption func(*accountSetOptions)
+
+type accountSetOptions struct {
+	setFlags     []uint32
+	clearFlags   []uint32
+	domain       *string
+	transferRate *float64
+	tickSize     *uint8
+}
+
+// Longest domain an account may set, in bytes
+const maxDomainLength = 256
+
+// AccountSetFlag enables, through SetFlag, or disables, through ClearFlag,
+// the account setting asf, one of the Asf constants
+func AccountSetFlag(asf uint32, enabled bool) AccountSetOption {
+	return func(o *accountSetOptions) {
+		if enabled {
+			o.setFlags = append(o.setFlags, asf)
+		} else {
+			o.clearFlags = append(o.clearFlags, asf)
+		}
+	}
+}
+
+// AccountSetDefaultRipple enables or disables rippling by default on the
+// account's trust lines, AsfDefaultRipple, which issuers need
+func AccountSetDefaultRipple(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDefaultRipple, enabled)
+}
+
+// AccountSetRequireDestinationTag requires, or stops requiring, a
+// destination tag on payments to the account, AsfRequireDest
+func AccountSetRequireDestinationTag(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfRequireDest, enabled)
+}
+
+// AccountSetRequireAuth requires, or stops requiring, the account to
+// authorize trust lines holding its tokens, AsfRequireAuth
+func AccountSetRequireAuth(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfRequireAuth, enabled)
+}
+
+// AccountSetDisallowXRP asks senders not to send XRP to the account, or
+// stops asking, AsfDisallowXRP. The network does not enforce it.
+func AccountSetDisallowXRP(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDisallowXRP, enabled)
+}
+
+// AccountSetDepositAuth requires, or stops requiring, senders to be
+// preauthorized with DepositPreauth to send funds to the account,
+// AsfDepositAuth
+func AccountSetDepositAuth(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDepositAuth, enabled)
+}
+
+// AccountSetDisallowIncomingNFTokenOffer blocks, or allows again, NFToken
+// offers made to the account, AsfDisallowIncomingNFTokenOffer
+func AccountSetDisallowIncomingNFTokenOffer(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDisallowIncomingNFTokenOffer, enabled)
+}
+
+// AccountSetDisallowIncomingCheck blocks, or allows again, Checks made out to
+// the account, AsfDisallowIncomingCheck
+func AccountSetDisallowIncomingCheck(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDisallowIncomingCheck, enabled)
+}
+
+// AccountSetDisallowIncomingPayChan blocks, or allows again, payment channels
+// to the account, AsfDisallowIncomingPayChan
+func AccountSetDisallowIncomingPayChan(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDisallowIncomingPayChan, enabled)
+}
+
+// AccountSetDisallowIncomingTrustline blocks, or allows again, trust lines to
+// the account, AsfDisallowIncomingTrustline
+func AccountSetDisallowIncomingTrustline(enabled bool) AccountSetOption {
+	return AccountSetFlag(AsfDisallowIncomingTrustline, enabled)
+}
+
+// AccountSetDomain sets the domain that owns the account, e.g.
+// "example.com", encoded as hex into Domain. An empty domain removes it.
+func AccountSetDomain(domain string) AccountSetOption {
+	return func(o *accountSetOptions) {
+		o.domain = &domain
+	}
+}
+
+// AccountSetTransferRate sets the fee the account charges when its tokens
+// are transferred between other accounts, as a multiplier from 1 to 2: 1.005
+// charges 0.5%. A rate of 0 or 1 removes the fee.
+func AccountSetTransferRate(rate float64) AccountSetOption {
+	return func(o *accountSetOptions) {
+		o.transferRate = &rate
+	}
+}
+
+// AccountSetTickSize sets the number of significant digits, 3 to 15, of the
+// exchange rates of offers involving the account's tokens. 0 removes it.
+func AccountSetTickSize(tickSize uint8) AccountSetOption {
+	return func(o *accountSetOptions) {
+		o.tickSize = &tickSize
+	}
+}
+
+// BuildAccountSet builds an AccountSet transaction from account, ready to be
+// autofilled and signed, changing the account settings given by opts. A
+// transaction sets at most one flag and clears at most one other; changing
+// more flags takes one transaction each, and combining them is rejected.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildAccountSet(
+//		issuer.ClassicAddress,
+//		xrpl.AccountSetDefaultRipple(true),
+//		xrpl.AccountSetDomain("example.com"),
+//		xrpl.AccountSetTransferRate(1.002))
+func BuildAccountSet(account string, opts ...AccountSetOption) (map[string]interface{}, error) {
+	var options accountSetOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if len(options.setFlags) > 1 || len(options.clearFlags) > 1 {
+		return nil, fmt.Errorf("AccountSet changes one SetFlag and one ClearFlag per transaction, got %d and %d: build one transaction per flag", len(options.setFlags), len(options.clearFlags))
+	}
+	if len(options.setFlags) == 1 && len(options.clearFlags) == 1 && options.setFlags[0] == options.clearFlags[0] {
+		return nil, fmt.Errorf("AccountSet cannot both set and clear flag %d", options.setFlags[0])
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeAccountSet),
+		"Account":         account,
+	}
+	if len(options.setFlags) == 1 {
+		tx["SetFlag"] = options.setFlags[0]
+	}
+	if len(options.clearFlags) == 1 {
+		tx["ClearFlag"] = options.clearFlags[0]
+	}
+	if options.domain != nil {
+		if len(*options.domain) > maxDomainLength {
+			return nil, fmt.Errorf("invalid Domain %q: longer than %d bytes", *options.domain, maxDomainLength)
+		}
+		tx["Domain"] = ToHexField(*options.domain)
+	}
+	if options.transferRate != nil {
+		rate := *options.transferRate
+		switch {
+		case rate == 0 || rate == 1:
+			tx["TransferRate"] = uint32(0)
+		case rate > 1 && rate <= 2:
+			tx["TransferRate"] = uint32(math.Round(rate * 1e9))
+		default:
+			return nil, fmt.Errorf("invalid TransferRate %v: must be 0 or from 1 to 2", rate)
+		}
+	}
+	if options.tickSize != nil {
+		tickSize := *options.tickSize
+		if tickSize != 0 && (tickSize < 3 || tickSize > 15) {
+			return nil, fmt.Errorf("invalid TickSize %d: must be 0 or from 3 to 15", tickSize)
+		}
+		tx["TickSize"] = tickSize
+	}
+	return tx, nil
+}
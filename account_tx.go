@@ -0,0 +1,278 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// TxWithMeta is a transaction together with its metadata, as listed by the
+// account_tx command. For binary requests Tx and Meta are empty and the
+// transaction and metadata are given as hex in TxBlob and MetaBlob.
+type TxWithMeta struct {
+	Hash        string
+	LedgerIndex uint32
+	Validated   bool
+	Tx          BaseResponse // Transaction fields
+	Meta        TxMeta
+	TxBlob      string
+	MetaBlob    string
+}
+
+// TxMeta is the metadata of a transaction, describing its outcome
+type TxMeta struct {
+	TransactionIndex  uint32         `json:"TransactionIndex"`
+	TransactionResult string         `json:"TransactionResult"`
+	AffectedNodes     []BaseResponse `json:"AffectedNodes"`
+	// Amount actually delivered by a payment. It is nil for transactions that
+	// deliver nothing, and for payments from before 2014 for which the server
+	// reports it as "unavailable".
+	DeliveredAmount *Amount `json:"delivered_amount,omitempty"`
+}
+
+func (m *TxMeta) UnmarshalJSON(data []byte) error {
+	type txMeta TxMeta
+	var meta struct {
+		txMeta
+		DeliveredAmount json.RawMessage `json:"delivered_amount"`
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return err
+	}
+	*m = TxMeta(meta.txMeta)
+	if len(meta.DeliveredAmount) > 0 && string(meta.DeliveredAmount) != `"unavailable"` {
+		var amount Amount
+		if err := json.Unmarshal(meta.DeliveredAmount, &amount); err != nil {
+			return err
+		}
+		m.DeliveredAmount = &amount
+	}
+	return nil
+}
+
+// AccountTxOption configures an account_tx request
+type AccountTxOption func(req BaseRequest)
+
+// AccountTxLedgerRange only lists transactions from ledgers min to max. Use
+// -1 for the earliest or the latest validated ledger available; the default
+// is the full range, -1 to -1.
+func AccountTxLedgerRange(min, max int64) AccountTxOption {
+	return func(req BaseRequest) {
+		req["ledger_index_min"] = min
+		req["ledger_index_max"] = max
+	}
+}
+
+// AccountTxForward lists the oldest transactions first. The default is to
+// list the newest first.
+func AccountTxForward(forward bool) AccountTxOption {
+	return func(req BaseRequest) {
+		req["forward"] = forward
+	}
+}
+
+// AccountTxBinary returns transactions and metadata as hex blobs
+func AccountTxBinary(binary bool) AccountTxOption {
+	return func(req BaseRequest) {
+		req["binary"] = binary
+	}
+}
+
+// AccountTxLimit sets the number of transactions requested per page
+func AccountTxLimit(limit int) AccountTxOption {
+	return func(req BaseRequest) {
+		req["limit"] = limit
+	}
+}
+
+// AccountTxMarker resumes listing at marker, as returned in the Marker of an
+// AccountTxResult. To keep the marker valid, request the same ledger range
+// and direction as the page it came from.
+func AccountTxMarker(marker Marker) AccountTxOption {
+	return func(req BaseRequest) {
+		if !marker.IsZero() {
+			req["marker"] = marker
+		}
+	}
+}
+
+// AccountTxResult is a page of the transaction history of an account, as
+// returned by AccountTxPage. Marker is zero on the last page.
+type AccountTxResult struct {
+	Account        string
+	LedgerIndexMin int64 // First ledger of the range searched
+	LedgerIndexMax int64 // Last ledger of the range searched
+	Transactions   []TxWithMeta
+	Marker         Marker
+}
+
+// Retrieve the transaction history of an account. Pages are requested one
+// after another, following the server's marker until the last page.
+//
+// Example usage:
+//
+//	txs, err := client.AccountTx("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		xrpl.AccountTxLedgerRange(80000000, -1))
+//	if err != nil {
+//		return err
+//	}
+//	for _, tx := range txs {
+//		fmt.Println(tx.Hash, tx.Tx["TransactionType"], tx.Meta.TransactionResult)
+//	}
+func (c *Client) AccountTx(account string, opts ...AccountTxOption) ([]TxWithMeta, error) {
+	var txs []TxWithMeta
+	err := c.accountTxPages(account, opts, func(page []TxWithMeta) bool {
+		txs = append(txs, page...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
+// AccountTxPages iterates over the transaction history of an account a page
+// at a time. Iteration stops when yield returns false or after the last page.
+// A failed request is yielded as a final nil page with its error, which is an
+// *XRPLError for error responses from the server.
+//
+// Example usage:
+//
+//	pages := client.AccountTxPages("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	pages(func(page []xrpl.TxWithMeta, err error) bool {
+//		if err != nil {
+//			log.Println(err)
+//			return false
+//		}
+//		fmt.Println(len(page))
+//		return true
+//	})
+func (c *Client) AccountTxPages(account string, opts ...AccountTxOption) func(yield func([]TxWithMeta, error) bool) {
+	return func(yield func([]TxWithMeta, error) bool) {
+		err := c.accountTxPages(account, opts, func(page []TxWithMeta) bool {
+			return yield(page, nil)
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+// accountTxEntry is a transaction listed by account_tx. API v1 lists the
+// transaction as tx, including its hash and ledger_index; API v2 lists it as
+// tx_json with hash and ledger_index alongside. Binary entries list both as
+// hex strings, the metadata in meta.
+type accountTxEntry struct {
+	Hash        string          `json:"hash"`
+	LedgerIndex uint32          `json:"ledger_index"`
+	Validated   bool            `json:"validated"`
+	Tx          BaseResponse    `json:"tx"`
+	TxJSON      BaseResponse    `json:"tx_json"`
+	TxBlob      string          `json:"tx_blob"`
+	Meta        json.RawMessage `json:"meta"`
+}
+
+func (e *accountTxEntry) txWithMeta() (TxWithMeta, error) {
+	tx := TxWithMeta{
+		Hash:        e.Hash,
+		LedgerIndex: e.LedgerIndex,
+		Validated:   e.Validated,
+		Tx:          e.TxJSON,
+		TxBlob:      e.TxBlob,
+	}
+	if tx.Tx == nil {
+		tx.Tx = e.Tx
+	}
+	if tx.Hash == "" {
+		tx.Hash, _ = tx.Tx["hash"].(string)
+	}
+	if index, ok := tx.Tx["ledger_index"].(float64); ok && tx.LedgerIndex == 0 {
+		tx.LedgerIndex = uint32(index)
+	}
+
+	if len(e.Meta) > 0 && e.Meta[0] == '"' {
+		if err := json.Unmarshal(e.Meta, &tx.MetaBlob); err != nil {
+			return tx, err
+		}
+	} else if len(e.Meta) > 0 {
+		if err := json.Unmarshal(e.Meta, &tx.Meta); err != nil {
+			return tx, fmt.Errorf("invalid metadata of transaction %s: %w", tx.Hash, err)
+		}
+	}
+	return tx, nil
+}
+
+// Retrieve a single page of the transaction history of an account. The
+// Marker of the result, which may be persisted, requests the next page.
+//
+// Example usage:
+//
+//	page, err := client.AccountTxPage(account, xrpl.AccountTxLimit(100))
+//	if err != nil {
+//		return err
+//	}
+//	// Later, e.g. after restoring page.Marker:
+//	next, err := client.AccountTxPage(account,
+//		xrpl.AccountTxLimit(100), xrpl.AccountTxMarker(page.Marker))
+func (c *Client) AccountTxPage(account string, opts ...AccountTxOption) (*AccountTxResult, error) {
+	return c.accountTxPage(accountTxRequest(account, opts))
+}
+
+// accountTxRequest builds an account_tx request for account
+func accountTxRequest(account string, opts []AccountTxOption) BaseRequest {
+	req := BaseRequest{
+		"command":          "account_tx",
+		"account":          account,
+		"ledger_index_min": -1,
+		"ledger_index_max": -1,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	return req
+}
+
+// accountTxPage requests the page of transactions of req
+func (c *Client) accountTxPage(req BaseRequest) (*AccountTxResult, error) {
+	var result struct {
+		Account        string           `json:"account"`
+		LedgerIndexMin int64            `json:"ledger_index_min"`
+		LedgerIndexMax int64            `json:"ledger_index_max"`
+		Transactions   []accountTxEntry `json:"transactions"`
+		Marker         Marker           `json:"marker"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+
+	page := &AccountTxResult{
+		Account:        result.Account,
+		LedgerIndexMin: result.LedgerIndexMin,
+		LedgerIndexMax: result.LedgerIndexMax,
+		Transactions:   make([]TxWithMeta, 0, len(result.Transactions)),
+		Marker:         result.Marker,
+	}
+	for i := range result.Transactions {
+		tx, err := result.Transactions[i].txWithMeta()
+		if err != nil {
+			return nil, err
+		}
+		page.Transactions = append(page.Transactions, tx)
+	}
+	return page, nil
+}
+
+// accountTxPages requests pages of transactions and passes each to yield
+// until the last page or until yield returns false.
+func (c *Client) accountTxPages(account string, opts []AccountTxOption, yield func([]TxWithMeta) bool) error {
+	req := accountTxRequest(account, opts)
+	for {
+		page, err := c.accountTxPage(req)
+		if err != nil {
+			return err
+		}
+		if !yield(page.Transactions) || page.Marker.IsZero() {
+			return nil
+		}
+		req["marker"] = page.Marker
+	}
+}
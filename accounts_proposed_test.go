@@ -0,0 +1,109 @@
+package xrpl
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSubscribeAccountsProposed(t *testing.T) {
+	var mutex sync.Mutex
+	var requests []BaseRequest
+	transport := transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		requests = append(requests, req)
+		return BaseResponse{"status": "success", "result": map[string]interface{}{}}, nil
+	})
+	client := NewClient(ClientConfig{URL: "custom://", Transport: transport}, WithLogger(NopLogger()))
+
+	const account = "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"
+	proposed := make(chan BaseResponse, 4)
+	validated := make(chan BaseResponse, 4)
+	if _, err := client.SubscribeAccountsProposed([]string{account}, func(msg BaseResponse) { proposed <- msg }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SubscribeAccounts([]string{account}, func(msg BaseResponse) { validated <- msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	message := func(isValidated bool) BaseResponse {
+		return BaseResponse{
+			"type":      "transaction",
+			"validated": isValidated,
+			"transaction": map[string]interface{}{
+				"TransactionType": "Payment",
+				"Account":         account,
+				"Destination":     "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+				"Amount":          "1000000",
+			},
+		}
+	}
+	receive := func(ch chan BaseResponse, name string) BaseResponse {
+		select {
+		case msg := <-ch:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s handler: no message", name)
+			return nil
+		}
+	}
+
+	// A proposed transaction only reaches the proposed handler, a validated
+	// one reaches both
+	client.dispatchStream(message(false))
+	if msg := receive(proposed, "proposed"); msg["validated"] != false {
+		t.Errorf("proposed handler got %v, want the proposed transaction", msg)
+	}
+	client.dispatchStream(message(true))
+	if msg := receive(proposed, "proposed"); msg["validated"] != true {
+		t.Errorf("proposed handler got %v, want the validated transaction", msg)
+	}
+	if msg := receive(validated, "validated"); msg["validated"] != true {
+		t.Errorf("validated handler got %v, want the validated transaction", msg)
+	}
+	select {
+	case msg := <-validated:
+		t.Errorf("validated handler got a second message %v", msg)
+	default:
+	}
+
+	client.resubscribeAccountsAndBooks()
+	mutex.Lock()
+	replay := requests[len(requests)-1]
+	mutex.Unlock()
+	want := BaseRequest{
+		"command":           "subscribe",
+		"accounts":          []string{account},
+		"accounts_proposed": []string{account},
+	}
+	delete(replay, "id")
+	if !reflect.DeepEqual(replay, want) {
+		t.Errorf("replayed %v, want %v", replay, want)
+	}
+
+	if _, err := client.UnsubscribeAccountsProposed([]string{account}); err != nil {
+		t.Fatal(err)
+	}
+	mutex.Lock()
+	unsubscribe := requests[len(requests)-1]
+	mutex.Unlock()
+	if unsubscribe["command"] != "unsubscribe" || !reflect.DeepEqual(unsubscribe["accounts_proposed"], []string{account}) {
+		t.Errorf("unsubscribe request %v", unsubscribe)
+	}
+	client.dispatchStream(message(false))
+	client.resubscribeAccountsAndBooks()
+	mutex.Lock()
+	replay = requests[len(requests)-1]
+	mutex.Unlock()
+	if _, ok := replay["accounts_proposed"]; ok {
+		t.Errorf("replayed %v after unsubscribing", replay)
+	}
+	select {
+	case msg := <-proposed:
+		t.Errorf("proposed handler got %v after unsubscribing", msg)
+	default:
+	}
+}
@@ -0,0 +1,67 @@
+package xrpl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by ValidateClassicAddress
+var (
+	ErrAddressChecksum = ErrChecksumMismatch
+	ErrAddressVersion  = errors.New("address version byte is not an account prefix")
+	ErrAddressLength   = errors.New("address payload is not 20 bytes")
+)
+
+// ValidateClassicAddress checks that addr is a well formed classic address:
+// valid base58 with a matching checksum, the account version prefix and a 20
+// byte account ID. The returned error wraps ErrAddressChecksum,
+// ErrAddressVersion or ErrAddressLength where applicable.
+func ValidateClassicAddress(addr string) error {
+	_, err := decodeAccountID(addr)
+	return err
+}
+
+// IsValidClassicAddress reports whether addr is a well formed classic address
+func IsValidClassicAddress(addr string) bool {
+	return ValidateClassicAddress(addr) == nil
+}
+
+// DecodeAccountID converts a classic address into the 20 byte account ID it
+// encodes, as used in binary transactions and ledger objects. Errors are
+// those of ValidateClassicAddress.
+func DecodeAccountID(addr string) ([20]byte, error) {
+	var id [20]byte
+	payload, err := decodeAccountID(addr)
+	if err != nil {
+		return id, err
+	}
+	copy(id[:], payload)
+	return id, nil
+}
+
+// EncodeAccountID converts a 20 byte account ID into its classic address
+func EncodeAccountID(id [20]byte) string {
+	return encodeAccountID(id[:])
+}
+
+// decodeAccountID converts a classic address into its 20 byte account ID
+func decodeAccountID(addr string) ([]byte, error) {
+	b58 := NewBase58()
+	version, payload, err := b58.DecodeCheck(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+	if version != accountIDPrefix[0] {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, ErrAddressVersion)
+	}
+	if len(payload) != 20 {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, ErrAddressLength)
+	}
+	return payload, nil
+}
+
+// encodeAccountID converts a 20 byte account ID into its classic address
+func encodeAccountID(accountID []byte) string {
+	b58 := NewBase58()
+	return b58.EncodeCheck(accountIDPrefix[0], accountID)
+}
@@ -0,0 +1,380 @@
+package xrpl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Highest AMM trading fee, in units of 1/100,000: a fee of 1%
+const ammMaxTradingFee = 1000
+
+// AMMVoteSlot is a liquidity provider's vote on the trading fee of an AMM
+type AMMVoteSlot struct {
+	Account    string `json:"account"`
+	TradingFee uint16 `json:"trading_fee"` // in units of 1/100,000
+	VoteWeight uint32 `json:"vote_weight"` // in units of 1/100,000 of the AMM's LP tokens
+}
+
+// AMMAuctionSlot is the holder of the auction slot of an AMM, who trades
+// against it at the discounted fee until the slot expires
+type AMMAuctionSlot struct {
+	Account       string           `json:"account"`
+	AuthAccounts  []AMMAuthAccount `json:"auth_accounts"`  // also trading at the discounted fee
+	DiscountedFee uint16           `json:"discounted_fee"` // in units of 1/100,000
+	Expiration    string           `json:"expiration"`     // as reported, e.g. "2024-Jan-01 00:00:00.000000000 UTC"
+	Price         Amount           `json:"price"`          // LP tokens paid for the slot
+	TimeInterval  uint32           `json:"time_interval"`  // 0 to 20, interval of the slot's 24 hours it was bought in
+}
+
+// AMMAuthAccount is an account the holder of an auction slot shares it with
+type AMMAuthAccount struct {
+	Account string `json:"account"`
+}
+
+// AMMInfoResult is an AMM as returned by the amm_info command: its pool
+// balances, the LP token it issues and its trading fee
+type AMMInfoResult struct {
+	Account      string          `json:"account"` // the AMM's own account, issuer of its LP tokens
+	Amount       Amount          `json:"amount"`  // pool balance of the first asset
+	Amount2      Amount          `json:"amount2"` // pool balance of the second asset
+	AssetFrozen  bool            `json:"asset_frozen"`
+	Asset2Frozen bool            `json:"asset2_frozen"`
+	LPToken      Amount          `json:"lp_token"`    // LP tokens outstanding
+	TradingFee   uint16          `json:"trading_fee"` // in units of 1/100,000
+	AuctionSlot  *AMMAuctionSlot `json:"auction_slot,omitempty"`
+	VoteSlots    []AMMVoteSlot   `json:"vote_slots"`
+	LedgerIndex  uint32          `json:"-"` // ledger the AMM was read from
+}
+
+// AMMInfoOption configures an amm_info request
+type AMMInfoOption func(req BaseRequest)
+
+// AMMInfoLedgerIndex selects the ledger to read the AMM from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func AMMInfoLedgerIndex(ledger LedgerSpecifier) AMMInfoOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// Retrieve the AMM trading asset and asset2, in either order. The assets are
+// identified by their currencies; their values are ignored. An asset pair
+// without an AMM is reported as an XRPLError for actNotFound.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "0")
+//	if err != nil {
+//		return err
+//	}
+//	amm, err := client.AMMInfo(xrpl.XRPAmount("0"), usd)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(amm.Amount, amm.Amount2, amm.LPToken, amm.TradingFee)
+func (c *Client) AMMInfo(asset, asset2 Amount, opts ...AMMInfoOption) (*AMMInfoResult, error) {
+	if err := validateAMMAssets(asset, asset2); err != nil {
+		return nil, err
+	}
+	req := BaseRequest{
+		"command":      "amm_info",
+		"asset":        bookCurrency(asset),
+		"asset2":       bookCurrency(asset2),
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		AMM                AMMInfoResult `json:"amm"`
+		LedgerIndex        uint32        `json:"ledger_index"`
+		LedgerCurrentIndex uint32        `json:"ledger_current_index"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+	amm := result.AMM
+	amm.LedgerIndex = result.LedgerIndex
+	if amm.LedgerIndex == 0 {
+		amm.LedgerIndex = result.LedgerCurrentIndex
+	}
+	return &amm, nil
+}
+
+// ammMode is one of the mutually exclusive modes of an AMMDeposit or
+// AMMWithdraw: its flag and the fields it sets
+type ammMode struct {
+	name       string
+	flag       uint32
+	fields     []ammField
+	tradingFee *uint16
+}
+
+type ammField struct {
+	name   string
+	amount Amount
+}
+
+// AMMDepositOption selects the mode of BuildAMMDeposit. Exactly one is
+// needed.
+type AMMDepositOption func(*[]ammMode)
+
+// AMMDepositLPToken deposits both assets in the pool's proportions to
+// receive exactly lpTokenOut LP tokens, TfLPToken
+func AMMDepositLPToken(lpTokenOut Amount) AMMDepositOption {
+	return ammDepositMode("LPToken", TfLPToken, ammField{"LPTokenOut", lpTokenOut})
+}
+
+// AMMDepositSingleAsset deposits exactly amount of one of the assets,
+// receiving the LP tokens it is worth, TfSingleAsset
+func AMMDepositSingleAsset(amount Amount) AMMDepositOption {
+	return ammDepositMode("SingleAsset", TfSingleAsset, ammField{"Amount", amount})
+}
+
+// AMMDepositTwoAsset deposits up to amount and amount2, one of each asset, in
+// the pool's proportions, TfTwoAsset
+func AMMDepositTwoAsset(amount, amount2 Amount) AMMDepositOption {
+	return ammDepositMode("TwoAsset", TfTwoAsset, ammField{"Amount", amount}, ammField{"Amount2", amount2})
+}
+
+// AMMDepositOneAssetLPToken deposits up to amount of one of the assets to
+// receive exactly lpTokenOut LP tokens, TfOneAssetLPToken
+func AMMDepositOneAssetLPToken(amount, lpTokenOut Amount) AMMDepositOption {
+	return ammDepositMode("OneAssetLPToken", TfOneAssetLPToken, ammField{"Amount", amount}, ammField{"LPTokenOut", lpTokenOut})
+}
+
+// AMMDepositLimitLPToken deposits up to amount of one of the assets, paying
+// at most ePrice of that asset per LP token received, TfLimitLPToken
+func AMMDepositLimitLPToken(amount, ePrice Amount) AMMDepositOption {
+	return ammDepositMode("LimitLPToken", TfLimitLPToken, ammField{"Amount", amount}, ammField{"EPrice", ePrice})
+}
+
+// AMMDepositTwoAssetIfEmpty refills an AMM whose LP tokens were all
+// withdrawn with amount and amount2, one of each asset, setting its trading
+// fee, in units of 1/100,000, TfTwoAssetIfEmpty
+func AMMDepositTwoAssetIfEmpty(amount, amount2 Amount, tradingFee uint16) AMMDepositOption {
+	return func(modes *[]ammMode) {
+		*modes = append(*modes, ammMode{
+			name:       "TwoAssetIfEmpty",
+			flag:       TfTwoAssetIfEmpty,
+			fields:     []ammField{{"Amount", amount}, {"Amount2", amount2}},
+			tradingFee: &tradingFee,
+		})
+	}
+}
+
+func ammDepositMode(name string, flag uint32, fields ...ammField) AMMDepositOption {
+	return func(modes *[]ammMode) {
+		*modes = append(*modes, ammMode{name: name, flag: flag, fields: fields})
+	}
+}
+
+// BuildAMMDeposit builds an AMMDeposit transaction from account, ready to be
+// autofilled and signed, adding liquidity to the AMM trading asset and
+// asset2 in exchange for its LP tokens. The assets are identified by their
+// currencies; their values are ignored. The deposit mode is given by exactly
+// one option, such as AMMDepositTwoAsset.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "5")
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildAMMDeposit(wallet.ClassicAddress, xrpl.XRPAmount("0"), usd,
+//		xrpl.AMMDepositTwoAsset(xrpl.XRPAmount("10000000"), usd))
+func BuildAMMDeposit(account string, asset, asset2 Amount, opts ...AMMDepositOption) (map[string]interface{}, error) {
+	var modes []ammMode
+	for _, opt := range opts {
+		opt(&modes)
+	}
+	return buildAMMTransaction(TxTypeAMMDeposit, account, asset, asset2, modes)
+}
+
+// AMMWithdrawOption selects the mode of BuildAMMWithdraw. Exactly one is
+// needed.
+type AMMWithdrawOption func(*[]ammMode)
+
+// AMMWithdrawLPToken returns exactly lpTokenIn LP tokens, receiving both
+// assets in the pool's proportions, TfLPToken
+func AMMWithdrawLPToken(lpTokenIn Amount) AMMWithdrawOption {
+	return ammWithdrawMode("LPToken", TfLPToken, ammField{"LPTokenIn", lpTokenIn})
+}
+
+// AMMWithdrawAll returns all of the account's LP tokens, receiving both
+// assets in the pool's proportions, TfWithdrawAll
+func AMMWithdrawAll() AMMWithdrawOption {
+	return ammWithdrawMode("WithdrawAll", TfWithdrawAll)
+}
+
+// AMMWithdrawTwoAsset receives up to amount and amount2, one of each asset,
+// in the pool's proportions, TfTwoAsset
+func AMMWithdrawTwoAsset(amount, amount2 Amount) AMMWithdrawOption {
+	return ammWithdrawMode("TwoAsset", TfTwoAsset, ammField{"Amount", amount}, ammField{"Amount2", amount2})
+}
+
+// AMMWithdrawSingleAsset receives exactly amount of one of the assets,
+// returning the LP tokens it is worth, TfSingleAsset
+func AMMWithdrawSingleAsset(amount Amount) AMMWithdrawOption {
+	return ammWithdrawMode("SingleAsset", TfSingleAsset, ammField{"Amount", amount})
+}
+
+// AMMWithdrawOneAssetAll returns all of the account's LP tokens, receiving
+// only the asset of amount and at least its value, which may be zero,
+// TfOneAssetWithdrawAll
+func AMMWithdrawOneAssetAll(amount Amount) AMMWithdrawOption {
+	return ammWithdrawMode("OneAssetWithdrawAll", TfOneAssetWithdrawAll, ammField{"Amount", amount})
+}
+
+// AMMWithdrawOneAssetLPToken returns exactly lpTokenIn LP tokens, receiving
+// only the asset of amount and at least its value, TfOneAssetLPToken
+func AMMWithdrawOneAssetLPToken(amount, lpTokenIn Amount) AMMWithdrawOption {
+	return ammWithdrawMode("OneAssetLPToken", TfOneAssetLPToken, ammField{"Amount", amount}, ammField{"LPTokenIn", lpTokenIn})
+}
+
+// AMMWithdrawLimitLPToken receives up to amount of one of the assets,
+// returning LP tokens for no less than ePrice of that asset each,
+// TfLimitLPToken
+func AMMWithdrawLimitLPToken(amount, ePrice Amount) AMMWithdrawOption {
+	return ammWithdrawMode("LimitLPToken", TfLimitLPToken, ammField{"Amount", amount}, ammField{"EPrice", ePrice})
+}
+
+func ammWithdrawMode(name string, flag uint32, fields ...ammField) AMMWithdrawOption {
+	return func(modes *[]ammMode) {
+		*modes = append(*modes, ammMode{name: name, flag: flag, fields: fields})
+	}
+}
+
+// BuildAMMWithdraw builds an AMMWithdraw transaction from account, ready to
+// be autofilled and signed, returning LP tokens of the AMM trading asset and
+// asset2 in exchange for its assets. The assets are identified by their
+// currencies; their values are ignored. The withdrawal mode is given by
+// exactly one option, such as AMMWithdrawAll.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "0")
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildAMMWithdraw(wallet.ClassicAddress, xrpl.XRPAmount("0"), usd,
+//		xrpl.AMMWithdrawOneAssetAll(xrpl.XRPAmount("0")))
+func BuildAMMWithdraw(account string, asset, asset2 Amount, opts ...AMMWithdrawOption) (map[string]interface{}, error) {
+	var modes []ammMode
+	for _, opt := range opts {
+		opt(&modes)
+	}
+	return buildAMMTransaction(TxTypeAMMWithdraw, account, asset, asset2, modes)
+}
+
+// buildAMMTransaction builds an AMMDeposit or AMMWithdraw of the single mode
+// in modes, checking that its amounts belong to the AMM's assets
+func buildAMMTransaction(txType TransactionType, account string, asset, asset2 Amount, modes []ammMode) (map[string]interface{}, error) {
+	if err := validateAMMAssets(asset, asset2); err != nil {
+		return nil, err
+	}
+	if len(modes) == 0 {
+		return nil, fmt.Errorf("%s needs a mode", txType)
+	}
+	if len(modes) > 1 {
+		names := make([]string, len(modes))
+		for i, mode := range modes {
+			names[i] = mode.name
+		}
+		return nil, fmt.Errorf("%s needs exactly one mode, got %s", txType, strings.Join(names, ", "))
+	}
+	mode := modes[0]
+
+	tx := map[string]interface{}{
+		"TransactionType": string(txType),
+		"Account":         account,
+		"Asset":           bookCurrency(asset),
+		"Asset2":          bookCurrency(asset2),
+		"Flags":           FlagsFor(txType).Set(mode.flag),
+	}
+	for _, field := range mode.fields {
+		if err := validateAMMField(mode, field, asset, asset2); err != nil {
+			return nil, fmt.Errorf("invalid %s %s: %w", txType, field.name, err)
+		}
+		tx[field.name] = field.amount
+	}
+	if len(mode.fields) == 2 && mode.fields[1].name == "Amount2" &&
+		sameAsset(mode.fields[0].amount, mode.fields[1].amount) {
+		return nil, fmt.Errorf("%s Amount and Amount2 must be of different assets", txType)
+	}
+	if mode.tradingFee != nil {
+		if *mode.tradingFee > ammMaxTradingFee {
+			return nil, fmt.Errorf("%s TradingFee out of bounds: %d, at most %d", txType, *mode.tradingFee, ammMaxTradingFee)
+		}
+		tx["TradingFee"] = *mode.tradingFee
+	}
+	return tx, nil
+}
+
+// validateAMMField checks an amount set by mode: Amount and Amount2 must be
+// of one of the AMM's assets, LP token amounts must be tokens and EPrice
+// must be of the asset of Amount
+func validateAMMField(mode ammMode, field ammField, asset, asset2 Amount) error {
+	if field.name == "Amount" && mode.flag == TfOneAssetWithdrawAll {
+		if err := field.amount.Validate(); err != nil {
+			return err
+		}
+		if value, _ := field.amount.Rat(); value.Sign() < 0 {
+			return fmt.Errorf("%s cannot be negative", field.amount)
+		}
+	} else if err := validatePositiveAmount(field.amount); err != nil {
+		return err
+	}
+
+	switch field.name {
+	case "Amount", "Amount2":
+		if !sameAsset(field.amount, asset) && !sameAsset(field.amount, asset2) {
+			return fmt.Errorf("%s is not one of the AMM's assets", field.amount)
+		}
+	case "LPTokenOut", "LPTokenIn":
+		if field.amount.IsXRP() {
+			return fmt.Errorf("LP tokens cannot be XRP")
+		}
+	case "EPrice":
+		if !sameAsset(field.amount, mode.fields[0].amount) {
+			return fmt.Errorf("%s is not in the asset of Amount %s", field.amount, mode.fields[0].amount)
+		}
+	}
+	return nil
+}
+
+// validateAMMAssets checks the currencies of the two assets of an AMM, which
+// must differ
+func validateAMMAssets(asset, asset2 Amount) error {
+	for _, a := range []Amount{asset, asset2} {
+		if a.IsXRP() {
+			continue
+		}
+		if _, err := EncodeCurrency(a.Currency()); err != nil {
+			return fmt.Errorf("invalid AMM asset: %w", err)
+		}
+		if err := ValidateClassicAddress(a.Issuer()); err != nil {
+			return fmt.Errorf("invalid AMM asset issuer %q: %w", a.Issuer(), err)
+		}
+	}
+	if sameAsset(asset, asset2) {
+		return fmt.Errorf("AMM assets cannot both be %s", assetString(asset))
+	}
+	return nil
+}
+
+// sameAsset reports whether a and b are amounts of the same currency and
+// issuer
+func sameAsset(a, b Amount) bool {
+	return a.Currency() == b.Currency() && a.Issuer() == b.Issuer()
+}
+
+// assetString returns the currency of an amount as "XRP" or
+// "currency/issuer"
+func assetString(a Amount) string {
+	if a.IsXRP() {
+		return XRPL_NATIVE_ASSET
+	}
+	return a.Currency() + "/" + a.Issuer()
+}
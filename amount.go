@@ -0,0 +1,240 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Most significant digits a token value may have. rippled stores token values
+// with a 16 digit mantissa, but only 15 digits of precision are guaranteed.
+// https://xrpl.org/docs/references/protocol/data-types/currency-formats#token-precision
+const tokenMaxPrecision = 15
+
+var signedDropsPattern = regexp.MustCompile(`^-?[0-9]+$`)
+
+// Amount is an XRPL amount, which is either an integer number of XRP drops or
+// a value of a token issued by an account. In JSON an XRP amount is a string
+// of drops, e.g. "1500000", and a token amount is an object with currency,
+// issuer and value fields. The zero Amount is empty and encodes as null.
+type Amount struct {
+	currency string // Empty for XRP
+	issuer   string
+	value    string
+}
+
+// XRPAmount returns an amount of XRP given as an integer number of drops
+func XRPAmount(drops string) Amount {
+	return Amount{value: drops}
+}
+
+// TokenAmount returns an amount of the token currency issued by issuer. The
+// value is a decimal string such as "1.25" or "-2e-3". It is not checked.
+//
+// Deprecated: use NewTokenAmount, which rejects malformed amounts right away
+// instead of leaving them to Validate or the server.
+func TokenAmount(currency, issuer, value string) Amount {
+	return Amount{currency: currency, issuer: issuer, value: value}
+}
+
+// NewTokenAmount returns an amount of the token currency issued by issuer,
+// with its value normalized by NormalizeTokenValue. The value is a decimal
+// string such as "1.25" or "-2e-3". The amount is validated, so malformed
+// currencies, issuers and values are reported here rather than by the server.
+func NewTokenAmount(currency, issuer, value string) (Amount, error) {
+	amount := Amount{currency: currency, issuer: issuer, value: value}
+	if err := amount.Validate(); err != nil {
+		return Amount{}, err
+	}
+	amount.value, _ = NormalizeTokenValue(value)
+	return amount, nil
+}
+
+// ledgerTokenAmount returns a token amount computed from values the server
+// reported, checking its currency and issuer and that its value is a
+// decimal. Unlike NewTokenAmount it accepts values with more than 15
+// significant digits: rippled reports values of 16, and differences of
+// balances may have more.
+func ledgerTokenAmount(currency, issuer, value string) (Amount, error) {
+	if _, err := EncodeCurrency(currency); err != nil {
+		return Amount{}, err
+	}
+	if err := ValidateClassicAddress(issuer); err != nil {
+		return Amount{}, fmt.Errorf("invalid token issuer %q: %w", issuer, err)
+	}
+	if _, _, _, err := parseDecimal(value); err != nil {
+		return Amount{}, err
+	}
+	return Amount{currency: currency, issuer: issuer, value: value}, nil
+}
+
+// NormalizeTokenValue checks a token value against the XRPL precision rules,
+// at most 15 significant digits and a magnitude from 1e-81 to about 1e96,
+// and returns it in the canonical text form rippled reports values in: a
+// plain decimal without superfluous zeros, such as "1.5" for "01.500", or
+// the 16 digit mantissa and its exponent, such as "1000000000000000e-96",
+// for values too large or small to write out. Values rippled would round,
+// or round down to zero, are rejected.
+func NormalizeTokenValue(value string) (string, error) {
+	negative, digits, exponent, err := parseDecimal(value)
+	if err != nil {
+		return "", err
+	}
+	if len(digits.String()) > tokenMaxPrecision {
+		return "", fmt.Errorf("token value %s has more than %d significant digits", value, tokenMaxPrecision)
+	}
+	mantissa, exponent, err := normalizeTokenMantissa(digits, exponent)
+	if err != nil {
+		return "", fmt.Errorf("invalid token value %s: %w", value, err)
+	}
+	if mantissa == 0 {
+		return "0", nil
+	}
+
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	raw := strconv.FormatUint(mantissa, 10)
+	// rippled's STAmount::getText switches to scientific notation outside
+	// this exponent range
+	if exponent != 0 && (exponent < -25 || exponent > -5) {
+		return fmt.Sprintf("%s%se%d", sign, raw, exponent), nil
+	}
+	if exponent == 0 {
+		return sign + raw, nil
+	}
+	// The mantissa has 16 digits and exponent is -5 to -25, so the value
+	// has fewer than 12 integer digits
+	padded := strings.Repeat("0", -exponent) + raw
+	point := len(padded) + exponent
+	integer := strings.TrimLeft(padded[:point], "0")
+	fraction := strings.TrimRight(padded[point:], "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if fraction == "" {
+		return sign + integer, nil
+	}
+	return sign + integer + "." + fraction, nil
+}
+
+// IsXRP reports whether the amount is denominated in XRP drops
+func (a Amount) IsXRP() bool {
+	return a.currency == ""
+}
+
+// Currency returns the token currency code, or "XRP" for XRP amounts
+func (a Amount) Currency() string {
+	if a.IsXRP() {
+		return XRPL_NATIVE_ASSET
+	}
+	return a.currency
+}
+
+// Issuer returns the token issuer, or the empty string for XRP amounts
+func (a Amount) Issuer() string {
+	return a.issuer
+}
+
+// Value returns the amount's value as given: the number of drops for XRP
+// amounts and the decimal token value otherwise.
+func (a Amount) Value() string {
+	return a.value
+}
+
+// Rat returns the amount's value as an exact rational number, in drops for
+// XRP amounts. Use it to compare or sum amounts without float rounding.
+func (a Amount) Rat() (*big.Rat, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	r, ok := new(big.Rat).SetString(a.value)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount value %q", a.value)
+	}
+	return r, nil
+}
+
+// String returns the number of drops for XRP amounts and value/currency/issuer
+// for token amounts, e.g. "1.5/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B".
+func (a Amount) String() string {
+	if a.IsXRP() {
+		return a.value
+	}
+	return fmt.Sprintf("%s/%s/%s", a.value, a.currency, a.issuer)
+}
+
+// Validate checks the amount against the XRPL amount rules. XRP amounts must
+// be an integer number of drops not exceeding MaxDrops. Token amounts need a
+// valid currency code other than XRP, a valid issuer address and a value of
+// at most 15 significant digits within the token exponent range.
+func (a Amount) Validate() error {
+	if a.IsXRP() {
+		if !signedDropsPattern.MatchString(a.value) {
+			return fmt.Errorf("invalid XRP amount %q: must be an integer number of drops", a.value)
+		}
+		drops, _ := new(big.Int).SetString(a.value, 10)
+		return checkMaxDrops(drops.Abs(drops))
+	}
+
+	if _, err := EncodeCurrency(a.currency); err != nil {
+		return err
+	}
+	if err := ValidateClassicAddress(a.issuer); err != nil {
+		return fmt.Errorf("invalid token issuer %q: %w", a.issuer, err)
+	}
+	_, err := NormalizeTokenValue(a.value)
+	return err
+}
+
+type tokenAmountJSON struct {
+	Currency string `json:"currency"`
+	Issuer   string `json:"issuer"`
+	Value    string `json:"value"`
+}
+
+// MarshalJSON encodes the amount in the XRPL JSON format. The amount is not
+// validated, so that amounts decoded from responses encode back unchanged;
+// call Validate to check amounts built for new transactions.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	if a == (Amount{}) {
+		return []byte("null"), nil
+	}
+	if a.IsXRP() {
+		return json.Marshal(a.value)
+	}
+	return json.Marshal(tokenAmountJSON{Currency: a.currency, Issuer: a.issuer, Value: a.value})
+}
+
+// UnmarshalJSON decodes a drops string or a token amount object. The amount
+// is not validated, so amounts reported by the server are always decoded.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*a = Amount{}
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var drops string
+		if err := json.Unmarshal(data, &drops); err != nil {
+			return err
+		}
+		*a = XRPAmount(drops)
+		return nil
+	default:
+		var token tokenAmountJSON
+		if err := json.Unmarshal(data, &token); err != nil {
+			return fmt.Errorf("invalid amount %s: %w", data, err)
+		}
+		if token.Currency == "" {
+			return fmt.Errorf("invalid amount %s: missing currency", data)
+		}
+		*a = Amount{currency: token.Currency, issuer: token.Issuer, value: token.Value}
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package xrpl
+
+import "testing"
+
+func TestNormalizeTokenValue(t *testing.T) {
+	tests := []struct {
+		value      string
+		normalized string
+	}{
+		{"1", "1"},
+		{"01.500", "1.5"},
+		{"1.000", "1"},
+		{"-1.5", "-1.5"},
+		{"+2.50", "2.5"},
+		{".5", "0.5"},
+		{"5.", "5"},
+		{"0", "0"},
+		{"-0.000", "0"},
+		{"0e10", "0"},
+		{"1.25E3", "1250"},
+		// 15 significant digits, trailing zeros aside
+		{"0.000123456789012345", "0.000123456789012345"},
+		{"12345678901.2345", "12345678901.2345"},
+		// Plain decimals from exponent -25 to -5 of the 16 digit mantissa,
+		// as rippled writes them
+		{"123456789012345", "1234567890123450e-1"},
+		{"1234567890123450000", "1234567890123450e3"},
+		{"0.00001", "0.00001"},
+		{"0.0000000001", "0.0000000001"},
+		{"0.00000000001", "1000000000000000e-26"},
+		{"99999999999", "99999999999"},
+		{"100000000000", "1000000000000000e-4"},
+		// Exponent range
+		{"1e-81", "1000000000000000e-96"},
+		{"-1e-81", "-1000000000000000e-96"},
+		{"1e95", "1000000000000000e80"},
+		{"999999999999999e81", "9999999999999990e80"},
+	}
+	for _, test := range tests {
+		got, err := NormalizeTokenValue(test.value)
+		if err != nil {
+			t.Errorf("NormalizeTokenValue(%q): %v", test.value, err)
+		} else if got != test.normalized {
+			t.Errorf("NormalizeTokenValue(%q) = %q, want %q", test.value, got, test.normalized)
+		}
+	}
+}
+
+func TestNormalizeTokenValueInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		".",
+		"-",
+		"abc",
+		"1.2.3",
+		"1e",
+		"0x10",
+		// More than 15 significant digits
+		"1234567890123456",
+		"1.234567890123456",
+		"0.0000000000000000001234567890123456",
+		// Outside of the exponent range
+		"1e-82",
+		"9e-82",
+		"1e96",
+		"-1e96",
+	} {
+		if got, err := NormalizeTokenValue(value); err == nil {
+			t.Errorf("NormalizeTokenValue(%q) = %q, want an error", value, got)
+		}
+	}
+}
+
+func TestNewTokenAmount(t *testing.T) {
+	amount, err := NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "01.500")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Currency() != "USD" || amount.Issuer() != "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B" || amount.Value() != "1.5" {
+		t.Errorf("NewTokenAmount = %s %s %s, want USD rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B 1.5", amount.Currency(), amount.Issuer(), amount.Value())
+	}
+
+	for _, test := range []struct{ currency, issuer, value string }{
+		{"XRP", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "1"},
+		{"US", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "1"},
+		{"USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59C", "1"},
+		{"USD", "", "1"},
+		{"USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "1234567890123456"},
+		{"USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "ten"},
+	} {
+		if amount, err := NewTokenAmount(test.currency, test.issuer, test.value); err == nil {
+			t.Errorf("NewTokenAmount(%q, %q, %q) = %v, want an error", test.currency, test.issuer, test.value, amount)
+		}
+	}
+}
+
+func TestLedgerTokenAmount(t *testing.T) {
+	// rippled reports values with 16 significant digits
+	amount, err := ledgerTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "0.3333333333333333")
+	if err != nil || amount.Value() != "0.3333333333333333" {
+		t.Errorf("ledgerTokenAmount of 16 digits = %v, %v", amount, err)
+	}
+	if amount, err := ledgerTokenAmount("USD", "not an address", "1"); err == nil {
+		t.Errorf("ledgerTokenAmount with an invalid issuer = %v, want an error", amount)
+	}
+}
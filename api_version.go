@@ -0,0 +1,50 @@
+package xrpl
+
+import "fmt"
+
+// Versions of the rippled API
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+)
+
+// WithAPIVersion sets the api_version of every request the client sends,
+// including those of the typed helpers, unless the request sets its own.
+// Without it the server's default version applies, which servers may change
+// over time.
+//
+// The typed helpers decode the response shapes of both versions, e.g. the
+// tx_json and meta fields of transactions in v2 against their v1 layout in
+// AccountTx and Ledger, the signer lists of AccountInfo and the numeric
+// ledger indexes of v2. Responses of Request are returned as the server
+// sends them, so code reading them directly depends on the version, as do
+// error names, which v2 made more specific for some commands; XRPLError
+// reports errors of either version.
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config, xrpl.WithAPIVersion(xrpl.APIVersion2))
+func WithAPIVersion(version int) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// validateAPIVersion checks the version set with WithAPIVersion, 0 being
+// the server's default
+func validateAPIVersion(version int) error {
+	if version < 0 {
+		return fmt.Errorf("api_version out of bounds: %d", version)
+	}
+	return nil
+}
+
+// setAPIVersion adds the client's api_version to req unless it has one
+func (c *Client) setAPIVersion(req BaseRequest) {
+	if c.apiVersion == 0 {
+		return
+	}
+	if _, ok := req["api_version"]; !ok {
+		req["api_version"] = c.apiVersion
+	}
+}
@@ -0,0 +1,161 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// ErrFeeAboveMax is returned by EstimateFee and Autofill when the fee a
+// transaction requires is above ClientConfig.MaxFeeXRP
+var ErrFeeAboveMax = errors.New("required fee above MaxFeeXRP")
+
+// Autofill populates the Sequence, Fee and LastLedgerSequence fields of a
+// transaction that are required for submission but absent from tx. Fields
+// already set by the caller are left untouched. An Account that does not
+// exist yet fails with an error matching ErrAccountNotFound.
+//
+//   - Sequence is the next sequence number of the transaction's Account, read
+//     from the current open ledger.
+//   - Fee is the open ledger fee reported by the fee command, scaled for
+//     the transaction type and its Signers as described in EstimateFee. A
+//     fee above ClientConfig.MaxFeeXRP, when set, fails with an error
+//     matching ErrFeeAboveMax.
+//   - LastLedgerSequence is the latest validated ledger index plus
+//     ClientConfig.LastLedgerOffset.
+func (c *Client) Autofill(tx map[string]interface{}) error {
+	account, ok := tx["Account"].(string)
+	if !ok || account == "" {
+		return fmt.Errorf("transaction has no Account")
+	}
+	if err := validateTxFlags(tx); err != nil {
+		return err
+	}
+
+	if _, ok := tx["Sequence"]; !ok {
+		info, err := c.AccountInfo(account, AccountInfoLedgerIndex(LedgerCurrent()))
+		if errors.Is(err, ErrAccountNotFound) {
+			return fmt.Errorf("failed to autofill Sequence: %s must be funded with the base reserve first: %w", account, err)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to autofill Sequence: %w", err)
+		}
+		tx["Sequence"] = info.Sequence
+	}
+
+	if _, ok := tx["Fee"]; !ok {
+		fee, err := c.EstimateFee(tx)
+		if err != nil {
+			return fmt.Errorf("failed to autofill Fee: %w", err)
+		}
+		tx["Fee"] = fee
+	}
+
+	if _, ok := tx["LastLedgerSequence"]; !ok {
+		ledgerIndex, err := c.validatedLedgerIndex(context.Background())
+		if err != nil {
+			return fmt.Errorf("failed to autofill LastLedgerSequence: %w", err)
+		}
+		tx["LastLedgerSequence"] = ledgerIndex + c.config.LastLedgerOffset
+	}
+
+	return nil
+}
+
+// validatedLedgerIndex returns the index of the latest validated ledger
+func (c *Client) validatedLedgerIndex(ctx context.Context) (uint32, error) {
+	var result struct {
+		LedgerIndex uint32 `json:"ledger_index"`
+	}
+	req := BaseRequest{
+		"command":      "ledger",
+		"ledger_index": "validated",
+	}
+	if err := c.requestResultCtx(ctx, req, &result); err != nil {
+		return 0, err
+	}
+	return result.LedgerIndex, nil
+}
+
+// EstimateFee returns the fee in drops needed to get tx into the current open
+// ledger, as Autofill sets it, without modifying tx. The network charges some
+// transactions more than the base fee of a reference transaction:
+//
+//   - A multi-signed transaction pays the base fee once more for each entry
+//     of its Signers.
+//   - An EscrowFinish with a Fulfillment pays 32 times the base fee plus once
+//     more per 16 bytes of fulfillment.
+//   - An AccountDelete pays the owner reserve increment of the latest
+//     validated ledger instead of the base fee.
+//
+// That cost is scaled by the open ledger fee escalation reported by the fee
+// command. When ClientConfig.MaxFeeXRP is set and the fee is above it, the
+// error matches ErrFeeAboveMax: a lower fee would only get the transaction
+// rejected.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildPayment(wallet.ClassicAddress, destination, amount)
+//	if err != nil {
+//		return err
+//	}
+//	fee, err := client.EstimateFee(tx)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("sending costs", fee, "drops")
+func (c *Client) EstimateFee(tx map[string]interface{}) (string, error) {
+	signers, err := signerCount(tx["Signers"])
+	if err != nil {
+		return "", err
+	}
+	result, err := c.Fee()
+	if err != nil {
+		return "", err
+	}
+	baseFee, err := strconv.ParseUint(result.BaseFee.Value(), 10, 64)
+	if err != nil || baseFee == 0 {
+		return "", fmt.Errorf("invalid base fee %q", result.BaseFee.Value())
+	}
+	openLedgerFee, err := strconv.ParseUint(result.OpenLedgerFee.Value(), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid open ledger fee %q", result.OpenLedgerFee.Value())
+	}
+
+	// Cost of tx before escalation, in drops
+	cost := new(big.Int)
+	if tx["TransactionType"] == string(TxTypeAccountDelete) {
+		reserves, err := c.ledgerReserves()
+		if err != nil {
+			return "", err
+		}
+		cost.SetUint64(reserves.ReserveInc)
+	} else {
+		cost.SetUint64(escrowFinishFeeUnits(tx) + signers)
+		cost.Mul(cost, new(big.Int).SetUint64(baseFee))
+	}
+
+	// Escalate as the open ledger fee escalates the base fee, rounding up
+	fee := cost.Mul(cost, new(big.Int).SetUint64(openLedgerFee))
+	fee.Add(fee, new(big.Int).SetUint64(baseFee-1))
+	fee.Div(fee, new(big.Int).SetUint64(baseFee))
+	if maxFee := new(big.Int).SetUint64(c.config.MaxFeeXRP * 1000000); maxFee.Sign() > 0 && fee.Cmp(maxFee) > 0 {
+		return "", fmt.Errorf("%w: %s drops required, %s allowed", ErrFeeAboveMax, fee, maxFee)
+	}
+	return fee.String(), nil
+}
+
+// signerCount returns the number of entries of a Signers field, 0 when absent
+func signerCount(value interface{}) (uint64, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case []interface{}:
+		return uint64(len(v)), nil
+	case []map[string]interface{}:
+		return uint64(len(v)), nil
+	}
+	return 0, fmt.Errorf("invalid Signers field: %T", value)
+}
@@ -0,0 +1,131 @@
+package xrpl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/andreimerlescu/xrpl-go/xrpltest"
+)
+
+// feeMock answers the fee command with a base fee of 10 drops escalated to
+// openLedgerFee, and server_state with an owner reserve of 2 XRP
+func feeMock(openLedgerFee string) *xrpltest.MockClient {
+	mock := xrpltest.NewMockClient()
+	mock.Result("fee", map[string]interface{}{
+		"drops": map[string]interface{}{
+			"base_fee":        "10",
+			"median_fee":      "5000",
+			"minimum_fee":     "10",
+			"open_ledger_fee": openLedgerFee,
+		},
+	})
+	mock.Result("server_state", map[string]interface{}{
+		"state": map[string]interface{}{
+			"validated_ledger": map[string]interface{}{
+				"reserve_base": 10000000,
+				"reserve_inc":  2000000,
+			},
+		},
+	})
+	return mock
+}
+
+func signers(n int) []interface{} {
+	entries := make([]interface{}, n)
+	for i := range entries {
+		entries[i] = map[string]interface{}{"Signer": map[string]interface{}{"Account": testAccount}}
+	}
+	return entries
+}
+
+func TestEstimateFee(t *testing.T) {
+	tests := []struct {
+		name          string
+		tx            map[string]interface{}
+		openLedgerFee string
+		fee           string
+	}{
+		{
+			name:          "Payment",
+			tx:            map[string]interface{}{"TransactionType": "Payment"},
+			openLedgerFee: "10",
+			fee:           "10",
+		},
+		{
+			name:          "escalated Payment",
+			tx:            map[string]interface{}{"TransactionType": "Payment"},
+			openLedgerFee: "15",
+			fee:           "15",
+		},
+		{
+			name:          "multi-signed by 3",
+			tx:            map[string]interface{}{"TransactionType": "Payment", "Signers": signers(3)},
+			openLedgerFee: "10",
+			fee:           "40",
+		},
+		{
+			// 2 signers pay 3 base fees of 10, escalated by 15/10
+			name:          "escalated multi-signed by 2",
+			tx:            map[string]interface{}{"TransactionType": "Payment", "Signers": signers(2)},
+			openLedgerFee: "15",
+			fee:           "45",
+		},
+		{
+			name:          "AccountDelete",
+			tx:            map[string]interface{}{"TransactionType": "AccountDelete"},
+			openLedgerFee: "10",
+			fee:           "2000000",
+		},
+		{
+			name:          "escalated AccountDelete",
+			tx:            map[string]interface{}{"TransactionType": "AccountDelete"},
+			openLedgerFee: "20",
+			fee:           "4000000",
+		},
+		{
+			// 33 base fees plus 2 for 36 bytes of fulfillment
+			name: "EscrowFinish with Fulfillment",
+			tx: map[string]interface{}{
+				"TransactionType": "EscrowFinish",
+				"Fulfillment":     "A0228020" + strings.Repeat("AB", 32),
+			},
+			openLedgerFee: "10",
+			fee:           "350",
+		},
+		{
+			name:          "EscrowFinish without Fulfillment",
+			tx:            map[string]interface{}{"TransactionType": "EscrowFinish"},
+			openLedgerFee: "10",
+			fee:           "10",
+		},
+		{
+			// A Fulfillment only costs extra in an EscrowFinish
+			name: "Payment with Fulfillment",
+			tx: map[string]interface{}{
+				"TransactionType": "Payment",
+				"Fulfillment":     "A0228020" + strings.Repeat("AB", 32),
+			},
+			openLedgerFee: "10",
+			fee:           "10",
+		},
+	}
+	for _, test := range tests {
+		mock := feeMock(test.openLedgerFee)
+		fee, err := mock.EstimateFee(test.tx)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+		} else if fee != test.fee {
+			t.Errorf("%s: fee %s, want %s", test.name, fee, test.fee)
+		}
+		if _, ok := test.tx["Fee"]; ok {
+			t.Errorf("%s: EstimateFee set the Fee of the transaction", test.name)
+		}
+	}
+}
+
+func TestEstimateFeeInvalidSigners(t *testing.T) {
+	mock := feeMock("10")
+	if _, err := mock.EstimateFee(map[string]interface{}{"TransactionType": "Payment", "Signers": "none"}); err == nil {
+		t.Error("invalid Signers: no error")
+	}
+}
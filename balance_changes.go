@@ -0,0 +1,202 @@
+package xrpl
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseBalanceChanges computes how a transaction changed the balances of the
+// accounts it affected, from its metadata. It walks the CreatedNode,
+// ModifiedNode and DeletedNode entries of AffectedNodes and diffs the
+// previous and final balances of AccountRoot objects, for XRP, and
+// RippleState objects, for tokens. The result maps each account to its
+// balance changes: XRP changes are in drops, including the fee paid by the
+// sender, and token changes are issued by the counterparty of the trust line.
+// Token changes are reported for both sides of a trust line, with opposite
+// signs.
+//
+// Example usage:
+//
+//	changes, err := xrpl.ParseBalanceChanges(res["result"].(map[string]interface{})["meta"].(map[string]interface{}))
+//	if err != nil {
+//		return err
+//	}
+//	for account, amounts := range changes {
+//		fmt.Println(account, amounts)
+//	}
+func ParseBalanceChanges(meta map[string]interface{}) (map[string][]Amount, error) {
+	nodes, ok := meta["AffectedNodes"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("metadata has no AffectedNodes")
+	}
+
+	changes := make(map[string][]Amount)
+	for _, n := range nodes {
+		node, err := affectedNodeFields(n)
+		if err != nil {
+			return nil, err
+		}
+		switch node.entryType {
+		case LedgerEntryTypeAccountRoot:
+			err = accountRootBalanceChange(node, changes)
+		case LedgerEntryTypeRippleState:
+			err = rippleStateBalanceChange(node, changes)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// affectedNode is the content of a CreatedNode, ModifiedNode or DeletedNode
+// entry. For created nodes, final holds the NewFields and previous is empty.
+type affectedNode struct {
+	entryType string
+	final     map[string]interface{}
+	previous  map[string]interface{}
+	created   bool
+}
+
+func affectedNodeFields(n interface{}) (affectedNode, error) {
+	wrapper, ok := n.(map[string]interface{})
+	if !ok || len(wrapper) != 1 {
+		return affectedNode{}, fmt.Errorf("invalid affected node %v", n)
+	}
+	var node affectedNode
+	for kind, v := range wrapper {
+		fields, ok := v.(map[string]interface{})
+		if !ok {
+			return affectedNode{}, fmt.Errorf("invalid %s %v", kind, v)
+		}
+		node.entryType, _ = fields["LedgerEntryType"].(string)
+		node.previous, _ = fields["PreviousFields"].(map[string]interface{})
+		switch kind {
+		case "CreatedNode":
+			node.final, _ = fields["NewFields"].(map[string]interface{})
+			node.created = true
+		case "ModifiedNode", "DeletedNode":
+			node.final, _ = fields["FinalFields"].(map[string]interface{})
+		default:
+			return affectedNode{}, fmt.Errorf("unknown affected node type %s", kind)
+		}
+	}
+	return node, nil
+}
+
+// balances returns the final balance of the node and its previous balance,
+// or ok false if the balance did not change. Balances are given as drops
+// strings or token amount objects.
+func (n affectedNode) balances() (final, previous interface{}, ok bool) {
+	final, hasFinal := n.final["Balance"]
+	if n.created {
+		return final, nil, hasFinal
+	}
+	previous, hasPrevious := n.previous["Balance"]
+	return final, previous, hasFinal && hasPrevious
+}
+
+func accountRootBalanceChange(node affectedNode, changes map[string][]Amount) error {
+	final, previous, ok := node.balances()
+	if !ok {
+		return nil
+	}
+	account, _ := node.final["Account"].(string)
+	delta, err := balanceDelta(final, previous)
+	if err != nil {
+		return fmt.Errorf("invalid AccountRoot balance of %s: %w", account, err)
+	}
+	if delta.Sign() != 0 {
+		changes[account] = append(changes[account], XRPAmount(delta.Num().String()))
+	}
+	return nil
+}
+
+// rippleStateBalanceChange records a trust line balance change for both of
+// its accounts. The balance of a RippleState is from the low account's
+// perspective, so the high account's change has the opposite sign.
+func rippleStateBalanceChange(node affectedNode, changes map[string][]Amount) error {
+	final, previous, ok := node.balances()
+	if !ok {
+		return nil
+	}
+	low, _ := node.final["LowLimit"].(map[string]interface{})
+	high, _ := node.final["HighLimit"].(map[string]interface{})
+	lowAccount, _ := low["issuer"].(string)
+	highAccount, _ := high["issuer"].(string)
+	balance, _ := final.(map[string]interface{})
+	currency, _ := balance["currency"].(string)
+	if lowAccount == "" || highAccount == "" || currency == "" {
+		return fmt.Errorf("invalid RippleState %v", node.final)
+	}
+
+	delta, err := balanceDelta(final, previous)
+	if err != nil {
+		return fmt.Errorf("invalid RippleState balance between %s and %s: %w", lowAccount, highAccount, err)
+	}
+	if delta.Sign() == 0 {
+		return nil
+	}
+	lowChange, err := ledgerTokenAmount(currency, highAccount, decimalString(delta))
+	if err != nil {
+		return fmt.Errorf("invalid RippleState between %s and %s: %w", lowAccount, highAccount, err)
+	}
+	delta.Neg(delta)
+	highChange, err := ledgerTokenAmount(currency, lowAccount, decimalString(delta))
+	if err != nil {
+		return fmt.Errorf("invalid RippleState between %s and %s: %w", lowAccount, highAccount, err)
+	}
+	changes[lowAccount] = append(changes[lowAccount], lowChange)
+	changes[highAccount] = append(changes[highAccount], highChange)
+	return nil
+}
+
+// balanceDelta returns final minus previous. A nil previous balance counts
+// as zero.
+func balanceDelta(final, previous interface{}) (*big.Rat, error) {
+	f, err := balanceValue(final)
+	if err != nil {
+		return nil, err
+	}
+	if previous == nil {
+		return f, nil
+	}
+	p, err := balanceValue(previous)
+	if err != nil {
+		return nil, err
+	}
+	return f.Sub(f, p), nil
+}
+
+func balanceValue(balance interface{}) (*big.Rat, error) {
+	value, ok := balance.(string)
+	if obj, isObj := balance.(map[string]interface{}); isObj {
+		value, ok = obj["value"].(string)
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid balance %v", balance)
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid balance value %q", value)
+	}
+	return r, nil
+}
+
+// decimalString formats a rational with a finite decimal expansion, as the
+// difference of two decimal values is, without exponent or trailing zeros.
+func decimalString(r *big.Rat) string {
+	places := 0
+	for pow := big.NewInt(1); places < 400; places++ {
+		if new(big.Int).Mod(pow, r.Denom()).Sign() == 0 {
+			break
+		}
+		pow.Mul(pow, big.NewInt(10))
+	}
+	s := r.FloatString(places)
+	if strings.Contains(s, ".") {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
@@ -0,0 +1,194 @@
+package xrpl
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const (
+	testSender      = "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"
+	testDestination = "rJoxBSzpXhPtAuqFmqxQtGKjA13jUJWthE"
+)
+
+// accountRoot returns the fields of an AccountRoot with balance, in drops
+func accountRoot(account, balance string) map[string]interface{} {
+	return map[string]interface{}{"Account": account, "Balance": balance, "Flags": float64(0)}
+}
+
+// rippleState returns the fields of a USD trust line between low and high
+// with balance, from the low account's perspective
+func rippleState(low, high, balance string) map[string]interface{} {
+	return map[string]interface{}{
+		"Balance":   map[string]interface{}{"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": balance},
+		"LowLimit":  map[string]interface{}{"currency": "USD", "issuer": low, "value": "1000"},
+		"HighLimit": map[string]interface{}{"currency": "USD", "issuer": high, "value": "0"},
+		"Flags":     float64(0),
+	}
+}
+
+func modifiedNode(entryType string, final, previous map[string]interface{}) map[string]interface{} {
+	node := map[string]interface{}{"LedgerEntryType": entryType, "FinalFields": final}
+	if previous != nil {
+		node["PreviousFields"] = previous
+	}
+	return map[string]interface{}{"ModifiedNode": node}
+}
+
+// balanceChangeStrings renders changes as sorted "currency/issuer value"
+// strings per account, "XRP value" for XRP
+func balanceChangeStrings(changes map[string][]Amount) map[string][]string {
+	out := make(map[string][]string, len(changes))
+	for account, amounts := range changes {
+		for _, amount := range amounts {
+			s := amount.Currency() + " " + amount.Value()
+			if !amount.IsXRP() {
+				s = amount.Currency() + "/" + amount.Issuer() + " " + amount.Value()
+			}
+			out[account] = append(out[account], s)
+		}
+		sort.Strings(out[account])
+	}
+	return out
+}
+
+func TestParseBalanceChanges(t *testing.T) {
+	tests := []struct {
+		name  string
+		nodes []interface{}
+		want  map[string][]string
+	}{
+		{
+			// The sender's change includes the fee of 12 drops
+			name: "XRP payment",
+			nodes: []interface{}{
+				modifiedNode(LedgerEntryTypeAccountRoot,
+					accountRoot(testSender, "98999988"),
+					map[string]interface{}{"Balance": "100000000", "Sequence": float64(4)}),
+				modifiedNode(LedgerEntryTypeAccountRoot,
+					accountRoot(testDestination, "51000000"),
+					map[string]interface{}{"Balance": "50000000"}),
+			},
+			want: map[string][]string{
+				testSender:      {"XRP -1000012"},
+				testDestination: {"XRP 1000000"},
+			},
+		},
+		{
+			// The sender is the low account of its line and the destination
+			// the high account of its line: a RippleState balance is the low
+			// account's, so the destination's balance goes from -5 to -15
+			name: "token payment through the issuer",
+			nodes: []interface{}{
+				modifiedNode(LedgerEntryTypeAccountRoot,
+					accountRoot(testSender, "99999988"),
+					map[string]interface{}{"Balance": "100000000"}),
+				modifiedNode(LedgerEntryTypeRippleState,
+					rippleState(testSender, testIssuer, "90"),
+					map[string]interface{}{"Balance": map[string]interface{}{"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "100"}}),
+				modifiedNode(LedgerEntryTypeRippleState,
+					rippleState(testIssuer, testDestination, "-15"),
+					map[string]interface{}{"Balance": map[string]interface{}{"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-5"}}),
+			},
+			want: map[string][]string{
+				testSender:      {"USD/" + testIssuer + " -10", "XRP -12"},
+				testIssuer:      {"USD/" + testDestination + " -10", "USD/" + testSender + " 10"},
+				testDestination: {"USD/" + testIssuer + " 10"},
+			},
+		},
+		{
+			// A created node has no previous balance, which counts as zero
+			name: "created trust line and account",
+			nodes: []interface{}{
+				map[string]interface{}{"CreatedNode": map[string]interface{}{
+					"LedgerEntryType": LedgerEntryTypeRippleState,
+					"NewFields":       rippleState(testIssuer, testDestination, "-25.5"),
+				}},
+				map[string]interface{}{"CreatedNode": map[string]interface{}{
+					"LedgerEntryType": LedgerEntryTypeAccountRoot,
+					"NewFields":       accountRoot(testSigner, "20000000"),
+				}},
+			},
+			want: map[string][]string{
+				testIssuer:      {"USD/" + testDestination + " -25.5"},
+				testDestination: {"USD/" + testIssuer + " 25.5"},
+				testSigner:      {"XRP 20000000"},
+			},
+		},
+		{
+			name: "created trust line without a balance",
+			nodes: []interface{}{
+				map[string]interface{}{"CreatedNode": map[string]interface{}{
+					"LedgerEntryType": LedgerEntryTypeRippleState,
+					"NewFields":       rippleState(testIssuer, testDestination, "0"),
+				}},
+			},
+			want: map[string][]string{},
+		},
+		{
+			// AccountDelete sends the balance less the fee of 2 XRP to the
+			// destination and deletes the AccountRoot
+			name: "AccountDelete",
+			nodes: []interface{}{
+				map[string]interface{}{"DeletedNode": map[string]interface{}{
+					"LedgerEntryType": LedgerEntryTypeAccountRoot,
+					"FinalFields":     accountRoot(testSender, "0"),
+					"PreviousFields":  map[string]interface{}{"Balance": "15000000"},
+				}},
+				modifiedNode(LedgerEntryTypeAccountRoot,
+					accountRoot(testDestination, "43000000"),
+					map[string]interface{}{"Balance": "30000000"}),
+			},
+			want: map[string][]string{
+				testSender:      {"XRP -15000000"},
+				testDestination: {"XRP 13000000"},
+			},
+		},
+		{
+			// Nodes whose Balance did not change, and other ledger objects,
+			// are skipped
+			name: "no previous balance",
+			nodes: []interface{}{
+				modifiedNode(LedgerEntryTypeAccountRoot,
+					accountRoot(testSender, "100000000"),
+					map[string]interface{}{"Sequence": float64(4)}),
+				modifiedNode(LedgerEntryTypeAccountRoot, accountRoot(testDestination, "50000000"), nil),
+				modifiedNode(LedgerEntryTypeRippleState, rippleState(testSender, testIssuer, "90"), nil),
+				modifiedNode(LedgerEntryTypeOffer,
+					map[string]interface{}{"Account": testSender, "TakerGets": "10", "TakerPays": "20"},
+					map[string]interface{}{"TakerGets": "15"}),
+			},
+			want: map[string][]string{},
+		},
+	}
+	for _, test := range tests {
+		changes, err := ParseBalanceChanges(map[string]interface{}{"AffectedNodes": test.nodes})
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got := balanceChangeStrings(changes); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestParseBalanceChangesInvalid(t *testing.T) {
+	for name, meta := range map[string]map[string]interface{}{
+		"no AffectedNodes":  {},
+		"unknown node type": {"AffectedNodes": []interface{}{map[string]interface{}{"ChangedNode": map[string]interface{}{}}}},
+		"invalid balance": {"AffectedNodes": []interface{}{modifiedNode(LedgerEntryTypeAccountRoot,
+			accountRoot(testSender, "ten"),
+			map[string]interface{}{"Balance": "100"})}},
+		"trust line with an invalid issuer": {"AffectedNodes": []interface{}{modifiedNode(LedgerEntryTypeRippleState,
+			rippleState(testSender, "rInvalid", "1"),
+			map[string]interface{}{"Balance": map[string]interface{}{"currency": "USD", "value": "0"}})}},
+		"trust line without limits": {"AffectedNodes": []interface{}{modifiedNode(LedgerEntryTypeRippleState,
+			map[string]interface{}{"Balance": map[string]interface{}{"currency": "USD", "value": "1"}},
+			map[string]interface{}{"Balance": map[string]interface{}{"currency": "USD", "value": "0"}})}},
+	} {
+		if changes, err := ParseBalanceChanges(meta); err == nil {
+			t.Errorf("%s: got %v, want an error", name, changes)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// BatchError reports the requests of a batch that failed. Errors is indexed
+// like the batch's requests, with nil for those that succeeded.
+type BatchError struct {
+	Errors []error
+}
+
+func (e *BatchError) Error() string {
+	failed := 0
+	var first error
+	for _, err := range e.Errors {
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			failed++
+		}
+	}
+	return fmt.Sprintf("%d of %d batch requests failed, first: %s", failed, len(e.Errors), first)
+}
+
+// Unwrap returns the errors of the failed requests, so that errors.Is and
+// errors.As match any of them
+func (e *BatchError) Unwrap() []error {
+	var errs []error
+	for _, err := range e.Errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Batch sends several requests at once and waits for all their responses,
+// which are returned in the order of reqs. Each request gets its own ID and
+// its response is matched to it whatever order the server responds in. The
+// requests must be distinct BaseRequest maps.
+//
+// A failed request does not abort the others: its response is nil and the
+// returned *BatchError holds its error at its index. As with Request, a
+// response reporting an error status is a response, not an error.
+//
+// Example usage:
+//
+//	res, err := client.Batch([]xrpl.BaseRequest{
+//		{"command": "account_info", "account": account},
+//		{"command": "account_lines", "account": account},
+//		{"command": "server_info"},
+//	})
+//	var batchErr *xrpl.BatchError
+//	if errors.As(err, &batchErr) {
+//		// some of res are nil, see batchErr.Errors
+//	}
+func (c *Client) Batch(reqs []BaseRequest) ([]BaseResponse, error) {
+	return c.BatchCtx(context.Background(), reqs)
+}
+
+// BatchCtx is Batch bounded by ctx for the whole batch. Requests still
+// awaiting a response when ctx is done fail with an error wrapping
+// ctx.Err(), while the responses received until then are returned.
+func (c *Client) BatchCtx(ctx context.Context, reqs []BaseRequest) ([]BaseResponse, error) {
+	responses := make([]BaseResponse, len(reqs))
+	errs := make([]error, len(reqs))
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		go func(i int, req BaseRequest) {
+			defer wg.Done()
+			responses[i], errs[i] = c.RequestCtx(ctx, req)
+		}(i, req)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return responses, &BatchError{Errors: errs}
+		}
+	}
+	return responses, nil
+}
@@ -0,0 +1,597 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Canonical binary serialization of XRPL objects
+ * https://xrpl.org/docs/references/protocol/binary-format
+ */
+
+// Hash prefixes prepended to serialized data before it is hashed or signed
+const (
+	HashPrefixTransactionID        uint32 = 0x54584E00 // 'TXN\0'
+	HashPrefixTransactionSign      uint32 = 0x53545800 // 'STX\0'
+	HashPrefixTransactionMultiSign uint32 = 0x534D5400 // 'SMT\0'
+	HashPrefixPaymentChannelClaim  uint32 = 0x434C4D00 // 'CLM\0'
+)
+
+const (
+	objectEndMarker = 0xE1
+	arrayEndMarker  = 0xF1
+
+	pathSeparator = 0xFF
+	pathSetEnd    = 0x00
+
+	pathStepAccount  = 0x01
+	pathStepCurrency = 0x10
+	pathStepIssuer   = 0x20
+)
+
+const (
+	tokenMinMantissa = 1000000000000000
+	tokenMinExponent = -96
+	tokenMaxExponent = 80
+)
+
+// EncodeTransaction serializes a transaction, including its signature fields,
+// into the canonical binary format. The result is the tx_blob accepted by the
+// submit command.
+func EncodeTransaction(tx map[string]interface{}) ([]byte, error) {
+	return encodeTopLevel(tx, false)
+}
+
+// EncodeForSigning serializes the signing fields of a transaction, prefixed
+// with HashPrefixTransactionSign. The result is the message that is signed to
+// produce a single signature TxnSignature.
+func EncodeForSigning(tx map[string]interface{}) ([]byte, error) {
+	data, err := encodeTopLevel(tx, true)
+	if err != nil {
+		return nil, err
+	}
+	return append(uint32Bytes(HashPrefixTransactionSign), data...), nil
+}
+
+// EncodeForMultisigning serializes the signing fields of a transaction for
+// signing on behalf of signerAccount. The signing fields are prefixed with
+// HashPrefixTransactionMultiSign and suffixed with the signer's AccountID, so
+// every signer of a multi-signed transaction signs a distinct message.
+func EncodeForMultisigning(tx map[string]interface{}, signerAccount string) ([]byte, error) {
+	accountID, err := decodeAccountID(signerAccount)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signer account %s: %w", signerAccount, err)
+	}
+	data, err := encodeTopLevel(tx, true)
+	if err != nil {
+		return nil, err
+	}
+	data = append(uint32Bytes(HashPrefixTransactionMultiSign), data...)
+	return append(data, accountID...), nil
+}
+
+func encodeTopLevel(obj map[string]interface{}, signingOnly bool) ([]byte, error) {
+	normalized, err := normalizeJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := encodeObjectFields(&buf, normalized, signingOnly); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// normalizeJSON converts an arbitrary transaction map into plain JSON values
+// (maps, slices, strings, bools and json.Number) so encoders only ever see one
+// representation regardless of how the caller built the map.
+func normalizeJSON(obj map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var normalized map[string]interface{}
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal object: %w", err)
+	}
+	return normalized, nil
+}
+
+// encodeObjectFields writes the fields of an object in canonical order, which
+// is ascending by type code and then by field code. Keys starting with a lower
+// case letter are API annotations (hash, meta, ledger_index...) rather than
+// ledger fields and are skipped.
+func encodeObjectFields(buf *bytes.Buffer, obj map[string]interface{}, signingOnly bool) error {
+	fields, err := canonicalFields(obj, signingOnly)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		if err := encodeField(buf, field, obj[field.Name], signingOnly); err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+	}
+	return nil
+}
+
+func encodeField(buf *bytes.Buffer, field *FieldMeta, value interface{}, signingOnly bool) error {
+	buf.Write(field.header())
+
+	switch field.Type {
+	case "STObject":
+		obj, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("expected object, got %T", value)
+		}
+		if err := encodeObjectFields(buf, obj, signingOnly); err != nil {
+			return err
+		}
+		buf.WriteByte(objectEndMarker)
+		return nil
+
+	case "STArray":
+		return encodeArray(buf, value, signingOnly)
+	}
+
+	data, err := encodeValue(field, value)
+	if err != nil {
+		return err
+	}
+	if field.IsVLEncoded {
+		prefix, err := encodeVLPrefix(len(data))
+		if err != nil {
+			return err
+		}
+		buf.Write(prefix)
+	}
+	buf.Write(data)
+	return nil
+}
+
+// encodeArray writes an STArray. Every element is an object wrapping a single
+// inner object, e.g. {"Memo": {...}}, which is encoded as an STObject field.
+func encodeArray(buf *bytes.Buffer, value interface{}, signingOnly bool) error {
+	elements, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("expected array, got %T", value)
+	}
+	for i, element := range elements {
+		wrapper, ok := element.(map[string]interface{})
+		if !ok || len(wrapper) != 1 {
+			return fmt.Errorf("array element %d must be an object with a single key", i)
+		}
+		for name, inner := range wrapper {
+			field, ok := definitions.fields[name]
+			if !ok {
+				return fmt.Errorf("unknown field %q", name)
+			}
+			if err := encodeField(buf, field, inner, signingOnly); err != nil {
+				return fmt.Errorf("array element %d: %w", i, err)
+			}
+		}
+	}
+	buf.WriteByte(arrayEndMarker)
+	return nil
+}
+
+func encodeValue(field *FieldMeta, value interface{}) ([]byte, error) {
+	switch field.Type {
+	case "UInt8":
+		n, err := encodeEnumOrUint(field.Name, value, 8)
+		return []byte{byte(n)}, err
+	case "UInt16":
+		n, err := encodeEnumOrUint(field.Name, value, 16)
+		b := make([]byte, 2)
+		binary.BigEndian.PutUint16(b, uint16(n))
+		return b, err
+	case "UInt32":
+		n, err := toUint(value, 32)
+		return uint32Bytes(uint32(n)), err
+	case "UInt64":
+		return encodeUint64(value)
+	case "Hash128":
+		return encodeHash(value, 16)
+	case "Hash160":
+		return encodeHash(value, 20)
+	case "Hash192":
+		return encodeHash(value, 24)
+	case "Hash256":
+		return encodeHash(value, 32)
+	case "Amount":
+		return encodeAmount(value)
+	case "Blob":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected hex string, got %T", value)
+		}
+		return hex.DecodeString(s)
+	case "AccountID":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected address string, got %T", value)
+		}
+		return decodeAccountID(s)
+	case "PathSet":
+		return encodePathSet(value)
+	case "Vector256":
+		return encodeVector256(value)
+	case "Issue":
+		return encodeIssue(value)
+	case "Currency":
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected currency string, got %T", value)
+		}
+		return currencyCodeBytes(s, true)
+	default:
+		return nil, fmt.Errorf("serialization of type %s is not supported", field.Type)
+	}
+}
+
+// encodeVLPrefix encodes the length prefix of a variable length field
+func encodeVLPrefix(length int) ([]byte, error) {
+	switch {
+	case length <= 192:
+		return []byte{byte(length)}, nil
+	case length <= 12480:
+		length -= 193
+		return []byte{byte(193 + length>>8), byte(length & 0xFF)}, nil
+	case length <= 918744:
+		length -= 12481
+		return []byte{byte(241 + length>>16), byte(length >> 8 & 0xFF), byte(length & 0xFF)}, nil
+	default:
+		return nil, fmt.Errorf("variable length field too long: %d bytes", length)
+	}
+}
+
+// encodeEnumOrUint encodes unsigned integers, resolving the names used in JSON
+// for the TransactionType, LedgerEntryType and TransactionResult fields.
+func encodeEnumOrUint(fieldName string, value interface{}, bits int) (uint64, error) {
+	name, ok := value.(string)
+	if !ok {
+		return toUint(value, bits)
+	}
+
+	var enum map[string]int
+	switch fieldName {
+	case "TransactionType":
+		enum = definitions.transactionTypes
+	case "LedgerEntryType":
+		enum = definitions.ledgerEntryTypes
+	case "TransactionResult":
+		enum = definitions.transactionResults
+	default:
+		return toUint(value, bits)
+	}
+	code, ok := enum[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown %s %q", fieldName, name)
+	}
+	return uint64(code) & (1<<bits - 1), nil
+}
+
+// toUint converts a JSON number or decimal string to an unsigned integer that
+// fits in the given number of bits.
+func toUint(value interface{}, bits int) (uint64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = v
+	default:
+		return 0, fmt.Errorf("expected unsigned integer, got %T", value)
+	}
+	n, err := strconv.ParseUint(s, 10, bits)
+	if err != nil {
+		return 0, fmt.Errorf("invalid UInt%d %q", bits, s)
+	}
+	return n, nil
+}
+
+// UInt64 fields are represented in JSON as hex strings
+func encodeUint64(value interface{}) ([]byte, error) {
+	var n uint64
+	var err error
+	switch v := value.(type) {
+	case string:
+		n, err = strconv.ParseUint(v, 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid UInt64 hex string %q", v)
+		}
+	default:
+		n, err = toUint(value, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b, nil
+}
+
+func encodeHash(value interface{}, size int) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected hex string, got %T", value)
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != size {
+		return nil, fmt.Errorf("expected %d bytes, got %d", size, len(b))
+	}
+	return b, nil
+}
+
+func encodeVector256(value interface{}) ([]byte, error) {
+	hashes, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array of hashes, got %T", value)
+	}
+	var buf bytes.Buffer
+	for _, h := range hashes {
+		b, err := encodeHash(h, 32)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(b)
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeAmount encodes XRP amounts given as a string of drops, and token
+// amounts given as a {currency, issuer, value} object.
+func encodeAmount(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return encodeNativeAmount(v)
+	case map[string]interface{}:
+		currency, _ := v["currency"].(string)
+		issuer, _ := v["issuer"].(string)
+		amount, _ := v["value"].(string)
+		if currency == "" || issuer == "" || amount == "" {
+			return nil, fmt.Errorf("token amount requires currency, issuer and value")
+		}
+		if currency == XRPL_NATIVE_ASSET {
+			return nil, fmt.Errorf("token amount cannot use XRP as currency")
+		}
+		mantissa, err := encodeTokenValue(amount)
+		if err != nil {
+			return nil, err
+		}
+		currencyBytes, err := currencyCodeBytes(currency, false)
+		if err != nil {
+			return nil, err
+		}
+		issuerBytes, err := decodeAccountID(issuer)
+		if err != nil {
+			return nil, err
+		}
+		return append(append(mantissa, currencyBytes...), issuerBytes...), nil
+	default:
+		return nil, fmt.Errorf("expected amount string or object, got %T", value)
+	}
+}
+
+func encodeNativeAmount(drops string) ([]byte, error) {
+	negative := strings.HasPrefix(drops, "-")
+	n, err := strconv.ParseUint(strings.TrimPrefix(drops, "-"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid XRP amount %q: must be an integer number of drops", drops)
+	}
+	if n > MaxDrops {
+		return nil, fmt.Errorf("XRP amount %s exceeds the maximum of %d drops", drops, MaxDrops)
+	}
+	if !negative {
+		n |= 0x4000000000000000
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b, nil
+}
+
+var decimalPattern = regexp.MustCompile(`^([-+]?)(\d*)(?:\.(\d*))?(?:[eE]([-+]?\d+))?$`)
+
+// parseDecimal splits a decimal string such as "-1.25e3" into its sign, its
+// significant digits without leading or trailing zeros, and the exponent that
+// scales those digits back to the original value.
+func parseDecimal(value string) (negative bool, digits *big.Int, exponent int, err error) {
+	match := decimalPattern.FindStringSubmatch(value)
+	if match == nil || match[2]+match[3] == "" {
+		return false, nil, 0, fmt.Errorf("invalid decimal value %q", value)
+	}
+
+	digitString := strings.TrimLeft(match[2]+match[3], "0")
+	exponent = -len(match[3])
+	if match[4] != "" {
+		e, err := strconv.Atoi(match[4])
+		if err != nil {
+			return false, nil, 0, fmt.Errorf("invalid exponent in %q", value)
+		}
+		exponent += e
+	}
+	trimmed := strings.TrimRight(digitString, "0")
+	exponent += len(digitString) - len(trimmed)
+
+	digits = new(big.Int)
+	if trimmed == "" {
+		return false, digits, 0, nil
+	}
+	digits.SetString(trimmed, 10)
+	return match[1] == "-", digits, exponent, nil
+}
+
+// normalizeTokenMantissa scales digits so that the mantissa has exactly 16
+// digits, as rippled stores token amounts, adjusting the exponent to match.
+func normalizeTokenMantissa(digits *big.Int, exponent int) (uint64, int, error) {
+	if digits.Sign() == 0 {
+		return 0, 0, nil
+	}
+	if len(digits.String()) > 16 {
+		return 0, 0, fmt.Errorf("token value has more than 16 significant digits")
+	}
+	mantissa := digits.Uint64()
+	for mantissa < tokenMinMantissa {
+		mantissa *= 10
+		exponent--
+	}
+	if exponent < tokenMinExponent || exponent > tokenMaxExponent {
+		return 0, 0, fmt.Errorf("token value exponent %d out of range", exponent)
+	}
+	return mantissa, exponent, nil
+}
+
+// encodeTokenValue encodes a token value into its 64 bit representation: a
+// not-XRP bit, a sign bit, an 8 bit exponent biased by 97 and a 54 bit
+// normalized mantissa.
+func encodeTokenValue(value string) ([]byte, error) {
+	negative, digits, exponent, err := parseDecimal(value)
+	if err != nil {
+		return nil, err
+	}
+	mantissa, exponent, err := normalizeTokenMantissa(digits, exponent)
+	if err != nil {
+		return nil, err
+	}
+
+	n := uint64(0x8000000000000000)
+	if mantissa != 0 {
+		if !negative {
+			n |= 0x4000000000000000
+		}
+		n |= uint64(exponent+97) << 54
+		n |= mantissa
+	}
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, n)
+	return b, nil
+}
+
+// currencyCodeBytes encodes a currency code into its 160 bit representation.
+// Standard three character codes are placed at bytes 12 to 14; 40 character
+// hex codes are used verbatim. XRP, encoded as all zeroes, is only allowed
+// where the caller sets allowXRP (issues and paths, not token amounts).
+func currencyCodeBytes(code string, allowXRP bool) ([]byte, error) {
+	b := make([]byte, 20)
+	switch {
+	case code == XRPL_NATIVE_ASSET:
+		if !allowXRP {
+			return nil, fmt.Errorf("XRP is not a valid token currency code")
+		}
+		return b, nil
+	case len(code) == 3:
+		copy(b[12:], code)
+		return b, nil
+	case len(code) == 40:
+		decoded, err := hex.DecodeString(code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex currency code %q", code)
+		}
+		return decoded, nil
+	default:
+		return nil, fmt.Errorf("invalid currency code %q", code)
+	}
+}
+
+// encodeIssue encodes an asset as its currency, followed by the issuer for
+// tokens. XRP is given as {"currency": "XRP"}.
+func encodeIssue(value interface{}) ([]byte, error) {
+	issue, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected issue object, got %T", value)
+	}
+	currency, _ := issue["currency"].(string)
+	currencyBytes, err := currencyCodeBytes(currency, true)
+	if err != nil {
+		return nil, err
+	}
+	if currency == XRPL_NATIVE_ASSET {
+		return currencyBytes, nil
+	}
+	issuer, _ := issue["issuer"].(string)
+	issuerBytes, err := decodeAccountID(issuer)
+	if err != nil {
+		return nil, err
+	}
+	return append(currencyBytes, issuerBytes...), nil
+}
+
+// encodePathSet encodes an array of paths, each an array of steps with an
+// optional account, currency and issuer.
+func encodePathSet(value interface{}) ([]byte, error) {
+	paths, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected array of paths, got %T", value)
+	}
+	var buf bytes.Buffer
+	for i, p := range paths {
+		if i > 0 {
+			buf.WriteByte(pathSeparator)
+		}
+		steps, ok := p.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected path array, got %T", p)
+		}
+		for _, s := range steps {
+			step, ok := s.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected path step object, got %T", s)
+			}
+			if err := encodePathStep(&buf, step); err != nil {
+				return nil, err
+			}
+		}
+	}
+	buf.WriteByte(pathSetEnd)
+	return buf.Bytes(), nil
+}
+
+func encodePathStep(buf *bytes.Buffer, step map[string]interface{}) error {
+	var stepType byte
+	var data []byte
+	if account, ok := step["account"].(string); ok {
+		stepType |= pathStepAccount
+		b, err := decodeAccountID(account)
+		if err != nil {
+			return err
+		}
+		data = append(data, b...)
+	}
+	if currency, ok := step["currency"].(string); ok {
+		stepType |= pathStepCurrency
+		b, err := currencyCodeBytes(currency, true)
+		if err != nil {
+			return err
+		}
+		data = append(data, b...)
+	}
+	if issuer, ok := step["issuer"].(string); ok {
+		stepType |= pathStepIssuer
+		b, err := decodeAccountID(issuer)
+		if err != nil {
+			return err
+		}
+		data = append(data, b...)
+	}
+	buf.WriteByte(stepType)
+	buf.Write(data)
+	return nil
+}
+
+func uint32Bytes(n uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, n)
+	return b
+}
@@ -0,0 +1,168 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const (
+	testIssuer = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+	testSigner = "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"
+)
+
+func TestTransactionRoundTrip(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"Payment": {
+			"TransactionType":    "Payment",
+			"Account":            "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+			"Destination":        "rDwvihpE48E48F8rvNrqTb2UGWv62xqYTg",
+			"DestinationTag":     float64(12345),
+			"Amount":             "1000000",
+			"Fee":                "12",
+			"Flags":              float64(0),
+			"Sequence":           float64(1798962),
+			"LastLedgerSequence": float64(1800000),
+			"SigningPubKey":      "EDE5638D8055CCD45EBF7F5FFD59FC1703D6BC00800BBA19F158119DAA1A52A8D5",
+		},
+		"OfferCreate": {
+			"TransactionType": "OfferCreate",
+			"Account":         "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+			"TakerGets":       "15000000",
+			"TakerPays": map[string]interface{}{
+				"currency": "USD",
+				"issuer":   testIssuer,
+				"value":    "7.5",
+			},
+			"Fee":      "10",
+			"Flags":    float64(0x00080000),
+			"Sequence": float64(7),
+		},
+		"TrustSet": {
+			"TransactionType": "TrustSet",
+			"Account":         "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+			"LimitAmount": map[string]interface{}{
+				"currency": "0158415500000000C1F76FF6ECB0BAC600000000",
+				"issuer":   testIssuer,
+				"value":    "1000000000",
+			},
+			"QualityIn": float64(1000000000),
+			"Fee":       "12",
+			"Flags":     float64(0x00020000),
+			"Sequence":  float64(3),
+		},
+		"Memos and Signers": {
+			"TransactionType": "Payment",
+			"Account":         "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+			"Destination":     "rDwvihpE48E48F8rvNrqTb2UGWv62xqYTg",
+			"Amount": map[string]interface{}{
+				"currency": "EUR",
+				"issuer":   testIssuer,
+				"value":    "-0.001",
+			},
+			"Fee":           "36",
+			"Sequence":      float64(42),
+			"SigningPubKey": "",
+			"Memos": []interface{}{
+				NewMemo("invoice", "INV-2024-0042", "text/plain"),
+				NewMemo("", "no type", ""),
+			},
+			"Signers": []interface{}{
+				map[string]interface{}{
+					"Signer": map[string]interface{}{
+						"Account":       testSigner,
+						"SigningPubKey": "ED5F5AC8B98974A3CA843326D9B88CEBD0560177B973EE0B149F782CFAA06DC66A",
+						"TxnSignature":  "30440220702ABC11419AD4940969CC32EB4D1BFDBFCA651F064F30D6E1646D74FBFC493902204E5B451B447B0F69904127F04FE71634BD825A8970B9467871DA89EEC4B021F8",
+					},
+				},
+			},
+		},
+	}
+	for name, tx := range tests {
+		blob, err := EncodeTransaction(tx)
+		if err != nil {
+			t.Errorf("%s: EncodeTransaction: %v", name, err)
+			continue
+		}
+		decoded, err := DecodeTxBlob(hex.EncodeToString(blob))
+		if err != nil {
+			t.Errorf("%s: DecodeTxBlob: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded, tx) {
+			t.Errorf("%s: decoded\n%v\nwant\n%v", name, decoded, tx)
+		}
+		reencoded, err := EncodeTransaction(decoded)
+		if err != nil {
+			t.Errorf("%s: re-encoding: %v", name, err)
+		} else if !reflect.DeepEqual(reencoded, blob) {
+			t.Errorf("%s: re-encoded %X, want %X", name, reencoded, blob)
+		}
+	}
+}
+
+func TestDecodeTxBlob(t *testing.T) {
+	const blob = "120000220000000024001B733261400000000000000F68400000000000000C7321EDE5638D8055CCD45EBF7F5FFD59FC1703D6BC00800BBA19F158119DAA1A52A8D57440A973391D589C1D81E55516420A8D095DD98D2FC1F85E53C427EEEC22C6D3DEBADFA184005F5539E6A672CC4FA468125981584DDCE9365A6C7076F2E9CAF86B0E81143A18A088CF12B2D3E51F47A75D2A9859EF61ECA78314858233827B488ECB8D0EB940E7AC85CE41E343CF"
+	tx, err := DecodeTxBlob(blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"TransactionType": "Payment",
+		"Account":         "raJB6EHNSJa3jV7FqWNrAhcL6FEDE3PGc5",
+		"Destination":     "rDwvihpE48E48F8rvNrqTb2UGWv62xqYTg",
+		"Amount":          "15",
+		"Fee":             "12",
+		"Flags":           float64(0),
+		"Sequence":        float64(1798962),
+		"SigningPubKey":   "EDE5638D8055CCD45EBF7F5FFD59FC1703D6BC00800BBA19F158119DAA1A52A8D5",
+		"TxnSignature":    "A973391D589C1D81E55516420A8D095DD98D2FC1F85E53C427EEEC22C6D3DEBADFA184005F5539E6A672CC4FA468125981584DDCE9365A6C7076F2E9CAF86B0E",
+	}
+	if !reflect.DeepEqual(tx, want) {
+		t.Errorf("decoded\n%v\nwant\n%v", tx, want)
+	}
+	reencoded, err := EncodeTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.ToUpper(hex.EncodeToString(reencoded)); got != blob {
+		t.Errorf("re-encoded %s, want %s", got, blob)
+	}
+}
+
+func TestAccountIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		address string
+		id      string
+	}{
+		{"rrrrrrrrrrrrrrrrrrrrrhoLvTp", "0000000000000000000000000000000000000000"},
+		{"rrrrrrrrrrrrrrrrrrrrBZbvji", "0000000000000000000000000000000000000001"},
+		{testSigner, "B5F762798A53D543A014CAF8B297CFF8F2F937E8"},
+	}
+	for _, test := range tests {
+		id, err := DecodeAccountID(test.address)
+		if err != nil {
+			t.Errorf("DecodeAccountID(%s): %v", test.address, err)
+			continue
+		}
+		if got := strings.ToUpper(hex.EncodeToString(id[:])); got != test.id {
+			t.Errorf("DecodeAccountID(%s) = %s, want %s", test.address, got, test.id)
+		}
+		if address := EncodeAccountID(id); address != test.address {
+			t.Errorf("EncodeAccountID(%s) = %s, want %s", test.id, address, test.address)
+		}
+	}
+
+	invalid := map[string]error{
+		"rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTi":                          ErrAddressChecksum,
+		"snoPBrXtMeMyMHUVTgbuqAfg1SUTb":                               ErrAddressVersion,
+		NewBase58().EncodeCheck(accountIDPrefix[0], make([]byte, 19)): ErrAddressLength,
+	}
+	for address, want := range invalid {
+		if _, err := DecodeAccountID(address); !errors.Is(err, want) {
+			t.Errorf("DecodeAccountID(%s): got %v, want %v", address, err, want)
+		}
+	}
+}
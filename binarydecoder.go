@@ -0,0 +1,424 @@
+package xrpl
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/*
+ * Decoding of the canonical binary format, the inverse of binarycodec.go
+ * https://xrpl.org/docs/references/protocol/binary-format
+ */
+
+// DecodeTxBlob decodes a hex encoded transaction in the canonical binary
+// format, such as a signed tx_blob, into its JSON form. Field values are
+// represented as encoding/json decodes the JSON form of a transaction:
+// numbers as float64, amounts as drops strings or {currency, issuer, value}
+// objects, accounts as classic addresses and hashes and blobs as uppercase
+// hex. Re-encoding the result with EncodeTransaction reproduces the blob.
+//
+// Example usage:
+//
+//	tx, err := xrpl.DecodeTxBlob(txBlob)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(tx["TransactionType"], tx["Account"], tx["Fee"])
+func DecodeTxBlob(blobHex string) (map[string]interface{}, error) {
+	data, err := hex.DecodeString(blobHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction blob: %w", err)
+	}
+	p := &binaryParser{data: data}
+	obj, err := p.readObject(false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction blob: %w", err)
+	}
+	return obj, nil
+}
+
+// binaryParser reads values of the binary format from data
+type binaryParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *binaryParser) end() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *binaryParser) read(n int) ([]byte, error) {
+	if n < 0 || p.pos+n > len(p.data) {
+		return nil, fmt.Errorf("unexpected end of data at byte %d", p.pos)
+	}
+	b := p.data[p.pos : p.pos+n]
+	p.pos += n
+	return b, nil
+}
+
+func (p *binaryParser) readByte() (byte, error) {
+	b, err := p.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// readFieldHeader reads a field ID and resolves it to its field definition.
+// It is the inverse of FieldMeta.header.
+func (p *binaryParser) readFieldHeader() (*FieldMeta, error) {
+	b, err := p.readByte()
+	if err != nil {
+		return nil, err
+	}
+	typeCode, nth := int(b>>4), int(b&0x0F)
+	if typeCode == 0 {
+		if b, err = p.readByte(); err != nil {
+			return nil, err
+		}
+		typeCode = int(b)
+	}
+	if nth == 0 {
+		if b, err = p.readByte(); err != nil {
+			return nil, err
+		}
+		nth = int(b)
+	}
+	field, ok := definitions.fieldsByID[[2]int{typeCode, nth}]
+	if !ok {
+		return nil, fmt.Errorf("unknown field with type code %d and field code %d", typeCode, nth)
+	}
+	return field, nil
+}
+
+// readVLPrefix reads the length prefix of a variable length field
+func (p *binaryParser) readVLPrefix() (int, error) {
+	b1, err := p.readByte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case b1 <= 192:
+		return int(b1), nil
+	case b1 <= 240:
+		b2, err := p.readByte()
+		if err != nil {
+			return 0, err
+		}
+		return 193 + int(b1-193)<<8 + int(b2), nil
+	case b1 <= 254:
+		b, err := p.read(2)
+		if err != nil {
+			return 0, err
+		}
+		return 12481 + int(b1-241)<<16 + int(b[0])<<8 + int(b[1]), nil
+	default:
+		return 0, fmt.Errorf("invalid variable length prefix %#x", b1)
+	}
+}
+
+// readObject reads fields until the object end marker, or until the end of
+// the data for the top level object.
+func (p *binaryParser) readObject(nested bool) (map[string]interface{}, error) {
+	obj := make(map[string]interface{})
+	for !p.end() {
+		if nested && p.data[p.pos] == objectEndMarker {
+			p.pos++
+			return obj, nil
+		}
+		field, err := p.readFieldHeader()
+		if err != nil {
+			return nil, err
+		}
+		value, err := p.readField(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		obj[field.Name] = value
+	}
+	if nested {
+		return nil, fmt.Errorf("object is missing its end marker")
+	}
+	return obj, nil
+}
+
+// readArray reads the elements of an STArray until the array end marker. Each
+// element is an STObject field, returned wrapped as {"Name": {...}}.
+func (p *binaryParser) readArray() ([]interface{}, error) {
+	elements := []interface{}{}
+	for !p.end() {
+		if p.data[p.pos] == arrayEndMarker {
+			p.pos++
+			return elements, nil
+		}
+		field, err := p.readFieldHeader()
+		if err != nil {
+			return nil, err
+		}
+		if field.Type != "STObject" {
+			return nil, fmt.Errorf("array element %d is a %s, not an object", len(elements), field.Type)
+		}
+		inner, err := p.readObject(true)
+		if err != nil {
+			return nil, fmt.Errorf("array element %d: %w", len(elements), err)
+		}
+		elements = append(elements, map[string]interface{}{field.Name: inner})
+	}
+	return nil, fmt.Errorf("array is missing its end marker")
+}
+
+func (p *binaryParser) readField(field *FieldMeta) (interface{}, error) {
+	switch field.Type {
+	case "STObject":
+		return p.readObject(true)
+	case "STArray":
+		return p.readArray()
+	}
+
+	var data []byte
+	var err error
+	if field.IsVLEncoded {
+		length, err := p.readVLPrefix()
+		if err != nil {
+			return nil, err
+		}
+		data, err = p.read(length)
+		if err != nil {
+			return nil, err
+		}
+		return decodeValue(field, data)
+	}
+
+	switch field.Type {
+	case "UInt8":
+		data, err = p.read(1)
+	case "UInt16":
+		data, err = p.read(2)
+	case "UInt32":
+		data, err = p.read(4)
+	case "UInt64":
+		data, err = p.read(8)
+	case "Hash128":
+		data, err = p.read(16)
+	case "Hash160":
+		data, err = p.read(20)
+	case "Hash256":
+		data, err = p.read(32)
+	case "AccountID", "Currency":
+		data, err = p.read(20)
+	case "Amount":
+		var first []byte
+		if first, err = p.read(1); err == nil {
+			p.pos--
+			if first[0]&0x80 != 0 {
+				data, err = p.read(48)
+			} else {
+				data, err = p.read(8)
+			}
+		}
+	case "Issue":
+		if data, err = p.read(20); err == nil && !isNativeCurrency(data) {
+			p.pos -= 20
+			data, err = p.read(40)
+		}
+	case "PathSet":
+		return p.readPathSet()
+	default:
+		return nil, fmt.Errorf("deserialization of type %s is not supported", field.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeValue(field, data)
+}
+
+// decodeValue decodes the bytes of a field value of a fixed size type, or
+// the content of a variable length field.
+func decodeValue(field *FieldMeta, data []byte) (interface{}, error) {
+	switch field.Type {
+	case "UInt8":
+		return decodeEnumOrUint(field.Name, uint64(data[0]), 8), nil
+	case "UInt16":
+		return decodeEnumOrUint(field.Name, uint64(binary.BigEndian.Uint16(data)), 16), nil
+	case "UInt32":
+		return float64(binary.BigEndian.Uint32(data)), nil
+	case "UInt64":
+		return strings.ToUpper(hex.EncodeToString(data)), nil
+	case "Hash128", "Hash160", "Hash256", "Blob":
+		return strings.ToUpper(hex.EncodeToString(data)), nil
+	case "AccountID":
+		if len(data) != 20 {
+			return nil, fmt.Errorf("account ID is %d bytes, expected 20", len(data))
+		}
+		return encodeAccountID(data), nil
+	case "Amount":
+		return decodeAmount(data)
+	case "Currency":
+		return currencyCodeString(data), nil
+	case "Issue":
+		issue := map[string]interface{}{"currency": currencyCodeString(data[:20])}
+		if len(data) == 40 {
+			issue["issuer"] = encodeAccountID(data[20:])
+		}
+		return issue, nil
+	case "Vector256":
+		if len(data)%32 != 0 {
+			return nil, fmt.Errorf("vector of %d bytes is not a list of 32 byte hashes", len(data))
+		}
+		hashes := make([]interface{}, 0, len(data)/32)
+		for i := 0; i < len(data); i += 32 {
+			hashes = append(hashes, strings.ToUpper(hex.EncodeToString(data[i:i+32])))
+		}
+		return hashes, nil
+	default:
+		return nil, fmt.Errorf("deserialization of type %s is not supported", field.Type)
+	}
+}
+
+// decodeEnumOrUint decodes unsigned integers, resolving the TransactionType,
+// LedgerEntryType and TransactionResult codes to their names. It is the
+// inverse of encodeEnumOrUint.
+func decodeEnumOrUint(fieldName string, n uint64, bits int) interface{} {
+	var enum map[string]int
+	switch fieldName {
+	case "TransactionType":
+		enum = definitions.transactionTypes
+	case "LedgerEntryType":
+		enum = definitions.ledgerEntryTypes
+	case "TransactionResult":
+		enum = definitions.transactionResults
+	default:
+		return float64(n)
+	}
+	// Exact matches take precedence: negative codes such as those of
+	// TransactionResult wrap around and may collide with positive ones.
+	for name, code := range enum {
+		if code >= 0 && uint64(code) == n {
+			return name
+		}
+	}
+	for name, code := range enum {
+		if uint64(code)&(1<<bits-1) == n {
+			return name
+		}
+	}
+	return float64(n)
+}
+
+// decodeAmount decodes an XRP amount of 8 bytes into a string of drops, and a
+// token amount of 48 bytes into a {currency, issuer, value} object.
+func decodeAmount(data []byte) (interface{}, error) {
+	n := binary.BigEndian.Uint64(data[:8])
+	if n&0x8000000000000000 == 0 {
+		drops := strconv.FormatUint(n&0x3FFFFFFFFFFFFFFF, 10)
+		if n&0x4000000000000000 == 0 && drops != "0" {
+			drops = "-" + drops
+		}
+		return drops, nil
+	}
+	if len(data) != 48 {
+		return nil, fmt.Errorf("token amount is %d bytes, expected 48", len(data))
+	}
+	return map[string]interface{}{
+		"currency": currencyCodeString(data[8:28]),
+		"issuer":   encodeAccountID(data[28:48]),
+		"value":    decodeTokenValue(n),
+	}, nil
+}
+
+// decodeTokenValue formats the 64 bit representation of a token value the
+// way rippled does: as a plain decimal number, or as mantissa and exponent,
+// e.g. "1234000000000000e-26", when the exponent is below -25 or above -5.
+func decodeTokenValue(n uint64) string {
+	mantissa := n & (1<<54 - 1)
+	if mantissa == 0 {
+		return "0"
+	}
+	exponent := int(n>>54&0xFF) - 97
+	sign := ""
+	if n&0x4000000000000000 == 0 {
+		sign = "-"
+	}
+
+	digits := strconv.FormatUint(mantissa, 10)
+	if exponent != 0 && (exponent < -25 || exponent > -5) {
+		return fmt.Sprintf("%s%se%d", sign, digits, exponent)
+	}
+	if exponent >= 0 {
+		return sign + digits
+	}
+
+	places := -exponent
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-places], strings.TrimRight(digits[len(digits)-places:], "0")
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
+}
+
+// currencyCodeString converts a 160 bit currency code into its JSON form:
+// "XRP" for the all-zero code, the three character code for standard codes
+// and uppercase hex otherwise.
+func currencyCodeString(b []byte) string {
+	if isNativeCurrency(b) {
+		return XRPL_NATIVE_ASSET
+	}
+	code, err := DecodeCurrency(hex.EncodeToString(b))
+	if err != nil {
+		return strings.ToUpper(hex.EncodeToString(b))
+	}
+	return code
+}
+
+// readPathSet reads an array of paths, the inverse of encodePathSet
+func (p *binaryParser) readPathSet() ([]interface{}, error) {
+	paths := []interface{}{}
+	path := []interface{}{}
+	for {
+		stepType, err := p.readByte()
+		if err != nil {
+			return nil, err
+		}
+		switch stepType {
+		case pathSetEnd:
+			if len(paths) == 0 && len(path) == 0 {
+				return paths, nil
+			}
+			return append(paths, path), nil
+		case pathSeparator:
+			paths = append(paths, path)
+			path = []interface{}{}
+			continue
+		}
+
+		step := map[string]interface{}{}
+		if stepType&pathStepAccount != 0 {
+			b, err := p.read(20)
+			if err != nil {
+				return nil, err
+			}
+			step["account"] = encodeAccountID(b)
+		}
+		if stepType&pathStepCurrency != 0 {
+			b, err := p.read(20)
+			if err != nil {
+				return nil, err
+			}
+			step["currency"] = currencyCodeString(b)
+		}
+		if stepType&pathStepIssuer != 0 {
+			b, err := p.read(20)
+			if err != nil {
+				return nil, err
+			}
+			step["issuer"] = encodeAccountID(b)
+		}
+		path = append(path, step)
+	}
+}
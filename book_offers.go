@@ -0,0 +1,104 @@
+package xrpl
+
+// Offer is an offer in an order book as returned by the book_offers command.
+// Funded amounts and owner funds are only reported for offers the owner
+// cannot fully fund, and for the first offer of each owner.
+type Offer struct {
+	Account           string  `json:"Account"`
+	Sequence          uint32  `json:"Sequence"`
+	Flags             uint32  `json:"Flags"`
+	TakerGets         Amount  `json:"TakerGets"`
+	TakerPays         Amount  `json:"TakerPays"`
+	Expiration        uint32  `json:"Expiration,omitempty"` // Ripple time
+	BookDirectory     string  `json:"BookDirectory"`
+	BookNode          string  `json:"BookNode"`
+	OwnerNode         string  `json:"OwnerNode"`
+	Index             string  `json:"index"`
+	Quality           string  `json:"quality"`     // TakerPays per unit of TakerGets
+	OwnerFunds        string  `json:"owner_funds"` // Balance of TakerGets held by the owner
+	TakerGetsFunded   *Amount `json:"taker_gets_funded,omitempty"`
+	TakerPaysFunded   *Amount `json:"taker_pays_funded,omitempty"`
+	PreviousTxnID     string  `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32  `json:"PreviousTxnLgrSeq"`
+}
+
+// BookOffersOption configures a book_offers request
+type BookOffersOption func(req BaseRequest)
+
+// BookOffersTaker views the order book from the perspective of taker, whose
+// own offers are then reported as fully funded and unaffected by transfer
+// fees.
+func BookOffersTaker(taker string) BookOffersOption {
+	return func(req BaseRequest) {
+		req["taker"] = taker
+	}
+}
+
+// BookOffersLimit sets the maximum number of offers returned
+func BookOffersLimit(limit int) BookOffersOption {
+	return func(req BaseRequest) {
+		req["limit"] = limit
+	}
+}
+
+// BookOffersLedgerIndex selects the ledger to read the order book from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func BookOffersLedgerIndex(ledger LedgerSpecifier) BookOffersOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// bookCurrency converts the currency of an amount into the currency object of
+// a book_offers request: {"currency": "XRP"} for XRP and {"currency": ...,
+// "issuer": ...} for tokens. The amount's value is not used.
+func bookCurrency(a Amount) map[string]interface{} {
+	if a.IsXRP() {
+		return map[string]interface{}{"currency": XRPL_NATIVE_ASSET}
+	}
+	return map[string]interface{}{
+		"currency": a.Currency(),
+		"issuer":   a.Issuer(),
+	}
+}
+
+// Retrieve the offers of an order book, best quality first. The book is
+// identified by the currencies of takerGets, which an offer's taker would
+// receive, and takerPays, which the taker would pay; their values are
+// ignored.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "0")
+//	if err != nil {
+//		return err
+//	}
+//	offers, err := client.BookOffers(
+//		xrpl.XRPAmount("0"),
+//		usd,
+//		xrpl.BookOffersLimit(10))
+//	if err != nil {
+//		return err
+//	}
+//	for _, offer := range offers {
+//		fmt.Println(offer.TakerGets, offer.TakerPays, offer.Quality)
+//	}
+func (c *Client) BookOffers(takerGets, takerPays Amount, opts ...BookOffersOption) ([]Offer, error) {
+	req := BaseRequest{
+		"command":      "book_offers",
+		"taker_gets":   bookCurrency(takerGets),
+		"taker_pays":   bookCurrency(takerPays),
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		Offers []Offer `json:"offers"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+	return result.Offers, nil
+}
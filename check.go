@@ -0,0 +1,194 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckCreateOption configures BuildCheckCreate
+type CheckCreateOption func(*checkCreateOptions)
+
+type checkCreateOptions struct {
+	destinationTag *uint32
+	expiration     time.Time
+	invoiceID      string
+}
+
+// CheckDestinationTag sets the tag identifying the recipient at the
+// destination, e.g. a customer of an exchange
+func CheckDestinationTag(tag uint32) CheckCreateOption {
+	return func(o *checkCreateOptions) {
+		o.destinationTag = &tag
+	}
+}
+
+// CheckExpiration sets the time after which the check can no longer be
+// cashed, only cancelled
+func CheckExpiration(t time.Time) CheckCreateOption {
+	return func(o *checkCreateOptions) {
+		o.expiration = t
+	}
+}
+
+// CheckInvoiceID sets the 32 byte hex identifier, e.g. a hash, of what the
+// check is for
+func CheckInvoiceID(invoiceIDHex string) CheckCreateOption {
+	return func(o *checkCreateOptions) {
+		o.invoiceID = invoiceIDHex
+	}
+}
+
+// BuildCheckCreate builds a CheckCreate transaction from account, ready to be
+// autofilled and signed, writing a check that destination may cash for up to
+// sendMax, including transfer fees. Nothing is set aside: the check can only
+// be cashed for what account holds at the time.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn", "100")
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildCheckCreate(
+//		wallet.ClassicAddress,
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		usd,
+//		xrpl.CheckExpiration(time.Now().Add(30*24*time.Hour)))
+func BuildCheckCreate(account, destination string, sendMax Amount, opts ...CheckCreateOption) (map[string]interface{}, error) {
+	var options checkCreateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := ValidateClassicAddress(destination); err != nil {
+		return nil, fmt.Errorf("invalid check Destination: %w", err)
+	}
+	if destination == account {
+		return nil, fmt.Errorf("check Destination cannot be its own account")
+	}
+	if err := validatePositiveAmount(sendMax); err != nil {
+		return nil, fmt.Errorf("invalid check SendMax: %w", err)
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeCheckCreate),
+		"Account":         account,
+		"Destination":     destination,
+		"SendMax":         sendMax,
+	}
+	if options.destinationTag != nil {
+		tx["DestinationTag"] = *options.destinationTag
+	}
+	if !options.expiration.IsZero() {
+		expiration, err := TimeToRippleTime(options.expiration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid check Expiration: %w", err)
+		}
+		tx["Expiration"] = expiration
+	}
+	if options.invoiceID != "" {
+		if b, err := hex.DecodeString(options.invoiceID); err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid check InvoiceID %q: must be 32 bytes of hex", options.invoiceID)
+		}
+		tx["InvoiceID"] = strings.ToUpper(options.invoiceID)
+	}
+	return tx, nil
+}
+
+// CheckCashSpec is what a CheckCash transaction receives: exactly one of
+// Amount, the exact amount to receive, failing otherwise, or DeliverMin, the
+// least to receive, receiving as much as possible up to the check's SendMax.
+type CheckCashSpec struct {
+	Amount     Amount
+	DeliverMin Amount
+	// SendMax of the check, when known, e.g. from CheckEntry, to reject a
+	// cash of another currency or of more than the check allows
+	SendMax Amount
+}
+
+// BuildCheckCash builds a CheckCash transaction from account, the check's
+// destination, ready to be autofilled and signed, cashing the check with ID
+// checkID as given by spec.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn", "95")
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildCheckCash(wallet.ClassicAddress, checkID, xrpl.CheckCashSpec{
+//		DeliverMin: usd,
+//	})
+func BuildCheckCash(account, checkID string, spec CheckCashSpec) (map[string]interface{}, error) {
+	hasAmount := spec.Amount != (Amount{})
+	hasDeliverMin := spec.DeliverMin != (Amount{})
+	if hasAmount == hasDeliverMin {
+		return nil, fmt.Errorf("CheckCash needs exactly one of Amount and DeliverMin")
+	}
+	field, amount := "Amount", spec.Amount
+	if hasDeliverMin {
+		field, amount = "DeliverMin", spec.DeliverMin
+	}
+	if err := validatePositiveAmount(amount); err != nil {
+		return nil, fmt.Errorf("invalid CheckCash %s: %w", field, err)
+	}
+	if spec.SendMax != (Amount{}) {
+		if amount.Currency() != spec.SendMax.Currency() || amount.Issuer() != spec.SendMax.Issuer() {
+			return nil, fmt.Errorf("CheckCash %s %s is not in the currency of the check's SendMax %s", field, amount, spec.SendMax)
+		}
+		value, _ := amount.Rat()
+		sendMax, err := spec.SendMax.Rat()
+		if err != nil {
+			return nil, fmt.Errorf("invalid check SendMax: %w", err)
+		}
+		if value.Cmp(sendMax) > 0 {
+			return nil, fmt.Errorf("CheckCash %s %s exceeds the check's SendMax %s", field, amount, spec.SendMax)
+		}
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeCheckCash),
+		"Account":         account,
+		field:             amount,
+	}
+	if err := setCheckID(tx, checkID); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// BuildCheckCancel builds a CheckCancel transaction from account, ready to be
+// autofilled and signed, cancelling the check with ID checkID. The sender or
+// destination can cancel a check at any time, and anyone once it expired.
+func BuildCheckCancel(account, checkID string) (map[string]interface{}, error) {
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeCheckCancel),
+		"Account":         account,
+	}
+	if err := setCheckID(tx, checkID); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// setCheckID sets the CheckID field of tx to checkID, a 32 byte hex ID
+func setCheckID(tx map[string]interface{}, checkID string) error {
+	if b, err := hex.DecodeString(checkID); err != nil || len(b) != 32 {
+		return fmt.Errorf("invalid CheckID %q: must be 32 bytes of hex", checkID)
+	}
+	tx["CheckID"] = strings.ToUpper(checkID)
+	return nil
+}
+
+// validatePositiveAmount checks that amount is valid and above zero
+func validatePositiveAmount(amount Amount) error {
+	value, err := amount.Rat()
+	if err != nil {
+		return err
+	}
+	if value.Sign() <= 0 {
+		return fmt.Errorf("%s must be positive", amount)
+	}
+	return nil
+}
@@ -1,13 +1,16 @@
 package xrpl
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"log"
 	"math"
 	"net/http"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -27,28 +30,73 @@ type ClientConfig struct {
 	WriteTimeout       time.Duration // Default is 60 seconds
 	HeartbeatInterval  time.Duration // Default is 5 seconds
 	QueueCapacity      int           // Default is 128
+	LastLedgerOffset   uint32        // Default is 20 ledgers
+	Reconnect          ReconnectConfig
+	Transport          Transport // Default is chosen by URL scheme
 }
 
+// ReconnectConfig controls how the client re-establishes a dropped websocket
+// connection. Delays grow from InitialDelay by Multiplier after each failed
+// attempt, up to MaxDelay.
+type ReconnectConfig struct {
+	Disabled     bool          // Default is false, i.e. reconnect automatically
+	InitialDelay time.Duration // Default is 1 second
+	MaxDelay     time.Duration // Default is 60 seconds
+	Multiplier   float64       // Default is 2
+	MaxAttempts  int           // Default is 0, i.e. retry forever
+}
+
+// ErrDisconnected is returned for requests still awaiting a response when the
+// websocket connection drops.
+var ErrDisconnected = errors.New("websocket connection lost before a response was received")
+
+// ErrClientClosed is returned for requests made after Close, and for requests
+// still awaiting a response when Close is called.
+var ErrClientClosed = errors.New("client is closed")
+
 type Client struct {
-	config              ClientConfig
-	connection          *websocket.Conn
-	heartbeatDone       chan bool
-	closed              bool
-	mutex               sync.Mutex
-	response            *http.Response
-	StreamLedger        chan []byte
-	StreamTransaction   chan []byte
-	StreamValidation    chan []byte
-	StreamManifest      chan []byte
-	StreamPeerStatus    chan []byte
-	StreamConsensus     chan []byte
-	StreamPathFind      chan []byte
-	StreamServer        chan []byte
-	StreamDefault       chan []byte
-	StreamSubscriptions map[string]bool
-	requestQueue        map[string](chan<- BaseResponse)
-	nextId              int
-	err                 error
+	config                       ClientConfig
+	transport                    Transport
+	connection                   *websocket.Conn
+	heartbeatDone                chan bool
+	closed                       bool
+	mutex                        sync.Mutex
+	response                     *http.Response
+	StreamLedger                 chan []byte
+	StreamTransaction            chan []byte
+	StreamValidation             chan []byte
+	StreamManifest               chan []byte
+	StreamPeerStatus             chan []byte
+	StreamConsensus              chan []byte
+	StreamPathFind               chan []byte
+	StreamServer                 chan []byte
+	StreamDefault                chan []byte
+	streamSubscriptions          map[string]bool
+	channelSubscriptions         map[string]bool // Streams subscribed with Subscribe
+	streamHandlers               map[string][]*subscriptionHandler
+	typeHandlers                 map[string][]*subscriptionHandler
+	accountSubscriptions         map[string][]*subscriptionHandler
+	proposedAccountSubscriptions map[string][]*subscriptionHandler
+	bookSubscriptions            map[string]*bookSubscription
+	pathFind                     *pathFindSubscription
+	requestQueue                 map[string](chan<- BaseResponse)
+	streamQueue                  streamQueueConfig
+	reconnectHooks               []func()
+	state                        ConnState
+	stateChanges                 chan ConnState
+	logger                       Logger
+	metrics                      Metrics
+	warningHandler               func([]Warning)
+	keepAlive                    KeepAliveConfig
+	dial                         dialOptions
+	rateLimit                    rateLimitConfig
+	limiter                      *rateLimiter
+	apiVersion                   int
+	nextId                       atomic.Uint64
+	orphanedResponses            atomic.Uint64
+	clock                        clock
+	requestIDPrefix              string
+	err                          error
 }
 
 func (config *ClientConfig) Validate() error {
@@ -70,11 +118,22 @@ func (config *ClientConfig) Validate() error {
 		config.HeartbeatInterval >= math.MaxInt32 {
 		return fmt.Errorf("connection heartbeat interval out of bounds: %d", config.HeartbeatInterval)
 	}
+	if config.Reconnect.InitialDelay < 0 ||
+		config.Reconnect.InitialDelay > config.Reconnect.MaxDelay ||
+		config.Reconnect.MaxDelay >= math.MaxInt32 {
+		return fmt.Errorf("reconnect delay out of bounds: %d..%d", config.Reconnect.InitialDelay, config.Reconnect.MaxDelay)
+	}
+	if config.Reconnect.Multiplier < 1 {
+		return fmt.Errorf("reconnect backoff multiplier out of bounds: %f", config.Reconnect.Multiplier)
+	}
+	if config.Reconnect.MaxAttempts < 0 {
+		return fmt.Errorf("reconnect max attempts out of bounds: %d", config.Reconnect.MaxAttempts)
+	}
 
 	return nil
 }
 
-func NewClient(config ClientConfig) *Client {
+func NewClient(config ClientConfig, opts ...ClientOption) *Client {
 	if config.ReadTimeout == 0 {
 		config.ReadTimeout = 60
 	}
@@ -89,79 +148,303 @@ func NewClient(config ClientConfig) *Client {
 		config.QueueCapacity = 128
 	}
 
+	if config.LastLedgerOffset == 0 {
+		config.LastLedgerOffset = 20
+	}
+
+	if config.Reconnect.InitialDelay == 0 {
+		config.Reconnect.InitialDelay = 1
+	}
+	if config.Reconnect.MaxDelay == 0 {
+		config.Reconnect.MaxDelay = 60
+	}
+	if config.Reconnect.Multiplier == 0 {
+		config.Reconnect.Multiplier = 2
+	}
+
 	if err := config.Validate(); err != nil {
 		panic(err)
 	}
 
 	client := &Client{
-		config:              config,
-		heartbeatDone:       make(chan bool),
-		StreamLedger:        make(chan []byte, config.QueueCapacity),
-		StreamTransaction:   make(chan []byte, config.QueueCapacity),
-		StreamValidation:    make(chan []byte, config.QueueCapacity),
-		StreamManifest:      make(chan []byte, config.QueueCapacity),
-		StreamPeerStatus:    make(chan []byte, config.QueueCapacity),
-		StreamConsensus:     make(chan []byte, config.QueueCapacity),
-		StreamPathFind:      make(chan []byte, config.QueueCapacity),
-		StreamServer:        make(chan []byte, config.QueueCapacity),
-		StreamDefault:       make(chan []byte, config.QueueCapacity),
-		StreamSubscriptions: make(map[string]bool),
-		requestQueue:        make(map[string](chan<- BaseResponse)),
-		nextId:              0,
-	}
-
-	_, err := client.NewConnection()
+		config:                       config,
+		StreamLedger:                 make(chan []byte, config.QueueCapacity),
+		StreamTransaction:            make(chan []byte, config.QueueCapacity),
+		StreamValidation:             make(chan []byte, config.QueueCapacity),
+		StreamManifest:               make(chan []byte, config.QueueCapacity),
+		StreamPeerStatus:             make(chan []byte, config.QueueCapacity),
+		StreamConsensus:              make(chan []byte, config.QueueCapacity),
+		StreamPathFind:               make(chan []byte, config.QueueCapacity),
+		StreamServer:                 make(chan []byte, config.QueueCapacity),
+		StreamDefault:                make(chan []byte, config.QueueCapacity),
+		streamSubscriptions:          make(map[string]bool),
+		channelSubscriptions:         make(map[string]bool),
+		streamHandlers:               make(map[string][]*subscriptionHandler),
+		typeHandlers:                 make(map[string][]*subscriptionHandler),
+		accountSubscriptions:         make(map[string][]*subscriptionHandler),
+		proposedAccountSubscriptions: make(map[string][]*subscriptionHandler),
+		bookSubscriptions:            make(map[string]*bookSubscription),
+		requestQueue:                 make(map[string](chan<- BaseResponse)),
+		stateChanges:                 make(chan ConnState, stateChangesCapacity),
+		logger:                       stdLogger{},
+		metrics:                      nopMetrics{},
+		clock:                        systemClock,
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	if client.keepAlive.Interval == 0 {
+		client.keepAlive.Interval = config.HeartbeatInterval * time.Second
+	}
+	if client.keepAlive.Timeout == 0 {
+		client.keepAlive.Timeout = 10 * time.Second
+	}
+	if err := client.keepAlive.validate(); err != nil {
+		panic(err)
+	}
+	if client.streamQueue.size == 0 {
+		client.streamQueue.size = config.QueueCapacity
+	}
+	if err := client.streamQueue.validate(); err != nil {
+		panic(err)
+	}
+	if client.dial.maxMessageSize == 0 {
+		client.dial.maxMessageSize = defaultMaxMessageSize
+	}
+	if client.dial.maxMessageSize < 0 {
+		panic(fmt.Errorf("max message size out of bounds: %d", client.dial.maxMessageSize))
+	}
+	limiter, err := client.rateLimit.newLimiter(client.clock)
 	if err != nil {
-		log.Println("WS connection error:", client.config.URL, err)
+		panic(err)
+	}
+	client.limiter = limiter
+	if err := validateAPIVersion(client.apiVersion); err != nil {
+		panic(err)
+	}
+	if client.dial.tlsConfig != nil && client.dial.tlsConfig.InsecureSkipVerify {
+		client.logger.Warnf("TLS certificate verification is disabled: %s", client.config.URL)
+	}
+	client.transport = newTransport(client)
+	if !client.usesWebsocket() {
+		client.state = ConnStateConnected
+		return client
+	}
+
+	if _, err := client.NewConnection(); err != nil {
+		client.logger.Errorf("WS connection error: %s %s", client.config.URL, err)
 	}
 	return client
 }
 
+// usesWebsocket reports whether requests are sent over the client's own
+// websocket connection, as opposed to HTTP or a custom Transport.
+func (c *Client) usesWebsocket() bool {
+	_, ok := c.transport.(*wsTransport)
+	return ok
+}
+
 func (c *Client) NewConnection() (*websocket.Conn, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	c.closed = false
+	conn, err := c.connect()
+	if err != nil {
+		c.setState(ConnStateDisconnected)
+	}
+	return conn, err
+}
 
-	conn, r, err := websocket.DefaultDialer.Dial(c.config.URL, nil)
+// connect dials a new websocket connection and starts its read loop and
+// heartbeat. The caller must hold c.mutex.
+func (c *Client) connect() (*websocket.Conn, error) {
+	conn, r, err := c.dial.dialWebsocket(c.config.URL)
 	if err != nil {
 		c.err = err
 		return nil, err
 	}
-	defer r.Body.Close()
+	if r != nil {
+		defer r.Body.Close()
+	}
 	c.connection = conn
 	c.response = r
-	c.closed = false
+	c.err = nil
+	c.heartbeatDone = make(chan bool)
+	c.setState(ConnStateConnected)
 
 	// Set connection handlers and heartbeat
-	c.connection.SetReadDeadline(time.Now().Add(c.config.ReadTimeout * time.Second))
+	c.connection.SetReadDeadline(c.keepAlive.readDeadline())
+	c.connection.SetReadLimit(c.dial.maxMessageSize)
 	c.connection.SetPongHandler(c.handlePong)
-	go c.handleResponse()
-	go c.heartbeat()
+	go c.handleResponse(conn)
+	go c.heartbeat(conn, c.heartbeatDone)
 	return c.connection, nil
 }
 
+// redial connects again unless the client has been closed in the meantime
+func (c *Client) redial() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return ErrClientClosed
+	}
+	_, err := c.connect()
+	return err
+}
+
+// Replace the websocket connection with a new one. All requests awaiting a
+// response on the old connection fail with ErrDisconnected, subscribed streams
+// are re-subscribed and OnReconnect hooks run once the new connection is up.
 func (c *Client) Reconnect() error {
+	if !c.usesWebsocket() {
+		return nil
+	}
+	if c.isClosed() {
+		return ErrClientClosed
+	}
+
 	// Close old websocket connection
-	c.Close()
+	c.changeState(ConnStateReconnecting)
+	c.closeConnection()
+	c.failPendingRequests()
 
 	// Create a new websocket connection
-	_, err := c.NewConnection()
+	err := c.redial()
 	if err != nil {
-		log.Println("WS reconnection error:", c.config.URL, err)
+		c.logger.Errorf("WS reconnection error: %s %s", c.config.URL, err)
+		if err != ErrClientClosed {
+			c.changeState(ConnStateDisconnected)
+		}
 		return err
 	}
+	c.logger.Infof("WS reconnected: %s", c.config.URL)
 
-	// Re-subscribe xrpl streams
-	_, err = c.Subscribe(c.Subscriptions())
-	if err != nil {
-		log.Println("WS stream subscription error:", err)
-	}
+	c.afterReconnect()
 	return nil
 }
 
+// OnReconnect registers a hook that runs after the client re-establishes a
+// dropped connection and has re-subscribed its streams. Hooks run in the order
+// they were registered, on the goroutine performing the reconnection.
+func (c *Client) OnReconnect(hook func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.reconnectHooks = append(c.reconnectHooks, hook)
+}
+
+// reconnect redials the server with exponential backoff after the read loop
+// exits on a dropped connection, as configured by ClientConfig.Reconnect.
+func (c *Client) reconnect() {
+	if c.config.Reconnect.Disabled {
+		c.changeState(ConnStateDisconnected)
+	} else {
+		c.changeState(ConnStateReconnecting)
+	}
+	c.closeConnection()
+	c.failPendingRequests()
+	if c.config.Reconnect.Disabled {
+		return
+	}
+
+	delay := c.config.Reconnect.InitialDelay * time.Second
+	maxDelay := c.config.Reconnect.MaxDelay * time.Second
+	for attempt := 1; c.config.Reconnect.MaxAttempts == 0 || attempt <= c.config.Reconnect.MaxAttempts; attempt++ {
+		c.clock.sleep(context.Background(), delay)
+
+		err := c.redial()
+		if err == ErrClientClosed {
+			return
+		}
+		if err == nil {
+			c.logger.Infof("WS reconnected on attempt %d: %s", attempt, c.config.URL)
+			c.afterReconnect()
+			return
+		}
+		c.logger.Warnf("WS reconnection attempt %d error: %s %s", attempt, c.config.URL, err)
+
+		delay = time.Duration(float64(delay) * c.config.Reconnect.Multiplier)
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+	c.logger.Errorf("WS reconnection abandoned: %s", c.config.URL)
+	c.changeState(ConnStateDisconnected)
+}
+
+// afterReconnect re-subscribes xrpl streams, accounts and books, reopens
+// the path_find request and runs OnReconnect hooks
+func (c *Client) afterReconnect() {
+	c.metrics.Reconnected()
+	if subs := c.Subscriptions(); len(subs) > 0 {
+		res, err := c.subscribe(subs, false)
+		if err != nil {
+			c.logger.Errorf("WS stream subscription error: %s", err)
+		} else if c.IsSubscribed(StreamTypeServer) {
+			// The new connection may be to a server under another load
+			var status ServerStatus
+			if decodeResult(res, &status) == nil {
+				c.applyServerLoad(&status)
+			}
+		}
+	}
+	c.resubscribeAccountsAndBooks()
+	c.reopenPathFind()
+
+	c.mutex.Lock()
+	hooks := append([]func(){}, c.reconnectHooks...)
+	c.mutex.Unlock()
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// failPendingRequests resolves every request awaiting a response by closing
+// its channel, which RequestCtx reports as ErrDisconnected, or as
+// ErrClientClosed once the client is closed.
+func (c *Client) failPendingRequests() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.drainRequestQueue()
+}
+
+// drainRequestQueue closes and forgets the channels of all pending requests.
+// The caller must hold c.mutex.
+func (c *Client) drainRequestQueue() {
+	for requestId, ch := range c.requestQueue {
+		close(ch)
+		delete(c.requestQueue, requestId)
+	}
+}
+
+// closeConnection stops the heartbeat and closes the current websocket
+// connection without marking the client as closed.
+func (c *Client) closeConnection() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.connection == nil {
+		return
+	}
+	if c.heartbeatDone != nil {
+		close(c.heartbeatDone)
+		c.heartbeatDone = nil
+	}
+	c.connection.Close()
+	c.connection = nil
+}
+
+func (c *Client) isClosed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.closed
+}
+
 func (c *Client) Ping(message []byte) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 	// log.Println("PING:", string(message))
+	if c.connection == nil {
+		return ErrDisconnected
+	}
 	newDeadline := time.Now().Add(c.config.WriteTimeout * time.Second)
 	if err := c.connection.WriteControl(websocket.PingMessage, message, newDeadline); err != nil {
 		return err
@@ -169,39 +452,100 @@ func (c *Client) Ping(message []byte) error {
 	return nil
 }
 
-// Returns incremental ID that may be used as request ID for websocket requests
+// Returns incremental ID that may be used as request ID for websocket requests.
+// IDs are unique and strictly increasing across concurrent callers. They are
+// decimal strings such as "42", or prefixed with WithStringRequestIDs.
 func (c *Client) NextID() string {
-	c.mutex.Lock()
-	c.nextId++
-	c.mutex.Unlock()
-	return strconv.Itoa(c.nextId)
+	return c.requestIDPrefix + strconv.FormatUint(c.nextId.Add(1), 10)
+}
+
+// WithStringRequestIDs prefixes request IDs with a random string unique to
+// the client, e.g. "xrpl-9f86d081-42" instead of "42", so that responses
+// cannot be mistaken for those of other clients or tools whose numeric IDs
+// share the same server session or proxy.
+func WithStringRequestIDs() ClientOption {
+	return func(c *Client) {
+		b := make([]byte, 4)
+		if _, err := rand.Read(b); err != nil {
+			panic(fmt.Errorf("failed to generate request ID prefix: %w", err))
+		}
+		c.requestIDPrefix = "xrpl-" + hex.EncodeToString(b) + "-"
+	}
+}
+
+// OrphanedResponses returns the number of responses received that matched no
+// pending request and were dropped: late responses to requests that already
+// timed out or were cancelled, repeated responses to the same request, and
+// responses with IDs the client never sent. A steadily growing count points
+// to a misbehaving server or proxy.
+func (c *Client) OrphanedResponses() uint64 {
+	return c.orphanedResponses.Load()
+}
+
+// responseID returns the ID of a response as the request ID it answers. IDs
+// are echoed as JSON strings, or as numbers for requests sent with a numeric
+// id by other tools.
+func responseID(id interface{}) string {
+	switch v := id.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", id)
 }
 
+// Subscriptions returns a snapshot of the streams the client is subscribed
+// to, which are subscribed again on reconnect. The snapshot is the caller's
+// to keep and does not change with later subscriptions.
 func (c *Client) Subscriptions() []string {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	subs := make([]string, 0, len(c.StreamSubscriptions))
-	for k := range c.StreamSubscriptions {
+	subs := make([]string, 0, len(c.streamSubscriptions))
+	for k := range c.streamSubscriptions {
 		subs = append(subs, k)
 	}
 	return subs
 }
 
+// IsSubscribed reports whether the client is subscribed to stream, e.g.
+// StreamTypeLedger
+func (c *Client) IsSubscribed(stream string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.streamSubscriptions[stream]
+}
+
+// Close sends a websocket close frame and closes the connection. Requests
+// still awaiting a response fail with ErrClientClosed, as do requests made
+// after Close. The client does not reconnect once closed. Close is idempotent
+// and safe to call concurrently with in-flight requests.
 func (c *Client) Close() error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
+	if c.closed {
+		return nil
+	}
 	c.closed = true
-	c.heartbeatDone <- true
+	c.setState(ConnStateClosed)
+	c.drainRequestQueue()
+	if c.heartbeatDone != nil {
+		close(c.heartbeatDone)
+		c.heartbeatDone = nil
+	}
+	if c.connection == nil {
+		return nil
+	}
 
-	err := c.connection.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
-	if err != nil {
-		log.Println("WS write error:", err)
-		return err
+	conn := c.connection
+	c.connection = nil
+	writeErr := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	if writeErr != nil {
+		c.logger.Errorf("WS write error: %s", writeErr)
 	}
-	err = c.connection.Close()
-	if err != nil {
-		log.Println("WS close error:", err)
+	if err := conn.Close(); err != nil {
+		c.logger.Errorf("WS close error: %s", err)
 		return err
 	}
-	return nil
+	return writeErr
 }
@@ -0,0 +1,59 @@
+package xrpl
+
+import "context"
+
+// ClientInterface is the request side of *Client: raw requests, stream
+// subscriptions and the typed helpers built on them. Code that depends on it
+// instead of *Client can be tested without a server, e.g. with the
+// MockClient of package xrpltest.
+type ClientInterface interface {
+	Request(req BaseRequest) (BaseResponse, error)
+	RequestCtx(ctx context.Context, req BaseRequest) (BaseResponse, error)
+	RequestChecked(req BaseRequest) (BaseResponse, error)
+	RequestCheckedCtx(ctx context.Context, req BaseRequest) (BaseResponse, error)
+	Subscribe(streams []string) (BaseResponse, error)
+	Unsubscribe(streams []string) (BaseResponse, error)
+	Subscriptions() []string
+	IsSubscribed(stream string) bool
+	SubscribeServer(handler func(ServerStatus)) (*ServerStatus, error)
+	Close() error
+
+	AccountInfo(account string, opts ...AccountInfoOption) (*AccountInfoResult, error)
+	AccountExists(account string) (bool, error)
+	RequiresDestinationTag(account string) (bool, error)
+	AccountCurrencies(account string, opts ...AccountCurrenciesOption) (sendCurrencies, receiveCurrencies []string, err error)
+	AccountLines(account string, opts ...AccountLinesOption) ([]TrustLine, error)
+	AccountLinesPage(account string, opts ...AccountLinesOption) (*AccountLinesResult, error)
+	AccountNFTs(account string, opts ...AccountNFTsOption) ([]NFToken, error)
+	AccountNFTsPage(account string, opts ...AccountNFTsOption) (*AccountNFTsResult, error)
+	AccountObjects(account string, opts ...AccountObjectsOption) ([]LedgerObject, error)
+	AccountObjectsPage(account string, opts ...AccountObjectsOption) (*AccountObjectsResult, error)
+	AccountTx(account string, opts ...AccountTxOption) ([]TxWithMeta, error)
+	AccountTxPage(account string, opts ...AccountTxOption) (*AccountTxResult, error)
+	AccountReserve(account string) (baseDrops, ownerDrops, totalReserveDrops string, err error)
+	SpendableBalance(account string) (string, error)
+	GatewayBalances(account string, opts ...GatewayBalancesOption) (*GatewayBalancesResult, error)
+	BookOffers(takerGets, takerPays Amount, opts ...BookOffersOption) ([]Offer, error)
+	AMMInfo(asset, asset2 Amount, opts ...AMMInfoOption) (*AMMInfoResult, error)
+	RipplePathFind(source, destination string, destAmount Amount, opts ...PathFindOption) ([]PaymentPath, error)
+	Ledger(opts ...LedgerOption) (*LedgerResult, error)
+	LedgerCurrent() (uint32, error)
+	LedgerClosed() (index uint32, hash string, err error)
+	LedgerEntry(selector LedgerEntrySelector, opts ...LedgerEntryOption) (*LedgerEntryResult, error)
+	LedgerDataPage(spec LedgerSpecifier, opts ...LedgerDataOption) (*LedgerDataResult, error)
+	Tx(txid string, opts ...TxOption) (*TxWithMeta, error)
+	Fee() (*FeeResult, error)
+	ServerInfo() (*ServerInfoResult, error)
+	Feature(name string) (*FeatureStatus, error)
+	Features() (map[string]FeatureStatus, error)
+
+	Autofill(tx map[string]interface{}) error
+	EstimateFee(tx map[string]interface{}) (string, error)
+	SignAndSubmitRequest(req BaseRequest, familySeed string, opts ...SubmitOption) (*SubmitResult, error)
+	SignAndSubmitWithWallet(req BaseRequest, wallet *Wallet, opts ...SubmitOption) (*SubmitResult, error)
+	SubmitBlob(blobHex string) (*SubmitResult, error)
+	SubmitMultisigned(tx map[string]interface{}) (*SubmitResult, error)
+	SubmitAndWait(tx map[string]interface{}, seed string, opts ...WaitOption) (*TxResult, error)
+}
+
+var _ ClientInterface = (*Client)(nil)
@@ -0,0 +1,52 @@
+package xrpl
+
+import (
+	"context"
+	"time"
+)
+
+// clock is the source of time for the client's reconnect backoff, retries,
+// polling, rate limiting and keepalive pings, so that they can be driven by a
+// fake clock rather than by real waits. Connection deadlines, the keepalive
+// read deadline included, always use the wall clock, as the network does.
+type clock struct {
+	now func() time.Time
+	// newTimer starts a timer that fires once after d, and returns its
+	// channel and a function stopping it
+	newTimer func(d time.Duration) (<-chan time.Time, func() bool)
+	// newTicker starts a ticker that fires every d, and returns its channel
+	// and a function stopping it
+	newTicker func(d time.Duration) (<-chan time.Time, func())
+}
+
+var systemClock = clock{
+	now: time.Now,
+	newTimer: func(d time.Duration) (<-chan time.Time, func() bool) {
+		t := time.NewTimer(d)
+		return t.C, t.Stop
+	},
+	newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+		t := time.NewTicker(d)
+		return t.C, t.Stop
+	},
+}
+
+// sleep waits for d to pass on the clock, or until ctx is done, in which case
+// it returns the error of ctx
+func (k clock) sleep(ctx context.Context, d time.Duration) error {
+	fired, stop := k.newTimer(d)
+	select {
+	case <-ctx.Done():
+		stop()
+		return ctx.Err()
+	case <-fired:
+		return nil
+	}
+}
+
+// withClock replaces the system clock of the client with k
+func withClock(k clock) ClientOption {
+	return func(c *Client) {
+		c.clock = k
+	}
+}
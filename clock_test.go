@@ -0,0 +1,241 @@
+package xrpl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// fakeClock is a clock whose timers fire right away, moving the time forward
+// by their duration, and which records how long each of them was. Its
+// tickers fire only when the test calls tick, and record their interval.
+type fakeClock struct {
+	mutex     sync.Mutex
+	time      time.Time
+	sleeps    []time.Duration
+	intervals []time.Duration
+	ticks     chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), ticks: make(chan time.Time)}
+}
+
+func (f *fakeClock) clock() clock {
+	return clock{
+		now: func() time.Time {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			return f.time
+		},
+		newTimer: func(d time.Duration) (<-chan time.Time, func() bool) {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			f.time = f.time.Add(d)
+			f.sleeps = append(f.sleeps, d)
+			fired := make(chan time.Time, 1)
+			fired <- f.time
+			return fired, func() bool { return false }
+		},
+		newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+			f.mutex.Lock()
+			defer f.mutex.Unlock()
+			f.intervals = append(f.intervals, d)
+			return f.ticks, func() {}
+		},
+	}
+}
+
+// tick moves the time forward by d and fires a ticker waiting on the clock
+func (f *fakeClock) tick(d time.Duration) {
+	f.mutex.Lock()
+	f.time = f.time.Add(d)
+	now := f.time
+	f.mutex.Unlock()
+	f.ticks <- now
+}
+
+func (f *fakeClock) tickerIntervals() []time.Duration {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]time.Duration(nil), f.intervals...)
+}
+
+func (f *fakeClock) slept() []time.Duration {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]time.Duration(nil), f.sleeps...)
+}
+
+// transportFunc is a Transport answering requests with a function
+type transportFunc func(ctx context.Context, req BaseRequest) (BaseResponse, error)
+
+func (f transportFunc) Send(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	return f(ctx, req)
+}
+
+func TestReconnectBackoff(t *testing.T) {
+	var mutex sync.Mutex
+	dials := 0
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		dials++
+		n := dials
+		mutex.Unlock()
+		// The first connection drops right away, the next three dials are
+		// refused and the fifth one stays up
+		if n >= 2 && n <= 4 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		if n == 1 {
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	fake := newFakeClock()
+	config := ClientConfig{
+		URL:       "ws" + strings.TrimPrefix(server.URL, "http"),
+		Reconnect: ReconnectConfig{InitialDelay: 1, MaxDelay: 5, Multiplier: 2},
+	}
+	client := NewClient(config, withClock(fake.clock()), WithLogger(NopLogger()))
+	defer client.Close()
+
+	reconnected := make(chan struct{})
+	client.OnReconnect(func() { close(reconnected) })
+	select {
+	case <-reconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("not reconnected after %v", fake.slept())
+	}
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	if got := fake.slept(); !reflect.DeepEqual(got, want) {
+		t.Errorf("reconnect delays %v, want %v", got, want)
+	}
+}
+
+func TestWaitForLedgerPolls(t *testing.T) {
+	var mutex sync.Mutex
+	seq := uint32(5)
+	transport := transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		res := BaseResponse{
+			"status": "success",
+			"result": map[string]interface{}{
+				"state": map[string]interface{}{
+					"validated_ledger": map[string]interface{}{"seq": seq},
+				},
+			},
+		}
+		seq++
+		return res, nil
+	})
+
+	fake := newFakeClock()
+	client := NewClient(ClientConfig{URL: "custom://", Transport: transport}, withClock(fake.clock()))
+	ledger, err := client.WaitForLedger(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("WaitForLedger: %v", err)
+	}
+	if ledger.LedgerIndex != 7 {
+		t.Errorf("got ledger %d, want 7", ledger.LedgerIndex)
+	}
+	want := []time.Duration{waitForLedgerInterval, waitForLedgerInterval}
+	if got := fake.slept(); !reflect.DeepEqual(got, want) {
+		t.Errorf("polled after %v, want %v", got, want)
+	}
+}
+
+func TestRateLimiterWaits(t *testing.T) {
+	fake := newFakeClock()
+	limiter, err := rateLimitConfig{perSecond: 4, burst: 2}.newLimiter(fake.clock())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		if err := limiter.wait(ctx); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	// The burst goes through, the next requests wait for a token each
+	want := []time.Duration{250 * time.Millisecond, 250 * time.Millisecond}
+	if got := fake.slept(); !reflect.DeepEqual(got, want) {
+		t.Errorf("waited %v, want %v", got, want)
+	}
+
+	limiter.setLoad(2)
+	if err := limiter.wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := fake.slept()[2]; got != 500*time.Millisecond {
+		t.Errorf("waited %v under load 2, want 500ms", got)
+	}
+}
+
+func TestKeepAlivePings(t *testing.T) {
+	pings := make(chan string, 2)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(data string) error {
+			pings <- data
+			return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(time.Second))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	fake := newFakeClock()
+	config := ClientConfig{URL: "ws" + strings.TrimPrefix(server.URL, "http")}
+	client := NewClient(config, withClock(fake.clock()), WithLogger(NopLogger()),
+		WithKeepAlive(KeepAliveConfig{Interval: 30 * time.Second, Timeout: 10 * time.Second}))
+	defer client.Close()
+
+	// Each tick of the keepalive interval sends one ping, with the tick's
+	// time as its payload
+	for i := 1; i <= 2; i++ {
+		fake.tick(30 * time.Second)
+		select {
+		case data := <-pings:
+			want := time.Date(2024, 1, 1, 0, 0, 30*i, 0, time.UTC).String()
+			if data != want {
+				t.Errorf("ping %d payload %q, want %q", i, data, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("ping %d not received", i)
+		}
+	}
+	if got, want := fake.tickerIntervals(), []time.Duration{30 * time.Second}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ticker intervals %v, want %v", got, want)
+	}
+}
@@ -2,19 +2,28 @@ package xrpl
 
 import (
 	"bytes"
+	"context"
 	"crypto/ed25519"
 	"crypto/sha256"
-	"crypto/sha512"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
 
-	"github.com/gorilla/websocket"
+	"github.com/decred/dcrd/crypto/ripemd160"
 )
 
+// Subscribe subscribes to streams and sends their messages to the client's
+// Stream channels, e.g. StreamLedger, including messages that handlers of
+// SubscribeWithHandler or SubscribeAccounts receive too.
 func (c *Client) Subscribe(streams []string) (BaseResponse, error) {
+	return c.subscribe(streams, true)
+}
+
+// subscribe subscribes to streams, with channel set for streams whose
+// messages go to the Stream channels whether or not handlers take them
+func (c *Client) subscribe(streams []string, channel bool) (BaseResponse, error) {
 	req := BaseRequest{
 		"command": "subscribe",
 		"streams": streams,
@@ -26,13 +35,67 @@ func (c *Client) Subscribe(streams []string) (BaseResponse, error) {
 
 	c.mutex.Lock()
 	for _, stream := range streams {
-		c.StreamSubscriptions[stream] = true
+		c.streamSubscriptions[stream] = true
+		if channel {
+			c.channelSubscriptions[stream] = true
+		}
 	}
 	c.mutex.Unlock()
 
 	return res, nil
 }
 
+// SubscribeWithHandler subscribes to streams and routes their messages to
+// handler instead of the client's Stream channels. Messages of streams also
+// subscribed with Subscribe still go to the channels. Several handlers may be
+// registered for the same stream; Unsubscribe removes all of them.
+//
+// Each call is a subscription with its own queue of messages and worker
+// goroutine, see WithStreamBuffer. The handler receives the messages of all
+// its streams one at a time, in the order the server sent them. Handlers of
+// different subscriptions run concurrently, so the order in which they see
+// the same message, or messages of different streams, is unspecified.
+//
+// With the default StreamOverflowBlock, a handler must not wait on Request:
+// once its queue is full the read loop blocks on it, so the response never
+// arrives. Choose a dropping policy with WithStreamBuffer, or send the request
+// from another goroutine.
+func (c *Client) SubscribeWithHandler(streams []string, handler func(msg BaseResponse)) (BaseResponse, error) {
+	res, _, err := c.subscribeWithHandler(streams, handler)
+	return res, err
+}
+
+// subscribeWithHandler is SubscribeWithHandler, also returning the handler
+// registered so that callers can roll it back
+func (c *Client) subscribeWithHandler(streams []string, handler func(msg BaseResponse)) (BaseResponse, *subscriptionHandler, error) {
+	h := c.newSubscriptionHandler(handler)
+	c.mutex.Lock()
+	for _, stream := range streams {
+		c.streamHandlers[stream] = append(c.streamHandlers[stream], h)
+	}
+	c.mutex.Unlock()
+
+	res, err := c.subscribe(streams, false)
+	if err != nil {
+		c.removeStreamHandler(streams, h)
+		return nil, nil, err
+	}
+	return res, h, nil
+}
+
+// removeStreamHandler removes h from the handlers of streams, leaving those
+// of other subscriptions to the same streams in place
+func (c *Client) removeStreamHandler(streams []string, h *subscriptionHandler) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, stream := range streams {
+		c.streamHandlers[stream] = removeSubscriptionHandler(c.streamHandlers[stream], h)
+		if len(c.streamHandlers[stream]) == 0 {
+			delete(c.streamHandlers, stream)
+		}
+	}
+}
+
 func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
 	req := BaseRequest{
 		"command": "unsubscribe",
@@ -45,15 +108,23 @@ func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
 
 	c.mutex.Lock()
 	for _, stream := range streams {
-		delete(c.StreamSubscriptions, stream)
+		delete(c.streamSubscriptions, stream)
+		delete(c.channelSubscriptions, stream)
+		delete(c.streamHandlers, stream)
 	}
 	c.mutex.Unlock()
+	for _, stream := range streams {
+		if stream == StreamTypeServer {
+			c.applyServerLoad(nil)
+		}
+	}
 
 	return res, nil
 }
 
-// Send a websocket request. This method takes a BaseRequest object and automatically adds
-// incremental request ID to it.
+// Send a request to the server. This method takes a BaseRequest object and automatically adds
+// incremental request ID to it. It blocks until a response arrives; use
+// RequestCtx to bound the wait with a deadline or cancellation.
 //
 // Example usage:
 //
@@ -63,27 +134,49 @@ func (c *Client) Unsubscribe(streams []string) (BaseResponse, error) {
 //		"ledger_index": "current",
 //	}
 //
-//	err := client.Request(req, func(){})
+//	res, err := client.Request(req)
 func (c *Client) Request(req BaseRequest) (BaseResponse, error) {
-	requestId := c.NextID()
-	req["id"] = requestId
-	data, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
-
-	ch := make(chan BaseResponse, 1)
+	return c.RequestCtx(context.Background(), req)
+}
 
-	c.mutex.Lock()
-	c.requestQueue[requestId] = ch
-	err = c.connection.WriteMessage(websocket.TextMessage, data)
-	if err != nil {
-		return nil, err
+// Send a request and wait for its response until ctx is done. If
+// ctx is canceled or its deadline passes first, the pending request is
+// forgotten and the returned error wraps ctx.Err(). With WithRateLimit the
+// request first waits for the rate limiter, bounded by ctx as well.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//	res, err := client.RequestCtx(ctx, req)
+//	if errors.Is(err, context.DeadlineExceeded) {
+//		// no response within 10 seconds
+//	}
+func (c *Client) RequestCtx(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("request not sent: %w", err)
 	}
-	c.mutex.Unlock()
-
-	res := <-ch
-	return res, nil
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	c.setAPIVersion(req)
+
+	command, _ := req["command"].(string)
+	c.metrics.RequestStarted(command)
+	start := c.clock.now()
+	res, err := c.transport.Send(ctx, req)
+	c.metrics.RequestFinished(command, c.clock.now().Sub(start), err)
+	if err == nil && c.warningHandler != nil {
+		if warnings := res.Warnings(); warnings != nil {
+			c.warningHandler(warnings)
+		}
+	}
+	return res, err
 }
 
 // XRPLBase58Alphabet is the specific alphabet used by XRPL
@@ -91,10 +184,15 @@ const XRPLBase58Alphabet = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1t
 
 var (
 	familySeedPrefix    = []byte{0x21}
+	accountIDPrefix     = []byte{0x00}
 	accountPublicPrefix = []byte{0x23}
 	nodePublicPrefix    = []byte{0x1C}
 )
 
+// ErrChecksumMismatch is returned when decoding base58check data whose
+// checksum does not match its payload
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
 // Base58 encoding specific to XRPL
 type Base58 struct {
 	alphabet     string
@@ -115,34 +213,68 @@ func NewBase58() *Base58 {
 	return b58
 }
 
-// Encode converts bytes to a base58 string
-func (b58 *Base58) Encode(input []byte) string {
-	x := new(big.Int)
-	x.SetBytes(input)
-
-	// Convert to base58
-	var result []byte
-	mod := new(big.Int)
-	zero := big.NewInt(0)
+// Five base58 digits fit in a uint32 limb, and a limb shifted by a 32 bit
+// chunk of input still fits in a uint64
+const base58LimbBase = 58 * 58 * 58 * 58 * 58
 
-	for x.Cmp(zero) > 0 {
-		x.DivMod(x, b58.baseMultiple, mod)
-		result = append(result, b58.alphabet[mod.Int64()])
+// Encode converts bytes to a base58 string. The input is treated as a big
+// endian number and converted with carry propagation, four input bytes at a
+// time, into limbs of five base58 digits each. This avoids the arbitrary
+// precision division of a big.Int per output digit.
+func (b58 *Base58) Encode(input []byte) string {
+	// Leading zero bytes are encoded as leading zero digits
+	zeros := 0
+	for zeros < len(input) && input[zeros] == 0 {
+		zeros++
 	}
+	rest := input[zeros:]
 
-	// Add leading zeros
-	for _, b := range input {
-		if b != 0 {
-			break
+	// Little endian limbs; log(256) / log(58) is about 1.37 digits per byte
+	limbs := make([]uint32, 0, len(rest)*138/100/5+2)
+	chunkSize := len(rest) % 4
+	if chunkSize == 0 {
+		chunkSize = 4
+	}
+	for len(rest) > 0 {
+		var carry uint64
+		for _, b := range rest[:chunkSize] {
+			carry = carry<<8 | uint64(b)
+		}
+		shift := 8 * uint(chunkSize)
+		rest = rest[chunkSize:]
+		chunkSize = 4
+
+		for i := range limbs {
+			carry += uint64(limbs[i]) << shift
+			limbs[i] = uint32(carry % base58LimbBase)
+			carry /= base58LimbBase
+		}
+		for carry > 0 {
+			limbs = append(limbs, uint32(carry%base58LimbBase))
+			carry /= base58LimbBase
 		}
-		result = append(result, b58.alphabet[0])
 	}
 
-	// Reverse the result
-	for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
-		result[i], result[j] = result[j], result[i]
+	result := make([]byte, zeros, zeros+len(limbs)*5)
+	for i := range result {
+		result[i] = b58.alphabet[0]
+	}
+	var digits [5]byte
+	for i := len(limbs) - 1; i >= 0; i-- {
+		limb := limbs[i]
+		for k := len(digits) - 1; k >= 0; k-- {
+			digits[k] = b58.alphabet[limb%58]
+			limb /= 58
+		}
+		out := digits[:]
+		if i == len(limbs)-1 {
+			// Drop the zero digits above the most significant digit
+			for len(out) > 1 && out[0] == b58.alphabet[0] {
+				out = out[1:]
+			}
+		}
+		result = append(result, out...)
 	}
-
 	return string(result)
 }
 
@@ -197,114 +329,245 @@ func (b58 *Base58) EncodeCheck(version byte, payload []byte) string {
 
 // DecodeCheck decodes and verifies checksum
 func (b58 *Base58) DecodeCheck(input string) (version byte, payload []byte, err error) {
-	decoded, err := b58.Decode(input)
+	decoded, err := b58.decodeChecked(input)
 	if err != nil {
 		return 0, nil, err
 	}
 
+	return decoded[0], decoded[1:], nil
+}
+
+// decodeChecked decodes and verifies checksum, returning the version prefix
+// and payload as a single slice. It is used for multi-byte version prefixes.
+func (b58 *Base58) decodeChecked(input string) ([]byte, error) {
+	decoded, err := b58.Decode(input)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(decoded) < 5 {
-		return 0, nil, fmt.Errorf("invalid decoded length")
+		return nil, fmt.Errorf("invalid decoded length")
 	}
 
-	// Split version, payload, and checksum
-	version = decoded[0]
-	payload = decoded[1 : len(decoded)-4]
+	// Split data and checksum
+	data := decoded[:len(decoded)-4]
 	checksum := decoded[len(decoded)-4:]
 
 	// Verify checksum
-	toCheck := decoded[:len(decoded)-4]
-	hash1 := sha256.Sum256(toCheck)
+	hash1 := sha256.Sum256(data)
 	hash2 := sha256.Sum256(hash1[:])
 
 	if !bytes.Equal(hash2[:4], checksum) {
-		return 0, nil, fmt.Errorf("checksum mismatch")
+		return nil, ErrChecksumMismatch
 	}
 
-	return version, payload, nil
+	return data, nil
 }
 
-// DecodeFamilySeed converts an XRPL family seed (starting with 's') to ed25519 private key bytes
+// DecodeFamilySeed converts an XRPL family seed (starting with 's') to private
+// key bytes. Seeds starting with 'sEd' yield an ed25519 private key, all other
+// seeds yield a secp256k1 private key. Use DecodeSeed to learn the key type.
 func DecodeFamilySeed(seed string) ([]byte, error) {
 	if !strings.HasPrefix(seed, "s") {
 		return nil, fmt.Errorf("invalid family seed format: must start with 's'")
 	}
 
-	b58 := NewBase58()
-	version, seedBytes, err := b58.DecodeCheck(seed)
+	privateKey, _, err := DecodeSeed(seed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode seed: %w", err)
+		return nil, err
 	}
 
-	if version != familySeedPrefix[0] {
-		return nil, fmt.Errorf("invalid family seed version byte")
-	}
+	return privateKey, nil
+}
 
-	// Generate ed25519 private key from seed
-	hash := sha512.Sum512(seedBytes)
-	privateKey := ed25519.NewKeyFromSeed(hash[:32])
+// SubmitOption configures SignAndSubmitRequest
+type SubmitOption func(*submitOptions)
 
-	return privateKey, nil
+type submitOptions struct {
+	autofill bool
 }
 
-// sign implements the XRPL transaction signing logic using a family seed
-func (c *Client) sign(msg, familySeed string) (string, error) {
-	privateKey, err := DecodeFamilySeed(familySeed)
+// SubmitWithAutofill populates missing Sequence, Fee and LastLedgerSequence
+// fields with Autofill before the transaction is signed.
+func SubmitWithAutofill() SubmitOption {
+	return func(o *submitOptions) {
+		o.autofill = true
+	}
+}
+
+// SignAndSubmitRequest signs a transaction using a family seed and submits it to the network.
+// The transaction is taken from the request's tx_json field, signed over its canonical
+// binary serialization, and submitted as a tx_blob. A seed that is neither the master
+// key nor the regular key of the transaction's Account is rejected before signing with
+// an error wrapping ErrSigningAccountMismatch. The engine result is classified in the
+// returned SubmitResult; a request the server rejects fails with an *XRPLError.
+func (c *Client) SignAndSubmitRequest(req BaseRequest, familySeed string, opts ...SubmitOption) (*SubmitResult, error) {
+	wallet, err := WalletFromSeed(familySeed)
 	if err != nil {
-		return "", fmt.Errorf("failed to decode family seed: %w", err)
+		return nil, fmt.Errorf("failed to decode family seed: %w", err)
 	}
-	msgHash := sha512.Sum512([]byte(msg))
-	signature := ed25519.Sign(ed25519.PrivateKey(privateKey), msgHash[:])
-	return strings.ToUpper(hex.EncodeToString(signature)), nil
+	return c.SignAndSubmitWithWallet(req, wallet, opts...)
 }
 
-// SignAndSubmitRequest signs a transaction using a family seed and submits it to the network
-func (c *Client) SignAndSubmitRequest(req BaseRequest, familySeed string) (BaseResponse, error) {
+// SignAndSubmitWithWallet signs a transaction with the keys of wallet and submits it, as
+// SignAndSubmitRequest does with a family seed. Wallets derived from a mnemonic, which
+// have no seed, can submit this way.
+func (c *Client) SignAndSubmitWithWallet(req BaseRequest, wallet *Wallet, opts ...SubmitOption) (*SubmitResult, error) {
 	txJSON, ok := req["tx_json"].(map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("tx_json field missing or invalid in request")
 	}
 
-	privateKey, err := DecodeFamilySeed(familySeed)
+	var options submitOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := c.checkSignerKey(txJSON, wallet.ClassicAddress); err != nil {
+		return nil, err
+	}
+	if options.autofill {
+		if err := c.Autofill(txJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	txBlob, err := wallet.signInPlace(txJSON)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode family seed: %w", err)
+		return nil, err
+	}
+
+	submitReq := BaseRequest{
+		"command": "submit",
+		"tx_blob": strings.ToUpper(hex.EncodeToString(txBlob)),
+	}
+	res, err := c.Request(submitReq)
+	if err != nil {
+		return nil, err
 	}
+	return parseSubmitResult(submitReq, res)
+}
 
-	pubKey := ed25519.PrivateKey(privateKey).Public()
-	txJSON["SigningPubKey"] = hex.EncodeToString(pubKey.(ed25519.PublicKey))
+// ErrSigningAccountMismatch is returned when the seed a transaction is signed
+// with belongs neither to its Account nor to the regular key of the Account
+var ErrSigningAccountMismatch = errors.New("seed cannot sign for the transaction Account")
 
-	message, err := json.Marshal(txJSON)
+// signingAccount returns the address of the key of seed
+func signingAccount(seed string) (string, error) {
+	privateKey, keyType, err := DecodeSeed(seed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal transaction for signing: %w", err)
+		return "", fmt.Errorf("failed to decode family seed: %w", err)
 	}
+	publicKey := publicKeyFor(privateKey, keyType)
+	return DeriveAddress(publicKey)
+}
 
-	signature, err := c.sign(string(message), familySeed)
+// checkSigningAccount returns an error wrapping ErrSigningAccountMismatch
+// unless seed is the master key of the transaction's Account, or its
+// regularKey when given
+func checkSigningAccount(tx map[string]interface{}, seed, regularKey string) error {
+	signer, err := signingAccount(seed)
 	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+		return err
 	}
+	return checkSigner(tx, signer, regularKey)
+}
 
-	txJSON["TxnSignature"] = signature
+// checkSigner returns an error wrapping ErrSigningAccountMismatch unless
+// signer, the address of a signing key, is the transaction's Account or its
+// regularKey when given
+func checkSigner(tx map[string]interface{}, signer, regularKey string) error {
+	account, _ := tx["Account"].(string)
+	if signer != account && signer != regularKey {
+		return fmt.Errorf("%w: the signing key is that of %s, not of %s", ErrSigningAccountMismatch, signer, account)
+	}
+	return nil
+}
 
-	submitReq := BaseRequest{
-		"command": "submit",
-		"tx_json": txJSON,
+// checkSigningKey checks that seed can sign tx, either as the master key of
+// the transaction's Account or as its regular key, which is looked up when
+// the seed is not the master key.
+func (c *Client) checkSigningKey(tx map[string]interface{}, seed string) error {
+	signer, err := signingAccount(seed)
+	if err != nil {
+		return err
+	}
+	return c.checkSignerKey(tx, signer)
+}
+
+// checkSignerKey checks that the key of address signer can sign tx, as
+// checkSigningKey does for a seed
+func (c *Client) checkSignerKey(tx map[string]interface{}, signer string) error {
+	err := checkSigner(tx, signer, "")
+	if !errors.Is(err, ErrSigningAccountMismatch) {
+		return err
+	}
+	account, _ := tx["Account"].(string)
+	info, infoErr := c.AccountInfo(account, AccountInfoLedgerIndex(LedgerCurrent()))
+	if infoErr != nil {
+		return fmt.Errorf("failed to look up the regular key of %s: %w", account, infoErr)
+	}
+	if info.RegularKey == "" || info.RegularKey != signer {
+		return err
+	}
+	return nil
+}
+
+// signTransaction sets the SigningPubKey and TxnSignature fields of txJSON
+// for the key of familySeed and returns the serialized signed transaction.
+// The message signed is the canonical signing data of the transaction;
+// ed25519 signs it directly, secp256k1 signs its SHA-512Half hash.
+func signTransaction(txJSON map[string]interface{}, familySeed string) ([]byte, error) {
+	privateKey, keyType, err := DecodeSeed(familySeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode family seed: %w", err)
+	}
+	return signTransactionWithKey(txJSON, privateKey, keyType)
+}
+
+// signTransactionWithKey signs txJSON as signTransaction does, with a private
+// key of keyType as returned by DecodeSeed
+func signTransactionWithKey(txJSON map[string]interface{}, privateKey []byte, keyType KeyType) ([]byte, error) {
+	if err := validateTxFlags(txJSON); err != nil {
+		return nil, err
 	}
 
-	return c.Request(submitReq)
+	txJSON["SigningPubKey"] = strings.ToUpper(hex.EncodeToString(publicKeyFor(privateKey, keyType)))
+
+	message, err := EncodeForSigning(txJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize transaction for signing: %w", err)
+	}
+
+	signature := signWithKey(privateKey, keyType, message)
+	txJSON["TxnSignature"] = strings.ToUpper(hex.EncodeToString(signature))
+
+	txBlob, err := EncodeTransaction(txJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize signed transaction: %w", err)
+	}
+	return txBlob, nil
 }
 
-// DeriveAddress derives an XRPL address from a public key
+// DeriveAddress derives an XRPL address from a public key. The public key is
+// the 33 byte SigningPubKey form: a compressed secp256k1 point, or an ed25519
+// key prefixed with 0xED. A bare 32 byte ed25519 key is accepted as well.
 func DeriveAddress(publicKey []byte) (string, error) {
-	if len(publicKey) != ed25519.PublicKeySize {
+	switch {
+	case len(publicKey) == ed25519.PublicKeySize:
+		publicKey = append([]byte{ed25519PublicKeyPrefix}, publicKey...)
+	case len(publicKey) != ed25519.PublicKeySize+1:
 		return "", fmt.Errorf("invalid public key length")
 	}
 
-	// Hash the public key
-	hash := sha512.Sum512(publicKey)
-	ripemd160Hash := hash[:20] // Use first 20 bytes
+	// The account ID is RIPEMD-160 of the SHA-256 hash of the public key
+	sha256Hash := sha256.Sum256(publicKey)
+	hasher := ripemd160.New()
+	hasher.Write(sha256Hash[:])
+	accountID := hasher.Sum(nil)
 
 	// Create XRPL address using base58check encoding
 	b58 := NewBase58()
-	address := b58.EncodeCheck(accountPublicPrefix[0], ripemd160Hash)
+	address := b58.EncodeCheck(accountIDPrefix[0], accountID)
 
 	return address, nil
 }
@@ -0,0 +1,102 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestBase58(t *testing.T) {
+	tests := []struct {
+		input   []byte
+		encoded string
+	}{
+		{[]byte{}, ""},
+		{[]byte{0}, "r"},
+		{[]byte{0, 0, 1}, "rrp"},
+		{[]byte("rDTXLQ7ZKZVKz33zJbHjgVShjsBnqMBhmN"), "s2Fku4vaPpFiqqXdAD3V5rYrSx5a9h9qvUJW3423akZSCeD"},
+		{[]byte("rJrpjzcxwQxokkqPxm62o5rtNfe2XimrTr"), "s2i2Jk6bF44eDSXnnMjxeVhnYZ3qmbteqesuhS6Tz7CSd9j"},
+		{[]byte("rUxb5vn9fGYRV3KZcnu3JLM4q5DTnNSavf"), "s2uiNSCBQnQfsVtnX49adC9QqtWNP8upC16t7GFLrmbR7tm"},
+	}
+	b58 := NewBase58()
+	for _, test := range tests {
+		if encoded := b58.Encode(test.input); encoded != test.encoded {
+			t.Errorf("Encode(%x) = %q, want %q", test.input, encoded, test.encoded)
+		}
+		decoded, err := b58.Decode(test.encoded)
+		if err != nil {
+			t.Errorf("Decode(%q): %v", test.encoded, err)
+		} else if !bytes.Equal(decoded, test.input) {
+			t.Errorf("Decode(%q) = %x, want %x", test.encoded, decoded, test.input)
+		}
+	}
+}
+
+func BenchmarkBase58Encode(b *testing.B) {
+	// The size of an account ID with its version byte and checksum
+	input := make([]byte, 25)
+	for i := range input {
+		input[i] = byte(i * 37)
+	}
+	b58 := NewBase58()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b58.Encode(input)
+	}
+}
+
+func FuzzBase58RoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0})
+	f.Add([]byte{0, 0, 0, 0xff})
+	f.Add([]byte{0, 0x01, 0x00, 0x00})
+	f.Add(bytes.Repeat([]byte{0xff}, 33))
+	b58 := NewBase58()
+	f.Fuzz(func(t *testing.T, input []byte) {
+		encoded := b58.Encode(input)
+		decoded, err := b58.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode(%q): %v", encoded, err)
+		}
+		if !bytes.Equal(decoded, input) {
+			t.Fatalf("round trip of %x gave %x", input, decoded)
+		}
+	})
+}
+
+func TestDeriveAddress(t *testing.T) {
+	tests := []struct {
+		publicKey string
+		address   string
+	}{
+		// Public key of the genesis seed snoPBrXtMeMyMHUVTgbuqAfg1SUTb
+		{"0330E7FC9D56BB25D6893BA3F317AE5BCF33B3291BD63DB32654A313222F7FD020", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"},
+		{"ED731C39781B964904E1FEEFFC9F99442196BCB5F499105A79533E2D678CA7D3D2", "rhTCnDC7v1Jp7NAupzisv6ynWHD161Q9nV"},
+		// A bare ed25519 key is prefixed with 0xED
+		{"731C39781B964904E1FEEFFC9F99442196BCB5F499105A79533E2D678CA7D3D2", "rhTCnDC7v1Jp7NAupzisv6ynWHD161Q9nV"},
+	}
+	for _, test := range tests {
+		publicKey, _ := hex.DecodeString(test.publicKey)
+		address, err := DeriveAddress(publicKey)
+		if err != nil {
+			t.Errorf("%s: %v", test.publicKey, err)
+		} else if address != test.address {
+			t.Errorf("%s: got %s, want %s", test.publicKey, address, test.address)
+		}
+	}
+
+	if _, err := DeriveAddress(make([]byte, 20)); err == nil {
+		t.Error("20 byte public key: no error")
+	}
+}
+
+func TestGenesisAddress(t *testing.T) {
+	wallet, err := WalletFromSeed("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.ClassicAddress != "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh" {
+		t.Errorf("got %s, want the genesis account rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", wallet.ClassicAddress)
+	}
+}
@@ -0,0 +1,105 @@
+package xrpl
+
+// ConnState is the state of a client's websocket connection
+type ConnState int
+
+const (
+	// ConnStateDisconnected: no connection is up and none is being dialed,
+	// because dialing failed, reconnecting is disabled or was abandoned
+	ConnStateDisconnected ConnState = iota
+	// ConnStateConnected: the connection is up
+	ConnStateConnected
+	// ConnStateReconnecting: the connection dropped and is being dialed again
+	ConnStateReconnecting
+	// ConnStateClosed: Close was called
+	ConnStateClosed
+)
+
+// Number of state changes StateChanges buffers for a slow consumer
+const stateChangesCapacity = 16
+
+func (s ConnState) String() string {
+	switch s {
+	case ConnStateDisconnected:
+		return "disconnected"
+	case ConnStateConnected:
+		return "connected"
+	case ConnStateReconnecting:
+		return "reconnecting"
+	case ConnStateClosed:
+		return "closed"
+	}
+	return "unknown"
+}
+
+// State returns the current state of the client's connection. Clients using
+// HTTP or a custom Transport are ConnStateConnected until closed.
+func (c *Client) State() ConnState {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.state
+}
+
+// StateChanges returns a channel receiving each new state of the client's
+// connection. The transitions are:
+//
+//   - Disconnected or Closed to Connected when NewClient or NewConnection
+//     connects, and to Disconnected when NewConnection fails to. A client
+//     whose first connection succeeded has Connected buffered already.
+//   - Connected to Reconnecting when the connection drops, or Reconnect is
+//     called, before requests pending on it fail with ErrDisconnected. With
+//     ReconnectConfig.Disabled a dropped connection goes to Disconnected
+//     instead.
+//   - Reconnecting to Connected once a new connection is dialed, before
+//     streams are re-subscribed and the OnReconnect hooks run.
+//   - Reconnecting to Disconnected when reconnecting is abandoned after
+//     ReconnectConfig.MaxAttempts or fails in Reconnect.
+//   - Any state to Closed when Close is called.
+//
+// The channel is never closed, as NewConnection may connect a closed client
+// again. It buffers up to 16 changes; when a consumer falls behind, the
+// oldest changes are dropped so that the client never blocks on it and the
+// latest state is always delivered.
+//
+// Example usage:
+//
+//	go func() {
+//		for state := range client.StateChanges() {
+//			fmt.Println("connection", state)
+//		}
+//	}()
+func (c *Client) StateChanges() <-chan ConnState {
+	return c.stateChanges
+}
+
+// setState records a new state of the connection and emits it on
+// stateChanges, dropping the oldest buffered change if the buffer is full.
+// The caller must hold c.mutex.
+func (c *Client) setState(state ConnState) {
+	if c.state == state {
+		return
+	}
+	c.state = state
+	for {
+		select {
+		case c.stateChanges <- state:
+			return
+		default:
+		}
+		select {
+		case <-c.stateChanges:
+		default:
+		}
+	}
+}
+
+// changeState is setState for callers not holding c.mutex. A closed client
+// stays closed.
+func (c *Client) changeState(state ConnState) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.closed {
+		return
+	}
+	c.setState(state)
+}
@@ -1,6 +1,11 @@
 package xrpl
 
 import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -37,3 +42,88 @@ func IsoTimeToRippleTime(isoTime string) (int64, error) {
 	rippleTime := UnixTimeToRippleTime(theTime.Unix())
 	return rippleTime, nil
 }
+
+// Convert a Ripple timestamp, as found in close_time and expiration fields,
+// to a time.Time in UTC.
+func RippleTimeToTime(rt uint32) time.Time {
+	return time.Unix(RippleTimeToUnixTime(int64(rt)), 0).UTC()
+}
+
+// Convert a time.Time to a Ripple timestamp. Fractional seconds are
+// truncated. Times before the Ripple Epoch, or too far in the future to be
+// represented in 32 bits (after 2136-02-07T06:28:15Z), are rejected.
+func TimeToRippleTime(t time.Time) (uint32, error) {
+	rippleTime := UnixTimeToRippleTime(t.Unix())
+	if rippleTime < 0 {
+		return 0, fmt.Errorf("time %s is before the Ripple Epoch", t.UTC().Format(time.RFC3339))
+	}
+	if rippleTime > math.MaxUint32 {
+		return 0, fmt.Errorf("time %s is too late for a Ripple timestamp", t.UTC().Format(time.RFC3339))
+	}
+	return uint32(rippleTime), nil
+}
+
+/*
+ * XRP to drops conversion
+ */
+
+// Number of drops in one XRP
+const DropsPerXRP = 1000000
+
+// Largest amount of XRP that can exist, in drops: 100 billion XRP
+const MaxDrops uint64 = 100000000000000000
+
+var (
+	xrpAmountPattern   = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+	dropsAmountPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// Convert an amount of XRP given as a decimal string, e.g. "1.5", to an
+// integer amount of drops, e.g. "1500000". The amount may have at most 6
+// decimal places and must not be negative or exceed MaxDrops.
+func XRPToDrops(xrp string) (string, error) {
+	if !xrpAmountPattern.MatchString(xrp) {
+		return "", fmt.Errorf("invalid XRP amount %q: must be a non-negative decimal number", xrp)
+	}
+	if i := strings.IndexByte(xrp, '.'); i >= 0 && len(xrp)-i-1 > 6 {
+		return "", fmt.Errorf("invalid XRP amount %q: more than 6 decimal places", xrp)
+	}
+
+	amount, ok := new(big.Rat).SetString(xrp)
+	if !ok {
+		return "", fmt.Errorf("invalid XRP amount %q", xrp)
+	}
+	drops := amount.Mul(amount, big.NewRat(DropsPerXRP, 1))
+	if err := checkMaxDrops(drops.Num()); err != nil {
+		return "", err
+	}
+	return drops.Num().String(), nil
+}
+
+// Convert an integer amount of drops given as a string, e.g. "1500000", to
+// a decimal amount of XRP, e.g. "1.5". The amount must not be negative or
+// exceed MaxDrops.
+func DropsToXRP(drops string) (string, error) {
+	if !dropsAmountPattern.MatchString(drops) {
+		return "", fmt.Errorf("invalid drops amount %q: must be a non-negative integer", drops)
+	}
+
+	amount, ok := new(big.Int).SetString(drops, 10)
+	if !ok {
+		return "", fmt.Errorf("invalid drops amount %q", drops)
+	}
+	if err := checkMaxDrops(amount); err != nil {
+		return "", err
+	}
+
+	xrp := new(big.Rat).SetFrac(amount, big.NewInt(DropsPerXRP)).FloatString(6)
+	xrp = strings.TrimRight(xrp, "0")
+	return strings.TrimSuffix(xrp, "."), nil
+}
+
+func checkMaxDrops(drops *big.Int) error {
+	if drops.Cmp(new(big.Int).SetUint64(MaxDrops)) > 0 {
+		return fmt.Errorf("amount of %s drops exceeds the maximum of %d drops", drops, MaxDrops)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package xrpl
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRippleTime(t *testing.T) {
+	tests := []struct {
+		rippleTime uint32
+		time       time.Time
+	}{
+		{0, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{31622400, time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{946771200, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{math.MaxUint32, time.Date(2136, 2, 7, 6, 28, 15, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		if got := RippleTimeToTime(test.rippleTime); !got.Equal(test.time) || got.Location() != time.UTC {
+			t.Errorf("RippleTimeToTime(%d) = %s, want %s", test.rippleTime, got, test.time)
+		}
+		got, err := TimeToRippleTime(test.time)
+		if err != nil {
+			t.Errorf("TimeToRippleTime(%s): %v", test.time, err)
+		} else if got != test.rippleTime {
+			t.Errorf("TimeToRippleTime(%s) = %d, want %d", test.time, got, test.rippleTime)
+		}
+	}
+
+	// The Ripple Epoch is Unix time 946684800
+	if got := RippleTimeToUnixTime(0); got != 946684800 {
+		t.Errorf("RippleTimeToUnixTime(0) = %d, want 946684800", got)
+	}
+	if got, err := TimeToRippleTime(time.Unix(946684800, 0)); err != nil || got != 0 {
+		t.Errorf("TimeToRippleTime(Unix 946684800) = %d, %v, want 0", got, err)
+	}
+	// Fractional seconds are truncated
+	if got, err := TimeToRippleTime(time.Unix(946684801, 999999999)); err != nil || got != 1 {
+		t.Errorf("TimeToRippleTime(Unix 946684801.999999999) = %d, %v, want 1", got, err)
+	}
+}
+
+func TestTimeToRippleTimeOutOfRange(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Unix(946684799, 0),
+		time.Unix(0, 0),
+		time.Date(2136, 2, 7, 6, 28, 16, 0, time.UTC),
+	} {
+		if got, err := TimeToRippleTime(tm); err == nil {
+			t.Errorf("TimeToRippleTime(%s) = %d, want an error", tm.UTC(), got)
+		}
+	}
+}
+
+func TestISOTime(t *testing.T) {
+	if got := RippleTimeToISOTime(946771200); got != "2030-01-01T00:00:00Z" {
+		t.Errorf("RippleTimeToISOTime(946771200) = %s", got)
+	}
+	if got, err := IsoTimeToRippleTime("2001-01-01T00:00:00Z"); err != nil || got != 31622400 {
+		t.Errorf("IsoTimeToRippleTime(2001-01-01T00:00:00Z) = %d, %v, want 31622400", got, err)
+	}
+	if _, err := IsoTimeToRippleTime("invalid"); err == nil {
+		t.Error("invalid ISO time: no error")
+	}
+}
@@ -0,0 +1,68 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Characters allowed in a standard three character currency code
+// https://xrpl.org/docs/references/protocol/data-types/currency-formats#standard-currency-codes
+var standardCurrencyPattern = regexp.MustCompile(`^[A-Za-z0-9?!@#$%^&*<>(){}\[\]|]{3}$`)
+
+// EncodeCurrency converts a currency code into its 160-bit on-wire form as a
+// 40 character uppercase hex string. A standard three character code such as
+// "USD" is placed in bytes 12 to 14 of an otherwise zero code; a 40
+// character hex code is returned in uppercase. XRP cannot be issued and is
+// rejected, both as "XRP" and as the all-zero code that denotes it.
+func EncodeCurrency(code string) (string, error) {
+	if len(code) == 3 && !standardCurrencyPattern.MatchString(code) {
+		return "", fmt.Errorf("invalid currency code %q", code)
+	}
+	b, err := currencyCodeBytes(code, false)
+	if err != nil {
+		return "", err
+	}
+	if isNativeCurrency(b) || (isStandardCurrency(b) && string(b[12:15]) == XRPL_NATIVE_ASSET) {
+		return "", fmt.Errorf("XRP is not a valid token currency code")
+	}
+	return strings.ToUpper(hex.EncodeToString(b)), nil
+}
+
+// DecodeCurrency converts a 40 character hex currency code into its human
+// readable form. A code in the standard format, which holds three ASCII
+// characters in bytes 12 to 14 and zeros elsewhere, decodes to those three
+// characters. Codes in any other, non-standard format are returned as
+// uppercase hex.
+func DecodeCurrency(hexCode string) (string, error) {
+	if len(hexCode) != 40 {
+		return "", fmt.Errorf("invalid hex currency code %q: must be 40 characters", hexCode)
+	}
+	b, err := hex.DecodeString(hexCode)
+	if err != nil {
+		return "", fmt.Errorf("invalid hex currency code %q", hexCode)
+	}
+	if isNativeCurrency(b) {
+		return "", fmt.Errorf("XRP is not a valid token currency code")
+	}
+
+	if isStandardCurrency(b) {
+		code := string(b[12:15])
+		if standardCurrencyPattern.MatchString(code) && code != XRPL_NATIVE_ASSET {
+			return code, nil
+		}
+	}
+	return strings.ToUpper(hexCode), nil
+}
+
+func isNativeCurrency(b []byte) bool {
+	return bytes.Equal(b, make([]byte, 20))
+}
+
+// isStandardCurrency reports whether a 160-bit currency code has the standard
+// format: all bytes are zero except for the three character code.
+func isStandardCurrency(b []byte) bool {
+	return bytes.Equal(b[:12], make([]byte, 12)) && bytes.Equal(b[15:], make([]byte, 5))
+}
@@ -0,0 +1,170 @@
+package xrpl
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"unicode"
+)
+
+// Field, type and enumeration definitions of the XRPL binary format, as
+// published with rippled and the official ripple-binary-codec package.
+//
+//go:embed definitions.json
+var definitionsJSON []byte
+
+// FieldMeta describes how a single field is serialized, as given by the
+// definitions table. Fields are identified in the binary format by their
+// TypeCode and FieldCode, and ordered by them.
+type FieldMeta struct {
+	Name           string
+	FieldCode      int    // "nth" in the definitions, unique within a type
+	Type           string // e.g. "UInt32", "Amount" or "STObject"
+	TypeCode       int
+	IsVLEncoded    bool // value is prefixed with its length
+	IsSerialized   bool // false for fields that exist only in JSON
+	IsSigningField bool // false for fields left out of the signed data
+}
+
+// header returns the field ID that precedes the field's value in the binary
+// format. Type and field codes below 16 share a single byte.
+func (f *FieldMeta) header() []byte {
+	switch {
+	case f.TypeCode < 16 && f.FieldCode < 16:
+		return []byte{byte(f.TypeCode<<4 | f.FieldCode)}
+	case f.TypeCode < 16:
+		return []byte{byte(f.TypeCode << 4), byte(f.FieldCode)}
+	case f.FieldCode < 16:
+		return []byte{byte(f.FieldCode), byte(f.TypeCode)}
+	default:
+		return []byte{0, byte(f.TypeCode), byte(f.FieldCode)}
+	}
+}
+
+type binaryDefinitions struct {
+	types              map[string]int
+	fields             map[string]*FieldMeta
+	fieldsByID         map[[2]int]*FieldMeta
+	transactionTypes   map[string]int
+	ledgerEntryTypes   map[string]int
+	transactionResults map[string]int
+}
+
+var definitions = mustLoadDefinitions(definitionsJSON)
+
+func mustLoadDefinitions(data []byte) *binaryDefinitions {
+	var raw struct {
+		Types              map[string]int       `json:"TYPES"`
+		Fields             [][2]json.RawMessage `json:"FIELDS"`
+		TransactionTypes   map[string]int       `json:"TRANSACTION_TYPES"`
+		LedgerEntryTypes   map[string]int       `json:"LEDGER_ENTRY_TYPES"`
+		TransactionResults map[string]int       `json:"TRANSACTION_RESULTS"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		panic(fmt.Errorf("invalid binary codec definitions: %w", err))
+	}
+
+	defs := &binaryDefinitions{
+		types:              raw.Types,
+		fields:             make(map[string]*FieldMeta, len(raw.Fields)),
+		fieldsByID:         make(map[[2]int]*FieldMeta, len(raw.Fields)),
+		transactionTypes:   raw.TransactionTypes,
+		ledgerEntryTypes:   raw.LedgerEntryTypes,
+		transactionResults: raw.TransactionResults,
+	}
+	for _, entry := range raw.Fields {
+		field := &FieldMeta{}
+		var info struct {
+			Nth            int    `json:"nth"`
+			IsVLEncoded    bool   `json:"isVLEncoded"`
+			IsSerialized   bool   `json:"isSerialized"`
+			IsSigningField bool   `json:"isSigningField"`
+			Type           string `json:"type"`
+		}
+		if err := json.Unmarshal(entry[0], &field.Name); err != nil {
+			panic(fmt.Errorf("invalid binary codec field name: %w", err))
+		}
+		if err := json.Unmarshal(entry[1], &info); err != nil {
+			panic(fmt.Errorf("invalid binary codec field %s: %w", field.Name, err))
+		}
+		field.FieldCode = info.Nth
+		field.Type = info.Type
+		field.TypeCode = raw.Types[info.Type]
+		field.IsVLEncoded = info.IsVLEncoded
+		field.IsSerialized = info.IsSerialized
+		field.IsSigningField = info.IsSigningField
+
+		defs.fields[field.Name] = field
+		if field.IsSerialized {
+			defs.fieldsByID[[2]int{field.TypeCode, field.FieldCode}] = field
+		}
+	}
+	return defs
+}
+
+// FieldByName returns the definition of the field name, such as "Account" or
+// "TakerPays"
+func FieldByName(name string) (FieldMeta, bool) {
+	field, ok := definitions.fields[name]
+	if !ok {
+		return FieldMeta{}, false
+	}
+	return *field, true
+}
+
+// CanonicalFieldOrder returns the definitions of the keys of fields in the
+// order they are serialized in: ascending by type code and then by field
+// code. This is the order EncodeTransaction, EncodeForSigning and
+// EncodeForMultisigning write an object's fields in. As there, keys starting
+// with a lower case letter, which are API annotations such as hash or
+// ledger_index, and fields that are not serialized are left out; any other
+// key that is not a known field is an error.
+//
+// Example usage:
+//
+//	order, err := xrpl.CanonicalFieldOrder(tx)
+//	if err != nil {
+//		return err
+//	}
+//	for _, field := range order {
+//		fmt.Println(field.Name, field.TypeCode, field.FieldCode)
+//	}
+func CanonicalFieldOrder(fields map[string]interface{}) ([]FieldMeta, error) {
+	sorted, err := canonicalFields(fields, false)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]FieldMeta, len(sorted))
+	for i, field := range sorted {
+		order[i] = *field
+	}
+	return order, nil
+}
+
+// canonicalFields returns the definitions of the fields of obj to serialize,
+// in canonical order. With signingOnly, fields that are not signed are left
+// out as well.
+func canonicalFields(obj map[string]interface{}, signingOnly bool) ([]*FieldMeta, error) {
+	fields := make([]*FieldMeta, 0, len(obj))
+	for name := range obj {
+		if name == "" || unicode.IsLower(rune(name[0])) {
+			continue
+		}
+		field, ok := definitions.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		if !field.IsSerialized || (signingOnly && !field.IsSigningField) {
+			continue
+		}
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].TypeCode != fields[j].TypeCode {
+			return fields[i].TypeCode < fields[j].TypeCode
+		}
+		return fields[i].FieldCode < fields[j].FieldCode
+	})
+	return fields, nil
+}
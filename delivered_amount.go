@@ -0,0 +1,93 @@
+package xrpl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDeliveredAmountUnavailable is returned by DeliveredAmount when the
+// metadata does not say what a partial payment delivered, as for payments
+// from before 2014
+var ErrDeliveredAmountUnavailable = errors.New("delivered amount unavailable")
+
+// DeliveredAmount returns the amount a transaction actually delivered to its
+// destination. For partial payments it may be much less than the Amount of
+// the transaction, so code crediting deposits must use it rather than
+// Amount.
+//
+// The txResult is a transaction with its metadata: the result of the tx
+// command, of either API version, an entry of account_tx or Ledger
+// transactions, or a transaction stream message. The amount is read from
+// meta.delivered_amount, or the DeliveredAmount field of older metadata.
+// When the metadata reports neither, Amount is returned for Payments without
+// TfPartialPayment, which deliver all of it, and ErrDeliveredAmountUnavailable
+// otherwise. Transactions that did not succeed delivered nothing and return
+// an error.
+//
+// Example usage:
+//
+//	res, err := client.Request(xrpl.BaseRequest{"command": "tx", "transaction": hash})
+//	if err != nil {
+//		return err
+//	}
+//	delivered, err := xrpl.DeliveredAmount(res["result"].(map[string]interface{}))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("received", delivered)
+func DeliveredAmount(txResult map[string]interface{}) (Amount, error) {
+	if result, ok := txResult["result"].(map[string]interface{}); ok && txResult["meta"] == nil {
+		txResult = result
+	}
+	meta, ok := txResult["meta"].(map[string]interface{})
+	if !ok {
+		meta, ok = txResult["metaData"].(map[string]interface{})
+	}
+	if !ok {
+		return Amount{}, fmt.Errorf("transaction result has no JSON metadata")
+	}
+	tx := txFields(txResult)
+
+	if result, _ := meta["TransactionResult"].(string); result != "" && result != "tesSUCCESS" {
+		return Amount{}, fmt.Errorf("transaction failed with %s and delivered nothing", result)
+	}
+	for _, field := range []string{"delivered_amount", "DeliveredAmount"} {
+		delivered, ok := meta[field]
+		if !ok || delivered == "unavailable" {
+			continue
+		}
+		var amount Amount
+		if err := remarshal(delivered, &amount); err != nil {
+			return Amount{}, fmt.Errorf("invalid %s: %w", field, err)
+		}
+		return amount, nil
+	}
+
+	if tx["TransactionType"] != string(TxTypePayment) {
+		return Amount{}, ErrDeliveredAmountUnavailable
+	}
+	flags, _ := tx["Flags"].(float64)
+	if uint32(flags)&TfPartialPayment != 0 {
+		return Amount{}, ErrDeliveredAmountUnavailable
+	}
+	if tx["Amount"] == nil {
+		return Amount{}, fmt.Errorf("payment has no Amount")
+	}
+	var amount Amount
+	if err := remarshal(tx["Amount"], &amount); err != nil {
+		return Amount{}, fmt.Errorf("invalid payment Amount: %w", err)
+	}
+	return amount, nil
+}
+
+// txFields returns the transaction fields of a transaction result: tx_json
+// in API v2, tx in account_tx entries of v1, transaction in stream messages,
+// or the result itself for the tx command and Ledger transactions of v1
+func txFields(txResult map[string]interface{}) map[string]interface{} {
+	for _, field := range []string{"tx_json", "tx", "transaction"} {
+		if tx, ok := txResult[field].(map[string]interface{}); ok {
+			return tx
+		}
+	}
+	return txResult
+}
@@ -0,0 +1,37 @@
+package xrpl
+
+import "fmt"
+
+// BuildDepositPreauth builds a DepositPreauth transaction from account, ready
+// to be autofilled and signed, preauthorizing authorize to send it funds once
+// it requires deposit authorization, see AccountSetDepositAuth.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildDepositPreauth(wallet.ClassicAddress, "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+func BuildDepositPreauth(account, authorize string) (map[string]interface{}, error) {
+	return buildDepositPreauth(account, "Authorize", authorize)
+}
+
+// BuildDepositPreauthUnauthorize builds a DepositPreauth transaction from
+// account, ready to be autofilled and signed, revoking the preauthorization
+// of unauthorize.
+func BuildDepositPreauthUnauthorize(account, unauthorize string) (map[string]interface{}, error) {
+	return buildDepositPreauth(account, "Unauthorize", unauthorize)
+}
+
+// buildDepositPreauth builds a DepositPreauth setting one of its Authorize
+// and Unauthorize fields, which the network requires exactly one of
+func buildDepositPreauth(account, field, sender string) (map[string]interface{}, error) {
+	if err := ValidateClassicAddress(sender); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+	if sender == account {
+		return nil, fmt.Errorf("invalid %s: %s cannot preauthorize itself", field, account)
+	}
+	return map[string]interface{}{
+		"TransactionType": string(TxTypeDepositPreauth),
+		"Account":         account,
+		field:             sender,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrAccountNotFound matches, with errors.Is, the actNotFound error of
+// requests for an account that does not exist in the ledger, typically
+// because it has not been funded with the base reserve yet
+var ErrAccountNotFound = errors.New("account not found")
+
+// ErrTxNotFound matches, with errors.Is, the txnNotFound error of requests
+// for a transaction the server does not have, which Tx returns as a
+// *TxNotFoundError
+var ErrTxNotFound = errors.New("transaction not found")
+
+// XRPLError is an error response from rippled, such as
+// {"status": "error", "error": "actNotFound", "error_code": 19, ...}.
+// The full response is kept in Response for debugging.
+//
+// Example usage:
+//
+//	res, err := client.RequestChecked(req)
+//	var xrplErr *xrpl.XRPLError
+//	if errors.As(err, &xrplErr) && xrplErr.Err == "actNotFound" {
+//		// account does not exist
+//	}
+type XRPLError struct {
+	Err      string       // error, e.g. "actNotFound"
+	Code     int          // error_code
+	Message  string       // error_message
+	Request  BaseResponse // request, as echoed by the server
+	Response BaseResponse // the raw response
+}
+
+// Is reports whether the error is actNotFound, for errors.Is(err,
+// ErrAccountNotFound), or txnNotFound, for errors.Is(err, ErrTxNotFound)
+func (e *XRPLError) Is(target error) bool {
+	switch target {
+	case ErrAccountNotFound:
+		return e.Err == "actNotFound"
+	case ErrTxNotFound:
+		return e.Err == "txnNotFound"
+	}
+	return false
+}
+
+func (e *XRPLError) Error() string {
+	command, _ := e.Request["command"].(string)
+	msg := e.Err
+	if command != "" {
+		msg = command + ": " + msg
+	}
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+	return msg
+}
+
+// checkResponse returns an *XRPLError when res reports an error status,
+// either at the top level or inside its result object. Errors inside the
+// result, as some API v1 responses report them, are recognized by their
+// error field even without an error status.
+func checkResponse(req BaseRequest, res BaseResponse) error {
+	fields := map[string]interface{}(res)
+	if res["status"] != "error" {
+		result, ok := res["result"].(map[string]interface{})
+		if !ok || !isErrorResult(result) {
+			return nil
+		}
+		fields = result
+	}
+
+	e := &XRPLError{Response: res}
+	e.Err, _ = fields["error"].(string)
+	e.Message, _ = fields["error_message"].(string)
+	if code, ok := fields["error_code"].(float64); ok {
+		e.Code = int(code)
+	}
+	if request, ok := fields["request"].(map[string]interface{}); ok {
+		e.Request = request
+	} else {
+		e.Request = BaseResponse{"command": req["command"]}
+	}
+	return e
+}
+
+// isErrorResult reports whether the result object of a response is an error
+func isErrorResult(result map[string]interface{}) bool {
+	if result["status"] == "error" {
+		return true
+	}
+	_, hasError := result["error"].(string)
+	return hasError && result["status"] != "success"
+}
+
+// RequestChecked sends a request like Request, but returns an *XRPLError
+// when the server responds with an error status instead of returning the
+// error response itself.
+func (c *Client) RequestChecked(req BaseRequest) (BaseResponse, error) {
+	return c.RequestCheckedCtx(context.Background(), req)
+}
+
+// RequestCheckedCtx is RequestChecked bounded by ctx, as with RequestCtx
+func (c *Client) RequestCheckedCtx(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	res, err := c.RequestCtx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(req, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
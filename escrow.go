@@ -0,0 +1,287 @@
+package xrpl
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EscrowCreateOption configures BuildEscrowCreate
+type EscrowCreateOption func(*escrowCreateOptions)
+
+type escrowCreateOptions struct {
+	finishAfter    time.Time
+	cancelAfter    time.Time
+	condition      string
+	destinationTag *uint32
+	sourceTag      *uint32
+}
+
+// EscrowFinishAfter sets the time after which the escrow can be finished
+func EscrowFinishAfter(t time.Time) EscrowCreateOption {
+	return func(o *escrowCreateOptions) {
+		o.finishAfter = t
+	}
+}
+
+// EscrowCancelAfter sets the time after which the escrow expires and can be
+// cancelled. It must be later than EscrowFinishAfter.
+func EscrowCancelAfter(t time.Time) EscrowCreateOption {
+	return func(o *escrowCreateOptions) {
+		o.cancelAfter = t
+	}
+}
+
+// EscrowCondition locks the escrow with a hex encoded PREIMAGE-SHA-256
+// crypto-condition, as returned by GenerateCondition. Finishing the escrow
+// then requires the matching fulfillment.
+func EscrowCondition(conditionHex string) EscrowCreateOption {
+	return func(o *escrowCreateOptions) {
+		o.condition = conditionHex
+	}
+}
+
+// EscrowDestinationTag sets the tag identifying the recipient at the
+// destination, e.g. a customer of an exchange
+func EscrowDestinationTag(tag uint32) EscrowCreateOption {
+	return func(o *escrowCreateOptions) {
+		o.destinationTag = &tag
+	}
+}
+
+// EscrowSourceTag sets the tag identifying the sender on whose behalf the
+// escrow is created
+func EscrowSourceTag(tag uint32) EscrowCreateOption {
+	return func(o *escrowCreateOptions) {
+		o.sourceTag = &tag
+	}
+}
+
+// BuildEscrowCreate builds an EscrowCreate transaction setting aside amount
+// from account for destination, ready to be autofilled and signed. The
+// escrow must be released by a time, EscrowFinishAfter, or by a condition,
+// EscrowCondition, or both; EscrowCancelAfter lets the sender reclaim the
+// funds after it expires.
+//
+// Example usage:
+//
+//	condition, fulfillment, err := xrpl.GenerateCondition()
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildEscrowCreate(
+//		"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		xrpl.XRPAmount("10000000"),
+//		xrpl.EscrowCondition(condition),
+//		xrpl.EscrowCancelAfter(time.Now().Add(24*time.Hour)))
+func BuildEscrowCreate(account, destination string, amount Amount, opts ...EscrowCreateOption) (map[string]interface{}, error) {
+	var options escrowCreateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid escrow amount: %w", err)
+	}
+	if options.finishAfter.IsZero() && options.condition == "" {
+		return nil, fmt.Errorf("escrow needs a FinishAfter time or a Condition")
+	}
+	if !options.finishAfter.IsZero() && !options.cancelAfter.IsZero() && !options.cancelAfter.After(options.finishAfter) {
+		return nil, fmt.Errorf("escrow CancelAfter must be later than FinishAfter")
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeEscrowCreate),
+		"Account":         account,
+		"Destination":     destination,
+		"Amount":          amount,
+	}
+	if !options.finishAfter.IsZero() {
+		finishAfter, err := TimeToRippleTime(options.finishAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escrow FinishAfter: %w", err)
+		}
+		tx["FinishAfter"] = finishAfter
+	}
+	if !options.cancelAfter.IsZero() {
+		cancelAfter, err := TimeToRippleTime(options.cancelAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escrow CancelAfter: %w", err)
+		}
+		tx["CancelAfter"] = cancelAfter
+	}
+	if options.condition != "" {
+		if _, err := parseCondition(options.condition); err != nil {
+			return nil, err
+		}
+		tx["Condition"] = strings.ToUpper(options.condition)
+	}
+	if options.destinationTag != nil {
+		tx["DestinationTag"] = *options.destinationTag
+	}
+	if options.sourceTag != nil {
+		tx["SourceTag"] = *options.sourceTag
+	}
+	return tx, nil
+}
+
+// EscrowFinishOption configures BuildEscrowFinish
+type EscrowFinishOption func(*escrowFinishOptions)
+
+type escrowFinishOptions struct {
+	condition   string
+	fulfillment string
+}
+
+// EscrowFulfillment finishes a conditional escrow with its hex encoded
+// condition and the matching fulfillment, as returned by GenerateCondition
+func EscrowFulfillment(conditionHex, fulfillmentHex string) EscrowFinishOption {
+	return func(o *escrowFinishOptions) {
+		o.condition = conditionHex
+		o.fulfillment = fulfillmentHex
+	}
+}
+
+// BuildEscrowFinish builds an EscrowFinish transaction, sent by account,
+// delivering the escrow that owner created with the transaction of sequence
+// number offerSequence. A conditional escrow needs EscrowFulfillment. Autofill
+// raises the fee of a transaction carrying a fulfillment to the amount the
+// network requires for it.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildEscrowFinish(
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		7,
+//		xrpl.EscrowFulfillment(condition, fulfillment))
+func BuildEscrowFinish(account, owner string, offerSequence uint32, opts ...EscrowFinishOption) (map[string]interface{}, error) {
+	var options escrowFinishOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeEscrowFinish),
+		"Account":         account,
+		"Owner":           owner,
+		"OfferSequence":   offerSequence,
+	}
+	if options.condition == "" && options.fulfillment == "" {
+		return tx, nil
+	}
+	if options.condition == "" || options.fulfillment == "" {
+		return nil, fmt.Errorf("conditional escrow needs both Condition and Fulfillment")
+	}
+	condition, err := parseCondition(options.condition)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := conditionForFulfillment(options.fulfillment)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(condition, expected) {
+		return nil, fmt.Errorf("escrow Fulfillment does not match Condition")
+	}
+	tx["Condition"] = strings.ToUpper(options.condition)
+	tx["Fulfillment"] = strings.ToUpper(options.fulfillment)
+	return tx, nil
+}
+
+// BuildEscrowCancel builds an EscrowCancel transaction, sent by account,
+// returning the expired escrow that owner created with the transaction of
+// sequence number offerSequence to owner.
+func BuildEscrowCancel(account, owner string, offerSequence uint32) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType": string(TxTypeEscrowCancel),
+		"Account":         account,
+		"Owner":           owner,
+		"OfferSequence":   offerSequence,
+	}
+}
+
+// Length of the random preimage of generated conditions
+const conditionPreimageLength = 32
+
+// GenerateCondition generates a PREIMAGE-SHA-256 crypto-condition from a
+// random 32 byte preimage. Both are returned hex encoded as used in the
+// Condition and Fulfillment fields. The fulfillment releases the escrow and
+// must be kept secret until then.
+func GenerateCondition() (conditionHex, fulfillmentHex string, err error) {
+	preimage := make([]byte, conditionPreimageLength)
+	if _, err := rand.Read(preimage); err != nil {
+		return "", "", fmt.Errorf("failed to generate condition preimage: %w", err)
+	}
+
+	fulfillment := encodeFulfillment(preimage)
+	condition := encodeCondition(preimage)
+	return strings.ToUpper(hex.EncodeToString(condition)), strings.ToUpper(hex.EncodeToString(fulfillment)), nil
+}
+
+// Crypto-conditions are DER encoded, see
+// https://datatracker.ietf.org/doc/html/draft-thomas-crypto-conditions-04.
+// A PREIMAGE-SHA-256 fulfillment is the preimage, tagged [0], inside a
+// context specific constructed tag [0] for the condition type. The condition
+// has the SHA-256 fingerprint of the preimage, tagged [0], and the cost,
+// tagged [1], which is the preimage length.
+const (
+	ccPreimageType = 0xA0
+	ccFingerprint  = 0x80
+	ccCost         = 0x81
+)
+
+func encodeFulfillment(preimage []byte) []byte {
+	body := append([]byte{ccFingerprint, byte(len(preimage))}, preimage...)
+	return append([]byte{ccPreimageType, byte(len(body))}, body...)
+}
+
+func encodeCondition(preimage []byte) []byte {
+	fingerprint := sha256.Sum256(preimage)
+	body := append([]byte{ccFingerprint, byte(len(fingerprint))}, fingerprint[:]...)
+	body = append(body, ccCost, 1, byte(len(preimage)))
+	return append([]byte{ccPreimageType, byte(len(body))}, body...)
+}
+
+// parseCondition decodes a hex encoded PREIMAGE-SHA-256 condition, the only
+// condition type the XRPL supports
+func parseCondition(conditionHex string) ([]byte, error) {
+	condition, err := hex.DecodeString(conditionHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow Condition: %w", err)
+	}
+	if len(condition) != 39 || condition[0] != ccPreimageType || condition[1] != 37 ||
+		condition[2] != ccFingerprint || condition[3] != 32 || condition[36] != ccCost || condition[37] != 1 {
+		return nil, fmt.Errorf("invalid escrow Condition: not a PREIMAGE-SHA-256 condition")
+	}
+	return condition, nil
+}
+
+// conditionForFulfillment decodes a hex encoded PREIMAGE-SHA-256 fulfillment
+// and returns the condition it fulfills
+func conditionForFulfillment(fulfillmentHex string) ([]byte, error) {
+	fulfillment, err := hex.DecodeString(fulfillmentHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid escrow Fulfillment: %w", err)
+	}
+	if len(fulfillment) < 4 || fulfillment[0] != ccPreimageType || int(fulfillment[1]) != len(fulfillment)-2 ||
+		fulfillment[2] != ccFingerprint || int(fulfillment[3]) != len(fulfillment)-4 || fulfillment[1] > 127 {
+		return nil, fmt.Errorf("invalid escrow Fulfillment: not a PREIMAGE-SHA-256 fulfillment")
+	}
+	return encodeCondition(fulfillment[4:]), nil
+}
+
+// escrowFinishFeeUnits returns the multiple of the base fee a transaction
+// costs: 33 plus one per 16 bytes of fulfillment for an EscrowFinish with a
+// Fulfillment, and 1 otherwise.
+func escrowFinishFeeUnits(tx map[string]interface{}) uint64 {
+	fulfillment, ok := tx["Fulfillment"].(string)
+	if !ok || tx["TransactionType"] != string(TxTypeEscrowFinish) {
+		return 1
+	}
+	return 33 + uint64(len(fulfillment)/2)/16
+}
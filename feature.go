@@ -0,0 +1,126 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FeatureStatus describes an amendment as known to the server, as reported
+// by the feature command. The voting fields are only set while the amendment
+// is not yet enabled.
+type FeatureStatus struct {
+	Hash        string `json:"-"`    // ID of the amendment
+	Name        string `json:"name"` // e.g. "NonFungibleTokensV1_1"
+	Enabled     bool   `json:"enabled"`
+	Supported   bool   `json:"supported"`             // The server can process it once enabled
+	Vetoed      bool   `json:"-"`                     // The server votes against it
+	Obsolete    bool   `json:"-"`                     // Retired and never to be enabled
+	Majority    uint32 `json:"majority,omitempty"`    // Since when it has a majority, in seconds since the Ripple epoch
+	Count       uint32 `json:"count,omitempty"`       // Trusted validators voting for it
+	Threshold   uint32 `json:"threshold,omitempty"`   // Votes needed for a majority
+	Validations uint32 `json:"validations,omitempty"` // Trusted validators
+}
+
+// UnmarshalJSON decodes a feature status, whose vetoed field is a boolean or
+// "Obsolete" for retired amendments
+func (f *FeatureStatus) UnmarshalJSON(data []byte) error {
+	type status FeatureStatus
+	var raw struct {
+		status
+		Vetoed interface{} `json:"vetoed"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*f = FeatureStatus(raw.status)
+	switch v := raw.Vetoed.(type) {
+	case bool:
+		f.Vetoed = v
+	case string:
+		f.Vetoed = true
+		f.Obsolete = v == "Obsolete"
+	}
+	return nil
+}
+
+// Retrieve the status of every amendment known to the server, by amendment
+// ID.
+//
+// Example usage:
+//
+//	features, err := client.Features()
+//	if err != nil {
+//		return err
+//	}
+//	for _, feature := range features {
+//		if !feature.Enabled && feature.Count > 0 {
+//			fmt.Println(feature.Name, "has", feature.Count, "of", feature.Threshold, "votes")
+//		}
+//	}
+func (c *Client) Features() (map[string]FeatureStatus, error) {
+	var result struct {
+		Features map[string]FeatureStatus `json:"features"`
+	}
+	if err := c.requestResult(BaseRequest{"command": "feature"}, &result); err != nil {
+		return nil, err
+	}
+	for hash, feature := range result.Features {
+		feature.Hash = hash
+		result.Features[hash] = feature
+	}
+	return result.Features, nil
+}
+
+// Retrieve the status of a single amendment, by name or ID. An amendment the
+// server does not know fails with an *XRPLError of badFeature.
+//
+// Example usage:
+//
+//	feature, err := client.Feature("AMM")
+//	if err != nil {
+//		return err
+//	}
+//	if !feature.Enabled {
+//		return fmt.Errorf("AMM transactions are not available on this network yet")
+//	}
+func (c *Client) Feature(name string) (*FeatureStatus, error) {
+	var result map[string]json.RawMessage
+	if err := c.requestResult(BaseRequest{"command": "feature", "feature": name}, &result); err != nil {
+		return nil, err
+	}
+	for hash, raw := range result {
+		var feature FeatureStatus
+		if json.Unmarshal(raw, &feature) != nil {
+			// Not a feature, e.g. the status field of JSON-RPC results
+			continue
+		}
+		feature.Hash = hash
+		return &feature, nil
+	}
+	return nil, fmt.Errorf("feature %s not found in response", name)
+}
+
+// AmendmentBlocked reports whether the server is amendment blocked: an
+// amendment it does not support has been enabled, so it can no longer process
+// ledgers and its data should not be relied on. The amendment_blocked flag of
+// server_info is cross-checked against the enabled amendments reported by
+// the feature command.
+func (c *Client) AmendmentBlocked() (bool, error) {
+	info, err := c.ServerInfo()
+	if err != nil {
+		return false, err
+	}
+	if info.AmendmentBlocked {
+		return true, nil
+	}
+	features, err := c.Features()
+	if err != nil {
+		return false, err
+	}
+	for _, feature := range features {
+		if feature.Enabled && !feature.Supported {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,72 @@
+package xrpl
+
+// FeeResult holds the transaction cost and queue state reported by the fee
+// command for the current open ledger. Fee levels are relative to
+// ReferenceLevel: a transaction paying OpenLedgerLevel or more gets into the
+// open ledger instead of the queue.
+type FeeResult struct {
+	BaseFee            Amount // Transaction cost of a reference transaction, in drops
+	MedianFee          Amount // Median cost of transactions in the last validated ledger
+	MinimumFee         Amount // Minimum cost for a transaction to enter the queue
+	OpenLedgerFee      Amount // Minimum cost to get into the current open ledger
+	CurrentLedgerSize  uint32
+	ExpectedLedgerSize uint32
+	CurrentQueueSize   uint32
+	MaxQueueSize       uint32
+	LedgerCurrentIndex uint32
+	MedianLevel        uint64
+	MinimumLevel       uint64
+	OpenLedgerLevel    uint64
+	ReferenceLevel     uint64
+}
+
+// Retrieve the current transaction cost levels and the state of the
+// transaction queue.
+//
+// Example usage:
+//
+//	fee, err := client.Fee()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(fee.OpenLedgerFee, fee.CurrentQueueSize, fee.MaxQueueSize)
+func (c *Client) Fee() (*FeeResult, error) {
+	var result struct {
+		Drops struct {
+			BaseFee       Amount `json:"base_fee"`
+			MedianFee     Amount `json:"median_fee"`
+			MinimumFee    Amount `json:"minimum_fee"`
+			OpenLedgerFee Amount `json:"open_ledger_fee"`
+		} `json:"drops"`
+		Levels struct {
+			MedianLevel     uint64 `json:"median_level,string"`
+			MinimumLevel    uint64 `json:"minimum_level,string"`
+			OpenLedgerLevel uint64 `json:"open_ledger_level,string"`
+			ReferenceLevel  uint64 `json:"reference_level,string"`
+		} `json:"levels"`
+		CurrentLedgerSize  uint32 `json:"current_ledger_size,string"`
+		ExpectedLedgerSize uint32 `json:"expected_ledger_size,string"`
+		CurrentQueueSize   uint32 `json:"current_queue_size,string"`
+		MaxQueueSize       uint32 `json:"max_queue_size,string"`
+		LedgerCurrentIndex uint32 `json:"ledger_current_index"`
+	}
+	if err := c.requestResult(BaseRequest{"command": "fee"}, &result); err != nil {
+		return nil, err
+	}
+
+	return &FeeResult{
+		BaseFee:            result.Drops.BaseFee,
+		MedianFee:          result.Drops.MedianFee,
+		MinimumFee:         result.Drops.MinimumFee,
+		OpenLedgerFee:      result.Drops.OpenLedgerFee,
+		CurrentLedgerSize:  result.CurrentLedgerSize,
+		ExpectedLedgerSize: result.ExpectedLedgerSize,
+		CurrentQueueSize:   result.CurrentQueueSize,
+		MaxQueueSize:       result.MaxQueueSize,
+		LedgerCurrentIndex: result.LedgerCurrentIndex,
+		MedianLevel:        result.Levels.MedianLevel,
+		MinimumLevel:       result.Levels.MinimumLevel,
+		OpenLedgerLevel:    result.Levels.OpenLedgerLevel,
+		ReferenceLevel:     result.Levels.ReferenceLevel,
+	}, nil
+}
@@ -0,0 +1,283 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TransactionType is the TransactionType field of a transaction
+type TransactionType string
+
+// Transaction types
+// https://xrpl.org/docs/references/protocol/transactions/types
+const (
+	TxTypeAccountDelete        TransactionType = "AccountDelete"
+	TxTypeAccountSet           TransactionType = "AccountSet"
+	TxTypeAMMBid               TransactionType = "AMMBid"
+	TxTypeAMMCreate            TransactionType = "AMMCreate"
+	TxTypeAMMDelete            TransactionType = "AMMDelete"
+	TxTypeAMMDeposit           TransactionType = "AMMDeposit"
+	TxTypeAMMVote              TransactionType = "AMMVote"
+	TxTypeAMMWithdraw          TransactionType = "AMMWithdraw"
+	TxTypeCheckCancel          TransactionType = "CheckCancel"
+	TxTypeCheckCash            TransactionType = "CheckCash"
+	TxTypeCheckCreate          TransactionType = "CheckCreate"
+	TxTypeClawback             TransactionType = "Clawback"
+	TxTypeDepositPreauth       TransactionType = "DepositPreauth"
+	TxTypeEscrowCancel         TransactionType = "EscrowCancel"
+	TxTypeEscrowCreate         TransactionType = "EscrowCreate"
+	TxTypeEscrowFinish         TransactionType = "EscrowFinish"
+	TxTypeNFTokenAcceptOffer   TransactionType = "NFTokenAcceptOffer"
+	TxTypeNFTokenBurn          TransactionType = "NFTokenBurn"
+	TxTypeNFTokenCancelOffer   TransactionType = "NFTokenCancelOffer"
+	TxTypeNFTokenCreateOffer   TransactionType = "NFTokenCreateOffer"
+	TxTypeNFTokenMint          TransactionType = "NFTokenMint"
+	TxTypeOfferCancel          TransactionType = "OfferCancel"
+	TxTypeOfferCreate          TransactionType = "OfferCreate"
+	TxTypePayment              TransactionType = "Payment"
+	TxTypePaymentChannelClaim  TransactionType = "PaymentChannelClaim"
+	TxTypePaymentChannelCreate TransactionType = "PaymentChannelCreate"
+	TxTypePaymentChannelFund   TransactionType = "PaymentChannelFund"
+	TxTypeSetRegularKey        TransactionType = "SetRegularKey"
+	TxTypeSignerListSet        TransactionType = "SignerListSet"
+	TxTypeTicketCreate         TransactionType = "TicketCreate"
+	TxTypeTrustSet             TransactionType = "TrustSet"
+)
+
+// Flags valid for every transaction type
+const (
+	TfFullyCanonicalSig uint32 = 0x80000000
+)
+
+// Payment flags
+const (
+	TfNoRippleDirect uint32 = 0x00010000
+	TfPartialPayment uint32 = 0x00020000
+	TfLimitQuality   uint32 = 0x00040000
+)
+
+// TrustSet flags
+const (
+	TfSetfAuth        uint32 = 0x00010000
+	TfSetNoRipple     uint32 = 0x00020000
+	TfClearNoRipple   uint32 = 0x00040000
+	TfSetFreeze       uint32 = 0x00100000
+	TfClearFreeze     uint32 = 0x00200000
+	TfSetDeepFreeze   uint32 = 0x00400000
+	TfClearDeepFreeze uint32 = 0x00800000
+)
+
+// OfferCreate flags
+const (
+	TfPassive           uint32 = 0x00010000
+	TfImmediateOrCancel uint32 = 0x00020000
+	TfFillOrKill        uint32 = 0x00040000
+	TfSell              uint32 = 0x00080000
+)
+
+// AccountSet flags. Most account settings are changed with the SetFlag and
+// ClearFlag fields instead; these flags are a legacy alternative.
+const (
+	TfRequireDestTag  uint32 = 0x00010000
+	TfOptionalDestTag uint32 = 0x00020000
+	TfRequireAuth     uint32 = 0x00040000
+	TfOptionalAuth    uint32 = 0x00080000
+	TfDisallowXRP     uint32 = 0x00100000
+	TfAllowXRP        uint32 = 0x00200000
+)
+
+// AccountSet SetFlag and ClearFlag values, each enabling or disabling one
+// account setting
+// https://xrpl.org/docs/references/protocol/transactions/types/accountset#accountset-flags
+const (
+	AsfRequireDest                  uint32 = 1
+	AsfRequireAuth                  uint32 = 2
+	AsfDisallowXRP                  uint32 = 3
+	AsfDisableMaster                uint32 = 4
+	AsfAccountTxnID                 uint32 = 5
+	AsfNoFreeze                     uint32 = 6
+	AsfGlobalFreeze                 uint32 = 7
+	AsfDefaultRipple                uint32 = 8
+	AsfDepositAuth                  uint32 = 9
+	AsfAuthorizedNFTokenMinter      uint32 = 10
+	AsfDisallowIncomingNFTokenOffer uint32 = 12
+	AsfDisallowIncomingCheck        uint32 = 13
+	AsfDisallowIncomingPayChan      uint32 = 14
+	AsfDisallowIncomingTrustline    uint32 = 15
+	AsfAllowTrustLineClawback       uint32 = 16
+)
+
+// AccountRoot flags, the settings of an account as found in the Flags of its
+// AccountRoot, e.g. as returned by AccountInfo
+// https://xrpl.org/docs/references/protocol/ledger-data/ledger-entry-types/accountroot#accountroot-flags
+const (
+	LsfPasswordSpent  uint32 = 0x00010000
+	LsfRequireDestTag uint32 = 0x00020000
+	LsfRequireAuth    uint32 = 0x00040000
+	LsfDisallowXRP    uint32 = 0x00080000
+	LsfDisableMaster  uint32 = 0x00100000
+	LsfNoFreeze       uint32 = 0x00200000
+	LsfGlobalFreeze   uint32 = 0x00400000
+	LsfDefaultRipple  uint32 = 0x00800000
+	LsfDepositAuth    uint32 = 0x01000000
+)
+
+// PaymentChannelClaim flags
+const (
+	TfRenew uint32 = 0x00010000
+	TfClose uint32 = 0x00020000
+)
+
+// NFTokenMint flags
+const (
+	TfBurnable     uint32 = 0x00000001
+	TfOnlyXRP      uint32 = 0x00000002
+	TfTrustLine    uint32 = 0x00000004
+	TfTransferable uint32 = 0x00000008
+	TfMutable      uint32 = 0x00000010
+)
+
+// NFTokenCreateOffer flags
+const (
+	TfSellNFToken uint32 = 0x00000001
+)
+
+// AMMDeposit and AMMWithdraw flags
+const (
+	TfLPToken             uint32 = 0x00010000
+	TfWithdrawAll         uint32 = 0x00020000 // AMMWithdraw only
+	TfOneAssetWithdrawAll uint32 = 0x00040000 // AMMWithdraw only
+	TfSingleAsset         uint32 = 0x00080000
+	TfTwoAsset            uint32 = 0x00100000
+	TfOneAssetLPToken     uint32 = 0x00200000
+	TfLimitLPToken        uint32 = 0x00400000
+	TfTwoAssetIfEmpty     uint32 = 0x00800000 // AMMDeposit only
+)
+
+// Flags that are valid for each transaction type, besides the universal
+// TfFullyCanonicalSig. Types not listed here are not validated.
+var transactionFlags = map[TransactionType]uint32{
+	TxTypeAccountDelete:        0,
+	TxTypeAccountSet:           TfRequireDestTag | TfOptionalDestTag | TfRequireAuth | TfOptionalAuth | TfDisallowXRP | TfAllowXRP,
+	TxTypeAMMBid:               0,
+	TxTypeAMMCreate:            0,
+	TxTypeAMMDelete:            0,
+	TxTypeAMMDeposit:           TfLPToken | TfSingleAsset | TfTwoAsset | TfOneAssetLPToken | TfLimitLPToken | TfTwoAssetIfEmpty,
+	TxTypeAMMVote:              0,
+	TxTypeAMMWithdraw:          TfLPToken | TfWithdrawAll | TfOneAssetWithdrawAll | TfSingleAsset | TfTwoAsset | TfOneAssetLPToken | TfLimitLPToken,
+	TxTypeCheckCancel:          0,
+	TxTypeCheckCash:            0,
+	TxTypeCheckCreate:          0,
+	TxTypeClawback:             0,
+	TxTypeDepositPreauth:       0,
+	TxTypeEscrowCancel:         0,
+	TxTypeEscrowCreate:         0,
+	TxTypeEscrowFinish:         0,
+	TxTypeNFTokenAcceptOffer:   0,
+	TxTypeNFTokenBurn:          0,
+	TxTypeNFTokenCancelOffer:   0,
+	TxTypeNFTokenCreateOffer:   TfSellNFToken,
+	TxTypeNFTokenMint:          TfBurnable | TfOnlyXRP | TfTrustLine | TfTransferable | TfMutable,
+	TxTypeOfferCancel:          0,
+	TxTypeOfferCreate:          TfPassive | TfImmediateOrCancel | TfFillOrKill | TfSell,
+	TxTypePayment:              TfNoRippleDirect | TfPartialPayment | TfLimitQuality,
+	TxTypePaymentChannelClaim:  TfRenew | TfClose,
+	TxTypePaymentChannelCreate: 0,
+	TxTypePaymentChannelFund:   0,
+	TxTypeSetRegularKey:        0,
+	TxTypeSignerListSet:        0,
+	TxTypeTicketCreate:         0,
+	TxTypeTrustSet:             TfSetfAuth | TfSetNoRipple | TfClearNoRipple | TfSetFreeze | TfClearFreeze | TfSetDeepFreeze | TfClearDeepFreeze,
+}
+
+// Flags is the Flags field of a transaction of a given type. It encodes in
+// JSON as the plain number, so it can be put into a transaction map as is.
+//
+// Example usage:
+//
+//	tx["Flags"] = xrpl.FlagsFor(xrpl.TxTypePayment).Set(xrpl.TfPartialPayment)
+type Flags struct {
+	txType TransactionType
+	value  uint32
+}
+
+// FlagsFor returns an empty set of flags for a transaction of type txType
+func FlagsFor(txType TransactionType) Flags {
+	return Flags{txType: txType}
+}
+
+// Set returns the flags with the given flags added
+func (f Flags) Set(flags ...uint32) Flags {
+	for _, flag := range flags {
+		f.value |= flag
+	}
+	return f
+}
+
+// Clear returns the flags with the given flags removed
+func (f Flags) Clear(flags ...uint32) Flags {
+	for _, flag := range flags {
+		f.value &^= flag
+	}
+	return f
+}
+
+// Has reports whether all of the given flags are set
+func (f Flags) Has(flags ...uint32) bool {
+	for _, flag := range flags {
+		if f.value&flag != flag {
+			return false
+		}
+	}
+	return true
+}
+
+// Value returns the numeric value of the Flags field
+func (f Flags) Value() uint32 {
+	return f.value
+}
+
+// Validate checks that only flags defined for the transaction type are set.
+// Flags of transaction types unknown to this package are not checked.
+func (f Flags) Validate() error {
+	allowed, ok := transactionFlags[f.txType]
+	if !ok {
+		return nil
+	}
+	if invalid := f.value &^ (allowed | TfFullyCanonicalSig); invalid != 0 {
+		return fmt.Errorf("flags %#x are not valid for %s transactions", invalid, f.txType)
+	}
+	return nil
+}
+
+func (f Flags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.value)
+}
+
+// validateTxFlags checks the Flags field of a transaction against its
+// TransactionType, see Flags.Validate.
+func validateTxFlags(tx map[string]interface{}) error {
+	value, ok := tx["Flags"]
+	if !ok {
+		return nil
+	}
+	txType, _ := tx["TransactionType"].(string)
+	if f, ok := value.(Flags); ok {
+		if f.txType != "" && string(f.txType) != txType {
+			return fmt.Errorf("flags for %s transactions used in a %s transaction", f.txType, txType)
+		}
+		return FlagsFor(TransactionType(txType)).Set(f.value).Validate()
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("invalid Flags: %w", err)
+	}
+	n, err := strconv.ParseUint(strings.Trim(string(data), `"`), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid Flags %s: must be an unsigned 32 bit integer", data)
+	}
+	return FlagsFor(TransactionType(txType)).Set(uint32(n)).Validate()
+}
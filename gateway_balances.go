@@ -0,0 +1,136 @@
+package xrpl
+
+import "fmt"
+
+// GatewayBalancesResult holds the balances of an issuing account as reported
+// by the gateway_balances command. Every Amount is a token amount with its
+// issuer filled in, even where the server omits it.
+type GatewayBalancesResult struct {
+	Account     string
+	LedgerIndex uint32
+	// Total amounts issued to accounts other than the hot wallets, by
+	// currency. They are issued by Account.
+	Obligations map[string]Amount
+	// Amounts held by each of the requested hot wallets, by hot wallet
+	// address. They are issued by Account.
+	Balances map[string][]Amount
+	// Amounts frozen on trust lines of Account, by holder address. They are
+	// issued by Account.
+	FrozenBalances map[string][]Amount
+	// Amounts issued by others and held by Account, by issuer address
+	Assets map[string][]Amount
+}
+
+// GatewayBalancesOption configures a gateway_balances request
+type GatewayBalancesOption func(req BaseRequest)
+
+// GatewayBalancesHotWallet excludes the balances of the given operational
+// addresses from the obligations and reports them as Balances instead.
+func GatewayBalancesHotWallet(hotWallets ...string) GatewayBalancesOption {
+	return func(req BaseRequest) {
+		if len(hotWallets) == 1 {
+			req["hotwallet"] = hotWallets[0]
+		} else {
+			req["hotwallet"] = hotWallets
+		}
+	}
+}
+
+// GatewayBalancesLedgerIndex selects the ledger to read balances from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func GatewayBalancesLedgerIndex(ledger LedgerSpecifier) GatewayBalancesOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// gatewayBalance is an entry of the balances, frozen_balances and assets
+// lists of a gateway_balances response, which do not name the issuer.
+type gatewayBalance struct {
+	Currency string `json:"currency"`
+	Value    string `json:"value"`
+}
+
+// Retrieve the total obligations of an issuing account, along with the
+// balances of its hot wallets and the assets it holds.
+//
+// Example usage:
+//
+//	balances, err := client.GatewayBalances("rMwjYedjc7qqtKYVLiAccJSmCwih4LnE2q",
+//		xrpl.GatewayBalancesHotWallet("rKm4uWpg9tfwbVSeATv4KxDe6mpE9yPkgJ"))
+//	if err != nil {
+//		return err
+//	}
+//	for currency, amount := range balances.Obligations {
+//		fmt.Println(currency, amount.Value())
+//	}
+func (c *Client) GatewayBalances(account string, opts ...GatewayBalancesOption) (*GatewayBalancesResult, error) {
+	req := BaseRequest{
+		"command":      "gateway_balances",
+		"account":      account,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		Account        string                      `json:"account"`
+		LedgerIndex    uint32                      `json:"ledger_index"`
+		Obligations    map[string]string           `json:"obligations"`
+		Balances       map[string][]gatewayBalance `json:"balances"`
+		FrozenBalances map[string][]gatewayBalance `json:"frozen_balances"`
+		Assets         map[string][]gatewayBalance `json:"assets"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+
+	issuer := result.Account
+	if issuer == "" {
+		issuer = account
+	}
+	res := &GatewayBalancesResult{
+		Account:     issuer,
+		LedgerIndex: result.LedgerIndex,
+		Obligations: make(map[string]Amount, len(result.Obligations)),
+	}
+	for currency, value := range result.Obligations {
+		amount, err := ledgerTokenAmount(currency, issuer, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid obligation: %w", err)
+		}
+		res.Obligations[currency] = amount
+	}
+	var err error
+	if res.Balances, err = gatewayAmounts(result.Balances, issuer); err != nil {
+		return nil, fmt.Errorf("invalid balance: %w", err)
+	}
+	if res.FrozenBalances, err = gatewayAmounts(result.FrozenBalances, issuer); err != nil {
+		return nil, fmt.Errorf("invalid frozen balance: %w", err)
+	}
+	if res.Assets, err = gatewayAmounts(result.Assets, ""); err != nil {
+		return nil, fmt.Errorf("invalid asset: %w", err)
+	}
+	return res, nil
+}
+
+// gatewayAmounts converts balances listed by address into amounts issued by
+// issuer, or by the address itself when issuer is empty.
+func gatewayAmounts(balances map[string][]gatewayBalance, issuer string) (map[string][]Amount, error) {
+	amounts := make(map[string][]Amount, len(balances))
+	for address, list := range balances {
+		iss := issuer
+		if iss == "" {
+			iss = address
+		}
+		for _, b := range list {
+			amount, err := ledgerTokenAmount(b.Currency, iss, b.Value)
+			if err != nil {
+				return nil, err
+			}
+			amounts[address] = append(amounts[address], amount)
+		}
+	}
+	return amounts, nil
+}
@@ -3,6 +3,8 @@ module github.com/andreimerlescu/xrpl-go
 go 1.20
 
 require (
+	github.com/decred/dcrd/crypto/ripemd160 v1.0.2
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/gorilla/websocket v1.5.1
 	github.com/xrpscan/xrpl-go v0.2.7
 )
@@ -2,8 +2,7 @@ package xrpl
 
 import (
 	"encoding/json"
-	"fmt"
-	"log"
+	"errors"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -11,26 +10,42 @@ import (
 
 func (c *Client) handlePong(message string) error {
 	// log.Println("PONG:", message)
-	c.connection.SetReadDeadline(time.Now().Add(c.config.ReadTimeout * time.Second))
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.connection == nil {
+		return nil
+	}
+	c.connection.SetReadDeadline(c.keepAlive.readDeadline())
 	c.connection.SetWriteDeadline(time.Now().Add(c.config.WriteTimeout * time.Second))
 	return nil
 }
 
-func (c *Client) handleResponse() error {
+// handleResponse is the read loop of a websocket connection. When reading
+// fails on a connection that was neither closed nor replaced, including when
+// no message arrived before the keepalive read deadline, the loop exits and
+// hands over to the reconnect logic.
+func (c *Client) handleResponse(conn *websocket.Conn) error {
 	for {
-		if c.closed {
-			break
-		}
-		messageType, message, err := c.connection.ReadMessage()
+		messageType, message, err := conn.ReadMessage()
 		if err != nil {
-			log.Println("WS read error:", err)
-			c.Reconnect()
+			c.mutex.Lock()
+			dropped := !c.closed && c.connection == conn
+			c.mutex.Unlock()
+			if dropped {
+				if errors.Is(err, websocket.ErrReadLimit) {
+					c.logger.Warnf("WS message larger than %d bytes, dropping the connection", c.dial.maxMessageSize)
+				} else {
+					c.logger.Warnf("WS read error: %s", err)
+				}
+				c.reconnect()
+			}
 			break
 		}
+		conn.SetReadDeadline(c.keepAlive.readDeadline())
 
 		switch messageType {
 		case websocket.CloseMessage:
-			log.Println("WS websocket.CloseMessage received")
+			c.logger.Warnf("WS websocket.CloseMessage received")
 			return nil
 		case websocket.TextMessage:
 			c.resolveStream(message)
@@ -44,7 +59,11 @@ func (c *Client) handleResponse() error {
 func (c *Client) resolveStream(message []byte) {
 	var m BaseResponse
 	if err := json.Unmarshal(message, &m); err != nil {
-		log.Println("json.Unmarshal error: ", err)
+		c.logger.Errorf("json.Unmarshal error: %s", err)
+	}
+
+	if m["type"] != StreamResponseType(StreamTypeResponse) && c.dispatchStream(m) && !c.channelSubscribed(m) {
+		return
 	}
 
 	switch m["type"] {
@@ -73,17 +92,89 @@ func (c *Client) resolveStream(message []byte) {
 		c.StreamServer <- message
 
 	case StreamResponseType(StreamTypeResponse):
-		requestId := fmt.Sprintf("%v", m["id"])
+		// The entry is removed as the response is delivered, under the same
+		// lock, so a repeated response finds no channel rather than a closed
+		// one. The channel is buffered and receives a single value, so the
+		// send never blocks.
+		requestId := responseID(m["id"])
 		c.mutex.Lock()
 		ch, ok := c.requestQueue[requestId]
 		if ok {
-			ch <- m
 			delete(c.requestQueue, requestId)
+			ch <- m
 			close(ch)
 		}
 		c.mutex.Unlock()
+		if !ok {
+			c.orphanedResponses.Add(1)
+			c.metrics.MessageDropped(StreamTypeResponse)
+			c.logger.Warnf("WS response %s matches no pending request, dropped", requestId)
+		}
 
 	default:
 		c.StreamDefault <- message
 	}
 }
+
+// dispatchStream queues the stream message m for the handlers registered with
+// SubscribeWithHandler and RegisterStreamType, and for transactions those
+// registered with SubscribeAccounts, SubscribeAccountsProposed and
+// SubscribeBooks, and for path_find updates the handler of PathFindCreate.
+// Each handler is queued m once. It reports whether any handler was found.
+func (c *Client) dispatchStream(m BaseResponse) bool {
+	messageType, _ := m["type"].(string)
+
+	seen := make(map[*subscriptionHandler]bool)
+	var handlers []*subscriptionHandler
+	add := func(hs ...*subscriptionHandler) {
+		for _, h := range hs {
+			if !seen[h] {
+				seen[h] = true
+				handlers = append(handlers, h)
+			}
+		}
+	}
+	c.mutex.Lock()
+	for stream, streamHandlers := range c.streamHandlers {
+		if streamCarries(stream, m) {
+			add(streamHandlers...)
+		}
+	}
+	add(c.typeHandlers[messageType]...)
+	if messageType == StreamResponseType(StreamTypeTransaction) {
+		add(c.transactionHandlers(m)...)
+	}
+	if messageType == StreamResponseType(StreamTypePathFind) && c.pathFind != nil {
+		add(c.pathFind.stream)
+	}
+	c.mutex.Unlock()
+
+	for _, h := range handlers {
+		c.enqueue(h, m)
+	}
+	return len(handlers) > 0
+}
+
+// channelSubscribed reports whether m belongs to a stream subscribed with
+// Subscribe, whose messages go to the Stream channels even when handlers
+// take them too
+func (c *Client) channelSubscribed(m BaseResponse) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for stream := range c.channelSubscriptions {
+		if streamCarries(stream, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// streamCarries reports whether m is a message of stream. Both transaction
+// streams send "transaction" messages, but only transactions_proposed sends
+// those of transactions not validated yet.
+func streamCarries(stream string, m BaseResponse) bool {
+	if m["type"] != StreamResponseType(stream) || StreamResponseType(stream) == "" {
+		return false
+	}
+	return stream != StreamTypeTransaction || m["validated"] != false
+}
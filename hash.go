@@ -0,0 +1,28 @@
+package xrpl
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// SHA512Half returns the first 32 bytes of the SHA-512 hash of data, the hash
+// function used throughout the XRP Ledger for object IDs and signing.
+func SHA512Half(data []byte) [32]byte {
+	hash := sha512.Sum512(data)
+	var half [32]byte
+	copy(half[:], hash[:32])
+	return half
+}
+
+// HashSignedTx computes the transaction ID (hash) of a signed transaction
+// blob, as returned by EncodeTransaction, in upper case hex.
+func HashSignedTx(blob []byte) (string, error) {
+	if len(blob) == 0 {
+		return "", fmt.Errorf("cannot hash an empty transaction blob")
+	}
+	data := append(uint32Bytes(HashPrefixTransactionID), blob...)
+	hash := SHA512Half(data)
+	return strings.ToUpper(hex.EncodeToString(hash[:])), nil
+}
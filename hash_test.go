@@ -0,0 +1,47 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSHA512Half(t *testing.T) {
+	hash := SHA512Half(nil)
+	want := "CF83E1357EEFB8BDF1542850D66D8007D620E4050B5715DC83F4A921D36CE9CE"
+	if got := strings.ToUpper(hex.EncodeToString(hash[:])); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestHashSignedTx(t *testing.T) {
+	tests := []struct {
+		blob string
+		hash string
+	}{
+		{
+			blob: "120000220000000024001B733261400000000000000F68400000000000000C7321EDE5638D8055CCD45EBF7F5FFD59FC1703D6BC00800BBA19F158119DAA1A52A8D57440A973391D589C1D81E55516420A8D095DD98D2FC1F85E53C427EEEC22C6D3DEBADFA184005F5539E6A672CC4FA468125981584DDCE9365A6C7076F2E9CAF86B0E81143A18A088CF12B2D3E51F47A75D2A9859EF61ECA78314858233827B488ECB8D0EB940E7AC85CE41E343CF",
+			hash: "37186C50D0A3FAB1218B5F7DAA235E4592F5080AF1C81F9B2678D4751C103CDF",
+		},
+		{
+			blob: "120000220000000024001B733261400000000000000F68400000000000000C7321ED839AE597DD34FDD0A806CE7690A7F0A753CAEEAC7A0B1DE1EF6EC647DD3CBC6D7440BAD74EEF422A1F8DDB28E005ECC3A19E5678EA36349722E1B8F7B76528A81D22E1CB0F0C15593613A3970B1D95CBB901C7C0F701381AD70C50FA8411778254078114D64E8ABC22DA5D143D60AEA083E17D3508DEE19C8314858233827B488ECB8D0EB940E7AC85CE41E343CF",
+			hash: "94DB52A478D965C73A17427593004FD14FF74853DD667D8F3E8BF2A1FE66A11F",
+		},
+	}
+	for _, test := range tests {
+		blob, err := hex.DecodeString(test.blob)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hash, err := HashSignedTx(blob)
+		if err != nil {
+			t.Errorf("HashSignedTx: %v", err)
+		} else if hash != test.hash {
+			t.Errorf("got %s, want %s", hash, test.hash)
+		}
+	}
+
+	if _, err := HashSignedTx(nil); err == nil {
+		t.Error("empty blob: no error")
+	}
+}
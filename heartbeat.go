@@ -1,23 +1,70 @@
 package xrpl
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/gorilla/websocket"
 )
 
-// Heartbeat runner to send Pings periodically. If a Pong is received, it is
-// handled by handlePong handler which further extends websocket connection's
-// read and write deadline into the future.
-func (c *Client) heartbeat() {
-	// log.Println("INF: Heartbeat started")
-	ticker := time.NewTicker(c.config.HeartbeatInterval * time.Second)
+// KeepAliveConfig controls the detection of dead websocket connections. A
+// ping frame is sent every Interval, and a connection on which neither the
+// pong nor any other message arrived within Timeout of a ping is considered
+// dead: it is closed and the client reconnects, as configured by
+// ClientConfig.Reconnect.
+type KeepAliveConfig struct {
+	Interval time.Duration // Default is ClientConfig.HeartbeatInterval, 5 seconds unless set
+	Timeout  time.Duration // Default is 10 seconds
+}
+
+// WithKeepAlive configures the websocket keepalive
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config, xrpl.WithKeepAlive(xrpl.KeepAliveConfig{
+//		Interval: 30 * time.Second,
+//		Timeout:  10 * time.Second,
+//	}))
+func WithKeepAlive(keepAlive KeepAliveConfig) ClientOption {
+	return func(c *Client) {
+		c.keepAlive = keepAlive
+	}
+}
+
+func (k KeepAliveConfig) validate() error {
+	if k.Interval <= 0 || k.Timeout <= 0 {
+		return fmt.Errorf("keepalive interval and timeout out of bounds: %s, %s", k.Interval, k.Timeout)
+	}
+	return nil
+}
+
+// readDeadline is the time by which the next message must arrive on a live
+// connection: a ping is sent at most Interval from now, and its pong is due
+// within Timeout. It is a deadline of the network connection, so it is taken
+// from the wall clock rather than the client's clock.
+func (k KeepAliveConfig) readDeadline() time.Time {
+	return time.Now().Add(k.Interval + k.Timeout)
+}
+
+// Heartbeat runner to send Pings periodically, every keepalive interval on
+// the client's clock. Any message received, a Pong included, extends the
+// connection's read deadline by the keepalive interval and timeout; when the
+// deadline passes, the read loop fails and reconnects. A ping that cannot be
+// written closes the connection to the same effect.
+func (c *Client) heartbeat(conn *websocket.Conn, done <-chan bool) {
+	ticks, stop := c.clock.newTicker(c.keepAlive.Interval)
+	defer stop()
 	for {
 		select {
-		case <-c.heartbeatDone:
-			ticker.Stop()
-			// log.Println("ERR: Heartbeat stopped")
+		case <-done:
 			return
-		case t := <-ticker.C:
-			c.Ping([]byte(t.String()))
+		case t := <-ticks:
+			err := c.Ping([]byte(t.String()))
+			if err != nil && err != ErrDisconnected {
+				c.logger.Warnf("WS ping error: %s %s", c.config.URL, err)
+				conn.Close()
+				return
+			}
 		}
 	}
 }
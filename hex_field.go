@@ -0,0 +1,28 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ToHexField encodes text as the uppercase hex of its UTF-8 bytes, as the
+// network expects for fields such as Domain, URI and those of memos, e.g.
+// "bücher.example" as "62C3BC636865722E6578616D706C65"
+func ToHexField(s string) string {
+	return strings.ToUpper(hex.EncodeToString([]byte(s)))
+}
+
+// FromHexField decodes a field encoded with ToHexField back to text. Hex of
+// either case is accepted; bytes that are not valid UTF-8 fail with an error.
+func FromHexField(h string) (string, error) {
+	data, err := hex.DecodeString(h)
+	if err != nil {
+		return "", fmt.Errorf("invalid hex field %q: %w", h, err)
+	}
+	if !utf8.Valid(data) {
+		return "", fmt.Errorf("invalid hex field %q: not UTF-8 text", h)
+	}
+	return string(data), nil
+}
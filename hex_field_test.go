@@ -0,0 +1,75 @@
+package xrpl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHexField(t *testing.T) {
+	tests := []struct {
+		text string
+		hex  string
+	}{
+		{"", ""},
+		{"example.com", "6578616D706C652E636F6D"},
+		{"bücher.example", "62C3BC636865722E6578616D706C65"},
+		// The ASCII form of the same IDN is kept as is
+		{"xn--bcher-kva.example", "786E2D2D62636865722D6B76612E6578616D706C65"},
+		{"例え.jp", "E4BE8BE381882E6A70"},
+		{"ipfs://🚀", "697066733A2F2FF09F9A80"},
+	}
+	for _, test := range tests {
+		if got := ToHexField(test.text); got != test.hex {
+			t.Errorf("ToHexField(%q) = %s, want %s", test.text, got, test.hex)
+		}
+		for _, h := range []string{test.hex, strings.ToLower(test.hex)} {
+			if got, err := FromHexField(h); err != nil || got != test.text {
+				t.Errorf("FromHexField(%s) = %q, %v, want %q", h, got, err, test.text)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"ZZ", "ABC", "C3", "FF00"} {
+		if got, err := FromHexField(invalid); err == nil {
+			t.Errorf("FromHexField(%s) = %q, want an error", invalid, got)
+		}
+	}
+}
+
+func TestHexFieldLengthLimits(t *testing.T) {
+	// Limits are in bytes of UTF-8: "ü" takes 2
+	for _, test := range []struct {
+		domain string
+		valid  bool
+	}{
+		{strings.Repeat("a", 256), true},
+		{strings.Repeat("a", 257), false},
+		{strings.Repeat("ü", 128), true},
+		{strings.Repeat("ü", 129), false},
+	} {
+		tx, err := BuildAccountSet("rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1", AccountSetDomain(test.domain))
+		if test.valid && (err != nil || tx["Domain"] != ToHexField(test.domain)) {
+			t.Errorf("Domain of %d bytes: got %v, %v", len(test.domain), tx["Domain"], err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("Domain of %d bytes: no error", len(test.domain))
+		}
+	}
+
+	for _, test := range []struct {
+		uri   string
+		valid bool
+	}{
+		{"", false},
+		{"ipfs://" + strings.Repeat("é", 124), true},
+		{"ipfs://" + strings.Repeat("é", 125), false},
+	} {
+		tx, err := BuildNFTokenMint("rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1", 0, NFTokenMintURI(test.uri))
+		if test.valid && (err != nil || tx["URI"] != ToHexField(test.uri)) {
+			t.Errorf("URI of %d bytes: got %v, %v", len(test.uri), tx["URI"], err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("URI of %d bytes: no error", len(test.uri))
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package xrpl
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+// KeyType identifies the signing algorithm of an XRPL keypair
+type KeyType int
+
+const (
+	KeyTypeSecp256k1 KeyType = iota
+	KeyTypeEd25519
+)
+
+func (k KeyType) String() string {
+	switch k {
+	case KeyTypeSecp256k1:
+		return "secp256k1"
+	case KeyTypeEd25519:
+		return "ed25519"
+	default:
+		return fmt.Sprintf("KeyType(%d)", int(k))
+	}
+}
+
+// Family seeds encoding ed25519 keys carry a three byte version prefix, which
+// makes their base58 representation start with 'sEd'. Any other seed is a
+// secp256k1 seed using the single byte familySeedPrefix.
+var ed25519SeedPrefix = []byte{0x01, 0xE1, 0x4B}
+
+// Length of the entropy encoded in a family seed
+const familySeedLength = 16
+
+// Prefix byte distinguishing 33 byte ed25519 public keys from compressed
+// secp256k1 public keys
+const ed25519PublicKeyPrefix = 0xED
+
+// DecodeSeed decodes an XRPL family seed and derives its private key. The key
+// type is detected from the seed's version prefix. For ed25519 seeds keyBytes
+// is an ed25519.PrivateKey; for secp256k1 seeds it is the 32 byte private
+// scalar of the account's master keypair.
+func DecodeSeed(seed string) (keyBytes []byte, keyType KeyType, err error) {
+	entropy, keyType, err := decodeSeedEntropy(seed)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch keyType {
+	case KeyTypeEd25519:
+		return deriveEd25519PrivateKey(entropy), keyType, nil
+	default:
+		privateKey, err := deriveSecp256k1PrivateKey(entropy)
+		if err != nil {
+			return nil, 0, err
+		}
+		return privateKey.Serialize(), keyType, nil
+	}
+}
+
+// decodeSeedEntropy returns the 16 bytes of entropy encoded in a family seed
+// together with the key type denoted by the seed's version prefix.
+func decodeSeedEntropy(seed string) ([]byte, KeyType, error) {
+	b58 := NewBase58()
+	decoded, err := b58.decodeChecked(seed)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode seed: %w", err)
+	}
+
+	switch {
+	case len(decoded) == len(ed25519SeedPrefix)+familySeedLength &&
+		bytes.HasPrefix(decoded, ed25519SeedPrefix):
+		return decoded[len(ed25519SeedPrefix):], KeyTypeEd25519, nil
+	case len(decoded) == len(familySeedPrefix)+familySeedLength &&
+		bytes.HasPrefix(decoded, familySeedPrefix):
+		return decoded[len(familySeedPrefix):], KeyTypeSecp256k1, nil
+	default:
+		return nil, 0, fmt.Errorf("invalid family seed version byte")
+	}
+}
+
+// deriveEd25519PrivateKey derives an ed25519 private key from seed entropy.
+// The ed25519 seed is the first half of the SHA-512 hash of the entropy.
+func deriveEd25519PrivateKey(entropy []byte) ed25519.PrivateKey {
+	hash := sha512.Sum512(entropy)
+	return ed25519.NewKeyFromSeed(hash[:32])
+}
+
+// deriveSecp256k1PrivateKey derives the master private key of an account
+// using the XRPL family key scheme: a root generator is derived from the seed
+// entropy, and the account key is the root key plus an intermediate scalar
+// derived from the root public key and account index 0.
+func deriveSecp256k1PrivateKey(entropy []byte) (*secp256k1.PrivateKey, error) {
+	root, err := deriveSecp256k1Scalar(entropy, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rootPublic := secp256k1.NewPrivateKey(root).PubKey().SerializeCompressed()
+	accountIndex := uint32(0)
+	intermediate, err := deriveSecp256k1Scalar(rootPublic, &accountIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	intermediate.Add(root)
+	return secp256k1.NewPrivateKey(intermediate), nil
+}
+
+// deriveSecp256k1Scalar hashes the input bytes, an optional discriminator and
+// an incrementing sequence number until the first half of the SHA-512 hash is
+// a valid secp256k1 private scalar.
+func deriveSecp256k1Scalar(input []byte, discriminator *uint32) (*secp256k1.ModNScalar, error) {
+	buf := make([]byte, 4)
+	for seq := uint32(0); seq < 0xFFFFFFFF; seq++ {
+		hasher := sha512.New()
+		hasher.Write(input)
+		if discriminator != nil {
+			binary.BigEndian.PutUint32(buf, *discriminator)
+			hasher.Write(buf)
+		}
+		binary.BigEndian.PutUint32(buf, seq)
+		hasher.Write(buf)
+		hash := hasher.Sum(nil)
+
+		var scalar secp256k1.ModNScalar
+		overflow := scalar.SetByteSlice(hash[:32])
+		if !overflow && !scalar.IsZero() {
+			return &scalar, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to derive secp256k1 private key")
+}
+
+// publicKeyFor returns the public key bytes for a private key of keyType, as
+// encoded in a transaction's SigningPubKey field: 33 bytes, an ed25519 key
+// prefixed with 0xED or a compressed secp256k1 point.
+func publicKeyFor(privateKey []byte, keyType KeyType) []byte {
+	switch keyType {
+	case KeyTypeEd25519:
+		publicKey := ed25519.PrivateKey(privateKey).Public().(ed25519.PublicKey)
+		return append([]byte{ed25519PublicKeyPrefix}, publicKey...)
+	default:
+		return secp256k1.PrivKeyFromBytes(privateKey).PubKey().SerializeCompressed()
+	}
+}
+
+// signWithKey signs message with a private key of keyType as returned by
+// DecodeSeed and returns the raw signature bytes.
+func signWithKey(privateKey []byte, keyType KeyType, message []byte) []byte {
+	switch keyType {
+	case KeyTypeEd25519:
+		return ed25519.Sign(ed25519.PrivateKey(privateKey), message)
+	default:
+		return signSecp256k1(privateKey, message)
+	}
+}
+
+// signSecp256k1 produces a DER encoded, canonical (low S) ECDSA signature of
+// the SHA-512Half hash of message. Nonces are generated per RFC 6979.
+func signSecp256k1(privateKey []byte, message []byte) []byte {
+	hash := SHA512Half(message)
+	signature := ecdsa.Sign(secp256k1.PrivKeyFromBytes(privateKey), hash[:])
+	return signature.Serialize()
+}
+
+// Verify checks a signature of message made by the key publicKeyHex, given as
+// in a SigningPubKey field. Keys prefixed with 0xED are ed25519 keys that
+// sign the message itself; compressed secp256k1 keys, prefixed with 0x02 or
+// 0x03, sign the SHA-512Half of the message with a DER encoded signature.
+// Malformed hex, keys or signatures are reported as errors; a well-formed
+// signature that does not match reports false.
+func Verify(message []byte, signatureHex string, publicKeyHex string) (bool, error) {
+	publicKey, err := hex.DecodeString(publicKeyHex)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key hex: %w", err)
+	}
+	signature, err := hex.DecodeString(signatureHex)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature hex: %w", err)
+	}
+	if len(publicKey) == 0 {
+		return false, fmt.Errorf("empty public key")
+	}
+
+	switch publicKey[0] {
+	case ed25519PublicKeyPrefix:
+		if len(publicKey) != ed25519.PublicKeySize+1 {
+			return false, fmt.Errorf("invalid ed25519 public key length %d", len(publicKey))
+		}
+		if len(signature) != ed25519.SignatureSize {
+			return false, fmt.Errorf("invalid ed25519 signature length %d", len(signature))
+		}
+		return ed25519.Verify(ed25519.PublicKey(publicKey[1:]), message, signature), nil
+	case 0x02, 0x03:
+		key, err := secp256k1.ParsePubKey(publicKey)
+		if err != nil {
+			return false, fmt.Errorf("invalid secp256k1 public key: %w", err)
+		}
+		sig, err := ecdsa.ParseDERSignature(signature)
+		if err != nil {
+			return false, fmt.Errorf("invalid secp256k1 signature: %w", err)
+		}
+		hash := SHA512Half(message)
+		return sig.Verify(hash[:], key), nil
+	default:
+		return false, fmt.Errorf("unsupported public key type 0x%02X", publicKey[0])
+	}
+}
@@ -0,0 +1,169 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestWalletFromSeed(t *testing.T) {
+	tests := []struct {
+		seed      string
+		keyType   KeyType
+		publicKey string
+		address   string
+	}{
+		{
+			seed:    "sp5fghtJtpUorTwvof1NpDXAzNwf5",
+			keyType: KeyTypeSecp256k1,
+			address: "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+		},
+		{
+			seed:    "sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r",
+			keyType: KeyTypeEd25519,
+			address: "rLUEXYuLiQptky37CqLcm9USQpPiz5rkpD",
+		},
+		{
+			seed:      "sh3pdwcaoo7vt5rtrEZJ7a75LnDo3",
+			keyType:   KeyTypeSecp256k1,
+			publicKey: "03A947D71477652C445B20F5226FAA4DF6CD716786E17D016E9A37FBA5379AF02B",
+		},
+		{
+			seed:      "sEdTjrdnJaPE2NNjmavQqXQdrf71NiH",
+			keyType:   KeyTypeEd25519,
+			publicKey: "ED4924A9045FE5ED8B22BAA7B6229A72A287CCF3EA287AADD3A032A24C0F008FA6",
+		},
+	}
+	for _, test := range tests {
+		wallet, err := WalletFromSeed(test.seed)
+		if err != nil {
+			t.Errorf("%s: %v", test.seed, err)
+			continue
+		}
+		if wallet.KeyType != test.keyType {
+			t.Errorf("%s: key type %s, want %s", test.seed, wallet.KeyType, test.keyType)
+		}
+		if test.publicKey != "" && wallet.PublicKey != test.publicKey {
+			t.Errorf("%s: public key %s, want %s", test.seed, wallet.PublicKey, test.publicKey)
+		}
+		if test.address != "" && wallet.ClassicAddress != test.address {
+			t.Errorf("%s: address %s, want %s", test.seed, wallet.ClassicAddress, test.address)
+		}
+	}
+}
+
+func TestDecodeSeedInvalid(t *testing.T) {
+	for _, seed := range []string{
+		"",
+		"invalid",
+		"sp5fghtJtpUorTwvof1NpDXAzNwf6", // checksum mismatch
+		"rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+	} {
+		if _, _, err := DecodeSeed(seed); err == nil {
+			t.Errorf("DecodeSeed(%q) succeeded", seed)
+		}
+	}
+}
+
+func TestSignEd25519(t *testing.T) {
+	privateKey, keyType, err := DecodeSeed("sEdTjrdnJaPE2NNjmavQqXQdrf71NiH")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// ed25519 signatures are deterministic
+	signature := strings.ToUpper(hex.EncodeToString(signWithKey(privateKey, keyType, []byte("hello world"))))
+	want := "E83CAFEAF100793F0C6570D60C7447FF3A87E0DC0CAE9AD90EF0102860EC3BD1D20F432494021F3E19DAFF257A420CA64A49C283AB5AD00B6B0CEA1756151C01"
+	if signature != want {
+		t.Errorf("got signature %s, want %s", signature, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	const (
+		publicKey = "ED4924A9045FE5ED8B22BAA7B6229A72A287CCF3EA287AADD3A032A24C0F008FA6"
+		signature = "C001CB8A9883497518917DD16391930F4FEE39CEA76C846CFF4330BA44ED19DC4730056C2C6D7452873DE8120A5023C6807135C6329A89A13BA1D476FE8E7100"
+	)
+	if ok, err := Verify([]byte("test message"), signature, publicKey); err != nil || !ok {
+		t.Errorf("valid signature: got %v, %v", ok, err)
+	}
+	if ok, err := Verify([]byte("other message"), signature, publicKey); err != nil || ok {
+		t.Errorf("signature of another message: got %v, %v", ok, err)
+	}
+	if _, err := Verify([]byte("test message"), signature, "invalid"); err == nil {
+		t.Error("invalid public key: no error")
+	}
+}
+
+func TestSignVerifySecp256k1(t *testing.T) {
+	wallet, err := WalletFromSeed("sp5fghtJtpUorTwvof1NpDXAzNwf5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := []byte("test message")
+	signature := hex.EncodeToString(signWithKey(wallet.PrivateKey, wallet.KeyType, message))
+	if ok, err := Verify(message, signature, wallet.PublicKey); err != nil || !ok {
+		t.Errorf("own signature: got %v, %v", ok, err)
+	}
+	if ok, err := Verify([]byte("other message"), signature, wallet.PublicKey); err != nil || ok {
+		t.Errorf("signature of another message: got %v, %v", ok, err)
+	}
+}
+
+func TestSigningPubKey(t *testing.T) {
+	tests := []struct {
+		seed     string
+		prefixes []byte
+	}{
+		{"sp5fghtJtpUorTwvof1NpDXAzNwf5", []byte{0x02, 0x03}},
+		{"sEdSKaCy2JT7JaM7v95H9SxkhP9wS2r", []byte{0xED}},
+	}
+	checkKey := func(seed, name, publicKeyHex string, prefixes []byte) {
+		publicKey, err := hex.DecodeString(publicKeyHex)
+		switch {
+		case err != nil:
+			t.Errorf("%s: %s %q: %v", seed, name, publicKeyHex, err)
+		case len(publicKey) != 33:
+			t.Errorf("%s: %s is %d bytes, want 33", seed, name, len(publicKey))
+		case !bytes.Contains(prefixes, publicKey[:1]):
+			t.Errorf("%s: %s prefix %02X, want one of %X", seed, name, publicKey[0], prefixes)
+		}
+	}
+
+	for _, test := range tests {
+		tx := map[string]interface{}{
+			"TransactionType": "Payment",
+			"Account":         "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+			"Destination":     "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+			"Amount":          "1000000",
+			"Fee":             "12",
+			"Sequence":        1,
+		}
+		blob, err := signTransaction(tx, test.seed)
+		if err != nil {
+			t.Fatalf("%s: %v", test.seed, err)
+		}
+		signed, err := DecodeTxBlob(hex.EncodeToString(blob))
+		if err != nil {
+			t.Fatal(err)
+		}
+		publicKey, _ := signed["SigningPubKey"].(string)
+		checkKey(test.seed, "SigningPubKey", publicKey, test.prefixes)
+
+		message, err := EncodeForSigning(signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature, _ := signed["TxnSignature"].(string)
+		if ok, err := Verify(message, signature, publicKey); err != nil || !ok {
+			t.Errorf("%s: TxnSignature does not verify with SigningPubKey: %v, %v", test.seed, ok, err)
+		}
+
+		entry, err := SignFor(tx, test.seed)
+		if err != nil {
+			t.Fatalf("%s: SignFor: %v", test.seed, err)
+		}
+		signerKey, _ := entry["SigningPubKey"].(string)
+		checkKey(test.seed, "signer SigningPubKey", signerKey, test.prefixes)
+	}
+}
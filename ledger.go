@@ -0,0 +1,250 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// LedgerResult is a ledger header as returned by the ledger command, with
+// the ledger's transactions when requested. Without LedgerExpand only the
+// transaction hashes are listed, in TransactionHashes; with it the
+// transactions and their metadata are listed in Transactions.
+type LedgerResult struct {
+	LedgerHash          string
+	LedgerIndex         uint32
+	ParentHash          string
+	CloseTime           uint32 // Ripple time, see RippleTimeToTime
+	ParentCloseTime     uint32 // Ripple time
+	CloseTimeResolution uint32 // Seconds
+	CloseFlags          uint32
+	TotalCoins          string // Drops of XRP in existence
+	AccountHash         string
+	TransactionHash     string
+	Closed              bool
+	Validated           bool
+	TransactionHashes   []string
+	Transactions        []TxWithMeta
+	AccountState        []LedgerObject // With LedgerAccounts and LedgerExpand
+	AccountStateHashes  []string       // With LedgerAccounts only
+}
+
+// LedgerOption configures a ledger request
+type LedgerOption func(req BaseRequest)
+
+// LedgerLedgerIndex selects the ledger, see LedgerSpecifier. The default is
+// the latest validated ledger.
+func LedgerLedgerIndex(ledger LedgerSpecifier) LedgerOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// LedgerTransactions lists the ledger's transactions
+func LedgerTransactions(transactions bool) LedgerOption {
+	return func(req BaseRequest) {
+		req["transactions"] = transactions
+	}
+}
+
+// LedgerExpand lists transactions, and account state with LedgerAccounts,
+// in full rather than as hashes
+func LedgerExpand(expand bool) LedgerOption {
+	return func(req BaseRequest) {
+		req["expand"] = expand
+	}
+}
+
+// LedgerAccounts lists the ledger's entire account state. This requires
+// admin access to the server; use LedgerData to page through the state
+// otherwise.
+func LedgerAccounts(accounts bool) LedgerOption {
+	return func(req BaseRequest) {
+		req["accounts"] = accounts
+	}
+}
+
+// LedgerBinary returns expanded transactions and metadata as hex blobs, in
+// the TxBlob and MetaBlob fields of each transaction
+func LedgerBinary(binary bool) LedgerOption {
+	return func(req BaseRequest) {
+		req["binary"] = binary
+	}
+}
+
+// Retrieve the header of a ledger, and optionally its transactions.
+//
+// Example usage:
+//
+//	ledger, err := client.Ledger(xrpl.LedgerTransactions(true), xrpl.LedgerExpand(true))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(ledger.LedgerIndex, ledger.LedgerHash, xrpl.RippleTimeToTime(ledger.CloseTime))
+//	for _, tx := range ledger.Transactions {
+//		fmt.Println(tx.Hash, tx.Tx["TransactionType"], tx.Meta.TransactionResult)
+//	}
+func (c *Client) Ledger(opts ...LedgerOption) (*LedgerResult, error) {
+	req := BaseRequest{
+		"command":      "ledger",
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		Ledger struct {
+			LedgerHash          string            `json:"ledger_hash"`
+			LedgerIndex         json.RawMessage   `json:"ledger_index"` // string in API v1
+			ParentHash          string            `json:"parent_hash"`
+			CloseTime           uint32            `json:"close_time"`
+			ParentCloseTime     uint32            `json:"parent_close_time"`
+			CloseTimeResolution uint32            `json:"close_time_resolution"`
+			CloseFlags          uint32            `json:"close_flags"`
+			TotalCoins          string            `json:"total_coins"`
+			AccountHash         string            `json:"account_hash"`
+			TransactionHash     string            `json:"transaction_hash"`
+			Closed              bool              `json:"closed"`
+			Transactions        []json.RawMessage `json:"transactions"`
+			AccountState        []json.RawMessage `json:"accountState"`
+		} `json:"ledger"`
+		LedgerHash  string `json:"ledger_hash"`
+		LedgerIndex uint32 `json:"ledger_index"`
+		Validated   bool   `json:"validated"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+
+	header := result.Ledger
+	ledger := &LedgerResult{
+		LedgerHash:          header.LedgerHash,
+		LedgerIndex:         result.LedgerIndex,
+		ParentHash:          header.ParentHash,
+		CloseTime:           header.CloseTime,
+		ParentCloseTime:     header.ParentCloseTime,
+		CloseTimeResolution: header.CloseTimeResolution,
+		CloseFlags:          header.CloseFlags,
+		TotalCoins:          header.TotalCoins,
+		AccountHash:         header.AccountHash,
+		TransactionHash:     header.TransactionHash,
+		Closed:              header.Closed,
+		Validated:           result.Validated,
+	}
+	if ledger.LedgerHash == "" {
+		ledger.LedgerHash = result.LedgerHash
+	}
+	if len(header.LedgerIndex) > 0 {
+		index, err := strconv.ParseUint(string(trimJSONString(header.LedgerIndex)), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ledger_index %s", header.LedgerIndex)
+		}
+		ledger.LedgerIndex = uint32(index)
+	}
+
+	for _, raw := range header.Transactions {
+		var hash string
+		if json.Unmarshal(raw, &hash) == nil {
+			ledger.TransactionHashes = append(ledger.TransactionHashes, hash)
+			continue
+		}
+		tx, err := ledgerTransaction(raw)
+		if err != nil {
+			return nil, err
+		}
+		tx.LedgerIndex = ledger.LedgerIndex
+		tx.Validated = ledger.Validated
+		ledger.Transactions = append(ledger.Transactions, tx)
+	}
+	for _, raw := range header.AccountState {
+		var hash string
+		if json.Unmarshal(raw, &hash) == nil {
+			ledger.AccountStateHashes = append(ledger.AccountStateHashes, hash)
+			continue
+		}
+		var obj LedgerObject
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("invalid ledger object: %w", err)
+		}
+		ledger.AccountState = append(ledger.AccountState, obj)
+	}
+	return ledger, nil
+}
+
+// Retrieve the index of the server's current open ledger, the ledger new
+// transactions are applied to, with the light ledger_current command.
+func (c *Client) LedgerCurrent() (uint32, error) {
+	result, err := c.ledgerLookup("ledger_current")
+	if err != nil {
+		return 0, err
+	}
+	index, ok := result["ledger_current_index"].(float64)
+	if !ok {
+		return 0, fmt.Errorf("ledger_current response has no ledger_current_index")
+	}
+	return uint32(index), nil
+}
+
+// Retrieve the index and hash of the latest closed ledger, which may not be
+// validated yet, with the light ledger_closed command.
+func (c *Client) LedgerClosed() (index uint32, hash string, err error) {
+	result, err := c.ledgerLookup("ledger_closed")
+	if err != nil {
+		return 0, "", err
+	}
+	ledgerIndex, ok := result["ledger_index"].(float64)
+	if !ok {
+		return 0, "", fmt.Errorf("ledger_closed response has no ledger_index")
+	}
+	hash, _ = result["ledger_hash"].(string)
+	return uint32(ledgerIndex), hash, nil
+}
+
+// ledgerLookup sends a parameterless ledger command and returns its result.
+// The result is read as decoded, without remarshalling, since these commands
+// are polled frequently.
+func (c *Client) ledgerLookup(command string) (map[string]interface{}, error) {
+	res, err := c.RequestChecked(BaseRequest{"command": command})
+	if err != nil {
+		return nil, err
+	}
+	result, ok := res["result"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s response has no result", command)
+	}
+	return result, nil
+}
+
+// ledgerTransaction decodes an expanded transaction of a ledger. API v1
+// lists the transaction fields with the metadata inlined as metaData; API
+// v2 and binary requests list them like account_tx does.
+func ledgerTransaction(raw json.RawMessage) (TxWithMeta, error) {
+	var entry accountTxEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return TxWithMeta{}, fmt.Errorf("invalid ledger transaction: %w", err)
+	}
+	if entry.TxJSON == nil && entry.TxBlob == "" {
+		var fields struct {
+			MetaData json.RawMessage `json:"metaData"`
+		}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return TxWithMeta{}, fmt.Errorf("invalid ledger transaction: %w", err)
+		}
+		if err := json.Unmarshal(raw, &entry.Tx); err != nil {
+			return TxWithMeta{}, fmt.Errorf("invalid ledger transaction: %w", err)
+		}
+		delete(entry.Tx, "metaData")
+		entry.Meta = fields.MetaData
+	}
+	return entry.txWithMeta()
+}
+
+// trimJSONString strips the quotes of a JSON string, leaving other JSON
+// values unchanged
+func trimJSONString(raw json.RawMessage) json.RawMessage {
+	if len(raw) >= 2 && raw[0] == '"' && raw[len(raw)-1] == '"' {
+		return raw[1 : len(raw)-1]
+	}
+	return raw
+}
@@ -0,0 +1,185 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+)
+
+// LedgerDataOption configures LedgerData and LedgerDataPage
+type LedgerDataOption func(*ledgerDataOptions)
+
+type ledgerDataOptions struct {
+	ctx        context.Context
+	objectType string
+	limit      int
+	binary     bool
+	marker     Marker
+}
+
+// LedgerDataType only returns objects of one type, given in the short form of
+// the API, e.g. "account", "state" for trust lines, "offer" or "amendments"
+func LedgerDataType(objectType string) LedgerDataOption {
+	return func(o *ledgerDataOptions) {
+		o.objectType = objectType
+	}
+}
+
+// LedgerDataLimit sets the number of objects requested per page. The server
+// enforces its own bounds, up to 256 by default, or 2048 in binary.
+func LedgerDataLimit(limit int) LedgerDataOption {
+	return func(o *ledgerDataOptions) {
+		o.limit = limit
+	}
+}
+
+// LedgerDataBinary requests objects in the binary format, which the server
+// pages through faster. They are decoded into the same LedgerObject values.
+func LedgerDataBinary(binary bool) LedgerDataOption {
+	return func(o *ledgerDataOptions) {
+		o.binary = binary
+	}
+}
+
+// LedgerDataMarker resumes listing at marker, as returned in the Marker of a
+// LedgerDataResult. To keep the marker valid, request the same ledger, e.g.
+// LedgerIndex(result.LedgerIndex).
+func LedgerDataMarker(marker Marker) LedgerDataOption {
+	return func(o *ledgerDataOptions) {
+		o.marker = marker
+	}
+}
+
+// LedgerDataContext bounds the requests by ctx. Once ctx is done, LedgerData
+// stops iterating and LedgerDataPage fails with an error wrapping ctx.Err().
+func LedgerDataContext(ctx context.Context) LedgerDataOption {
+	return func(o *ledgerDataOptions) {
+		o.ctx = ctx
+	}
+}
+
+// LedgerDataResult is a page of the state of a ledger, as returned by
+// LedgerDataPage. Marker is zero on the last page.
+type LedgerDataResult struct {
+	LedgerIndex uint32         `json:"ledger_index"`
+	LedgerHash  string         `json:"ledger_hash"`
+	State       []LedgerObject `json:"state"`
+	Marker      Marker         `json:"marker,omitempty"`
+}
+
+// LedgerData iterates over the state of the ledger selected by spec, every
+// object it holds, a page at a time. Later pages are read from the ledger the
+// first page came from. Iteration stops when yield returns false, after the
+// last page or once the context of LedgerDataContext is done. A failed
+// request also ends the iteration and is logged; use LedgerDataPage where the
+// error must be handled.
+//
+// Example usage:
+//
+//	pages := client.LedgerData(xrpl.LedgerValidated(), xrpl.LedgerDataType("offer"))
+//	pages(func(page []xrpl.LedgerObject) bool {
+//		for _, obj := range page {
+//			if offer, ok := obj.AsOffer(); ok {
+//				fmt.Println(offer.Account, offer.TakerGets, offer.TakerPays)
+//			}
+//		}
+//		return true
+//	})
+func (c *Client) LedgerData(spec LedgerSpecifier, opts ...LedgerDataOption) func(yield func([]LedgerObject) bool) {
+	return func(yield func([]LedgerObject) bool) {
+		options := newLedgerDataOptions(opts)
+		for {
+			page, err := c.ledgerDataPage(spec, options)
+			if err != nil {
+				if options.ctx.Err() == nil {
+					c.logger.Errorf("ledger_data error: %s %s", spec, err)
+				}
+				return
+			}
+			if !yield(page.State) || page.Marker.IsZero() {
+				return
+			}
+
+			options.marker = page.Marker
+			if page.LedgerIndex != 0 {
+				spec = LedgerIndex(page.LedgerIndex)
+			}
+		}
+	}
+}
+
+// Retrieve a single page of the state of the ledger selected by spec. The
+// Marker and LedgerIndex of the result, which may be persisted, request the
+// next page.
+//
+// Example usage:
+//
+//	page, err := client.LedgerDataPage(xrpl.LedgerValidated(), xrpl.LedgerDataLimit(100))
+//	if err != nil {
+//		return err
+//	}
+//	// Later, e.g. after restoring page.Marker and page.LedgerIndex:
+//	next, err := client.LedgerDataPage(xrpl.LedgerIndex(page.LedgerIndex),
+//		xrpl.LedgerDataLimit(100), xrpl.LedgerDataMarker(page.Marker))
+func (c *Client) LedgerDataPage(spec LedgerSpecifier, opts ...LedgerDataOption) (*LedgerDataResult, error) {
+	return c.ledgerDataPage(spec, newLedgerDataOptions(opts))
+}
+
+func newLedgerDataOptions(opts []LedgerDataOption) ledgerDataOptions {
+	options := ledgerDataOptions{ctx: context.Background()}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+// ledgerDataPage requests the page of ledger objects selected by spec and
+// options, decoding binary objects
+func (c *Client) ledgerDataPage(spec LedgerSpecifier, options ledgerDataOptions) (*LedgerDataResult, error) {
+	req := BaseRequest{"command": "ledger_data"}
+	if err := spec.apply(req); err != nil {
+		return nil, err
+	}
+	if options.objectType != "" {
+		req["type"] = options.objectType
+	}
+	if options.limit > 0 {
+		req["limit"] = options.limit
+	}
+	if options.binary {
+		req["binary"] = true
+	}
+	if !options.marker.IsZero() {
+		req["marker"] = options.marker
+	}
+
+	var result LedgerDataResult
+	if err := c.requestResultCtx(options.ctx, req, &result); err != nil {
+		return nil, err
+	}
+	if options.binary {
+		for i, obj := range result.State {
+			decoded, err := decodeLedgerObjectBlob(obj)
+			if err != nil {
+				return nil, err
+			}
+			result.State[i] = decoded
+		}
+	}
+	return &result, nil
+}
+
+// decodeLedgerObjectBlob decodes a binary ledger_data entry, the hex data of
+// the object and its index, into a LedgerObject
+func decodeLedgerObjectBlob(entry LedgerObject) (LedgerObject, error) {
+	data, _ := entry.fields["data"].(string)
+	fields, err := DecodeTxBlob(data)
+	if err != nil {
+		return LedgerObject{}, fmt.Errorf("invalid ledger object %s: %w", entry.Index(), err)
+	}
+	fields["index"] = entry.Index()
+	var obj LedgerObject
+	if err := remarshal(fields, &obj); err != nil {
+		return LedgerObject{}, err
+	}
+	return obj, nil
+}
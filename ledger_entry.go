@@ -0,0 +1,169 @@
+package xrpl
+
+import "fmt"
+
+// LedgerEntrySelector identifies the ledger object read by LedgerEntry,
+// either by its ID or by the fields its ID is derived from. Use one of the
+// Entry constructors, such as EscrowEntry or RippleStateEntry.
+type LedgerEntrySelector struct {
+	field string
+	value interface{}
+}
+
+// String returns the selector as the request field and value it sets
+func (s LedgerEntrySelector) String() string {
+	return fmt.Sprintf("%s %v", s.field, s.value)
+}
+
+// IndexEntry selects the object with the ID index, for any type of object
+func IndexEntry(index string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "index", value: index}
+}
+
+// AccountRootEntry selects the AccountRoot of account
+func AccountRootEntry(account string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "account_root", value: account}
+}
+
+// EscrowEntry selects the Escrow created by owner with the transaction of
+// sequence number seq
+func EscrowEntry(owner string, seq uint32) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "escrow", value: map[string]interface{}{
+		"owner": owner,
+		"seq":   seq,
+	}}
+}
+
+// RippleStateEntry selects the trust line for currency between the two
+// accounts, in either order
+func RippleStateEntry(accounts [2]string, currency string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "ripple_state", value: map[string]interface{}{
+		"accounts": accounts[:],
+		"currency": currency,
+	}}
+}
+
+// OfferEntry selects the Offer placed by account with the transaction of
+// sequence number seq
+func OfferEntry(account string, seq uint32) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "offer", value: map[string]interface{}{
+		"account": account,
+		"seq":     seq,
+	}}
+}
+
+// CheckEntry selects the Check with the ID checkID
+func CheckEntry(checkID string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "check", value: checkID}
+}
+
+// PayChannelEntry selects the PayChannel with the ID channelID
+func PayChannelEntry(channelID string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "payment_channel", value: channelID}
+}
+
+// DirectoryEntry selects page subIndex of the owner directory of owner, the
+// list of the objects it owns. The first page is 0.
+func DirectoryEntry(owner string, subIndex uint64) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "directory", value: map[string]interface{}{
+		"owner":     owner,
+		"sub_index": subIndex,
+	}}
+}
+
+// DepositPreauthEntry selects the preauthorization by owner of authorized
+// to send it payments
+func DepositPreauthEntry(owner, authorized string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "deposit_preauth", value: map[string]interface{}{
+		"owner":      owner,
+		"authorized": authorized,
+	}}
+}
+
+// TicketEntry selects the Ticket of account with sequence number ticketSeq
+func TicketEntry(account string, ticketSeq uint32) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "ticket", value: map[string]interface{}{
+		"account":    account,
+		"ticket_seq": ticketSeq,
+	}}
+}
+
+// NFTokenPageEntry selects the NFTokenPage with the ID pageID
+func NFTokenPageEntry(pageID string) LedgerEntrySelector {
+	return LedgerEntrySelector{field: "nft_page", value: pageID}
+}
+
+// LedgerEntryResult is a ledger object read by LedgerEntry, along with the
+// ledger it was read from
+type LedgerEntryResult struct {
+	Index       string       // ID of the object
+	Object      LedgerObject // The object, with its index
+	LedgerIndex uint32       // ledger_index, or ledger_current_index for the open ledger
+	Validated   bool
+}
+
+// LedgerEntryOption configures a ledger_entry request
+type LedgerEntryOption func(req BaseRequest)
+
+// LedgerEntryLedgerIndex selects the ledger to read the object from, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func LedgerEntryLedgerIndex(ledger LedgerSpecifier) LedgerEntryOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// Retrieve a single ledger object, selected by its ID or by the fields its ID
+// is derived from. An object that does not exist fails with an *XRPLError of
+// entryNotFound.
+//
+// Example usage:
+//
+//	entry, err := client.LedgerEntry(xrpl.EscrowEntry("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn", 7))
+//	if err != nil {
+//		return err
+//	}
+//	if escrow, ok := entry.Object.AsEscrow(); ok {
+//		fmt.Println(entry.Index, escrow.Amount, escrow.Destination)
+//	}
+func (c *Client) LedgerEntry(selector LedgerEntrySelector, opts ...LedgerEntryOption) (*LedgerEntryResult, error) {
+	if selector.field == "" {
+		return nil, fmt.Errorf("ledger entry selector is empty")
+	}
+	req := BaseRequest{
+		"command":      "ledger_entry",
+		selector.field: selector.value,
+		"ledger_index": "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		Index              string       `json:"index"`
+		Node               BaseResponse `json:"node"`
+		LedgerIndex        uint32       `json:"ledger_index"`
+		LedgerCurrentIndex uint32       `json:"ledger_current_index"`
+		Validated          bool         `json:"validated"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+	if result.Node == nil {
+		return nil, fmt.Errorf("ledger entry %s has no JSON node", selector)
+	}
+	if _, ok := result.Node["index"]; !ok {
+		result.Node["index"] = result.Index
+	}
+
+	entry := &LedgerEntryResult{
+		Index:       result.Index,
+		Object:      LedgerObject{fields: result.Node},
+		LedgerIndex: result.LedgerIndex,
+		Validated:   result.Validated,
+	}
+	if entry.LedgerIndex == 0 {
+		entry.LedgerIndex = result.LedgerCurrentIndex
+	}
+	return entry, nil
+}
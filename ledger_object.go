@@ -0,0 +1,249 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LedgerEntryType values of common ledger objects
+// https://xrpl.org/docs/references/protocol/ledger-data/ledger-entry-types
+const (
+	LedgerEntryTypeAccountRoot    = "AccountRoot"
+	LedgerEntryTypeCheck          = "Check"
+	LedgerEntryTypeDepositPreauth = "DepositPreauth"
+	LedgerEntryTypeDirectoryNode  = "DirectoryNode"
+	LedgerEntryTypeEscrow         = "Escrow"
+	LedgerEntryTypeNFTokenOffer   = "NFTokenOffer"
+	LedgerEntryTypeNFTokenPage    = "NFTokenPage"
+	LedgerEntryTypeOffer          = "Offer"
+	LedgerEntryTypePayChannel     = "PayChannel"
+	LedgerEntryTypeRippleState    = "RippleState"
+	LedgerEntryTypeSignerList     = "SignerList"
+	LedgerEntryTypeTicket         = "Ticket"
+)
+
+// LedgerObject is an object stored in the ledger, such as a trust line, an
+// offer or an escrow. LedgerEntryType tells its kind; the As methods decode
+// the common kinds into typed values, and Fields gives access to all fields.
+type LedgerObject struct {
+	fields BaseResponse
+}
+
+// LedgerEntryType returns the kind of the object, e.g. "RippleState"
+func (o LedgerObject) LedgerEntryType() string {
+	t, _ := o.fields["LedgerEntryType"].(string)
+	return t
+}
+
+// Index returns the object's ID in the ledger
+func (o LedgerObject) Index() string {
+	index, _ := o.fields["index"].(string)
+	return index
+}
+
+// Fields returns the object's fields as sent by the server
+func (o LedgerObject) Fields() BaseResponse {
+	return o.fields
+}
+
+func (o LedgerObject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.fields)
+}
+
+func (o *LedgerObject) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &o.fields)
+}
+
+// as decodes the object into v if it is of type t
+func (o LedgerObject) as(t string, v interface{}) bool {
+	if o.LedgerEntryType() != t {
+		return false
+	}
+	if err := remarshal(o.fields, v); err != nil {
+		return false
+	}
+	return true
+}
+
+// AsAccountRoot decodes an AccountRoot object. It reports false for objects of
+// other types.
+func (o LedgerObject) AsAccountRoot() (*AccountRoot, bool) {
+	var v AccountRoot
+	return &v, o.as(LedgerEntryTypeAccountRoot, &v)
+}
+
+// AsRippleState decodes a RippleState object, i.e. a trust line. It reports
+// false for objects of other types.
+func (o LedgerObject) AsRippleState() (*RippleState, bool) {
+	var v RippleState
+	return &v, o.as(LedgerEntryTypeRippleState, &v)
+}
+
+// AsOffer decodes an Offer object. It reports false for objects of other
+// types.
+func (o LedgerObject) AsOffer() (*Offer, bool) {
+	var v Offer
+	return &v, o.as(LedgerEntryTypeOffer, &v)
+}
+
+// AsEscrow decodes an Escrow object. It reports false for objects of other
+// types.
+func (o LedgerObject) AsEscrow() (*Escrow, bool) {
+	var v Escrow
+	return &v, o.as(LedgerEntryTypeEscrow, &v)
+}
+
+// AsPayChannel decodes a PayChannel object, i.e. a payment channel. It
+// reports false for objects of other types.
+func (o LedgerObject) AsPayChannel() (*PayChannel, bool) {
+	var v PayChannel
+	return &v, o.as(LedgerEntryTypePayChannel, &v)
+}
+
+// AsCheck decodes a Check object. It reports false for objects of other
+// types.
+func (o LedgerObject) AsCheck() (*Check, bool) {
+	var v Check
+	return &v, o.as(LedgerEntryTypeCheck, &v)
+}
+
+// AsSignerList decodes a SignerList object. It reports false for objects of
+// other types.
+func (o LedgerObject) AsSignerList() (*SignerList, bool) {
+	var v SignerList
+	return &v, o.as(LedgerEntryTypeSignerList, &v)
+}
+
+// AccountRoot is the ledger object holding an account's XRP balance and
+// settings
+type AccountRoot struct {
+	Account           string `json:"Account"`
+	Balance           Amount `json:"Balance"`
+	Flags             uint32 `json:"Flags"`
+	OwnerCount        uint32 `json:"OwnerCount"`
+	Sequence          uint32 `json:"Sequence"`
+	RegularKey        string `json:"RegularKey,omitempty"`
+	Domain            string `json:"Domain,omitempty"` // Hex encoded
+	EmailHash         string `json:"EmailHash,omitempty"`
+	MessageKey        string `json:"MessageKey,omitempty"`
+	TransferRate      uint32 `json:"TransferRate,omitempty"`
+	TickSize          uint8  `json:"TickSize,omitempty"`
+	Index             string `json:"index"`
+	PreviousTxnID     string `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq"`
+}
+
+// RippleState is a trust line between two accounts. Balance is from the
+// perspective of the low account: positive when the high account owes the low
+// account. The issuer of Balance is a placeholder; the two accounts are the
+// issuers of LowLimit and HighLimit.
+type RippleState struct {
+	Balance           Amount `json:"Balance"`
+	Flags             uint32 `json:"Flags"`
+	LowLimit          Amount `json:"LowLimit"`
+	HighLimit         Amount `json:"HighLimit"`
+	LowNode           string `json:"LowNode"`
+	HighNode          string `json:"HighNode"`
+	LowQualityIn      uint32 `json:"LowQualityIn,omitempty"`
+	LowQualityOut     uint32 `json:"LowQualityOut,omitempty"`
+	HighQualityIn     uint32 `json:"HighQualityIn,omitempty"`
+	HighQualityOut    uint32 `json:"HighQualityOut,omitempty"`
+	Index             string `json:"index"`
+	PreviousTxnID     string `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq"`
+}
+
+// Escrow holds an amount of XRP until it can be finished or cancelled
+type Escrow struct {
+	Account           string `json:"Account"`
+	Destination       string `json:"Destination"`
+	Amount            Amount `json:"Amount"`
+	Condition         string `json:"Condition,omitempty"`
+	CancelAfter       uint32 `json:"CancelAfter,omitempty"` // Ripple time
+	FinishAfter       uint32 `json:"FinishAfter,omitempty"` // Ripple time
+	DestinationTag    uint32 `json:"DestinationTag,omitempty"`
+	SourceTag         uint32 `json:"SourceTag,omitempty"`
+	Flags             uint32 `json:"Flags"`
+	OwnerNode         string `json:"OwnerNode"`
+	DestinationNode   string `json:"DestinationNode,omitempty"`
+	Index             string `json:"index"`
+	PreviousTxnID     string `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq"`
+}
+
+// PayChannel is a payment channel of XRP from Account to Destination. Balance
+// is the amount already paid out of Amount.
+type PayChannel struct {
+	Account           string `json:"Account"`
+	Destination       string `json:"Destination"`
+	Amount            Amount `json:"Amount"`
+	Balance           Amount `json:"Balance"`
+	PublicKey         string `json:"PublicKey"`
+	SettleDelay       uint32 `json:"SettleDelay"`           // Seconds
+	Expiration        uint32 `json:"Expiration,omitempty"`  // Ripple time
+	CancelAfter       uint32 `json:"CancelAfter,omitempty"` // Ripple time
+	DestinationTag    uint32 `json:"DestinationTag,omitempty"`
+	SourceTag         uint32 `json:"SourceTag,omitempty"`
+	Flags             uint32 `json:"Flags"`
+	OwnerNode         string `json:"OwnerNode"`
+	Index             string `json:"index"`
+	PreviousTxnID     string `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq"`
+}
+
+// Check is a deferred payment that Destination may cash for up to SendMax
+type Check struct {
+	Account           string `json:"Account"`
+	Destination       string `json:"Destination"`
+	SendMax           Amount `json:"SendMax"`
+	Sequence          uint32 `json:"Sequence"`
+	Expiration        uint32 `json:"Expiration,omitempty"` // Ripple time
+	InvoiceID         string `json:"InvoiceID,omitempty"`
+	DestinationTag    uint32 `json:"DestinationTag,omitempty"`
+	SourceTag         uint32 `json:"SourceTag,omitempty"`
+	Flags             uint32 `json:"Flags"`
+	OwnerNode         string `json:"OwnerNode"`
+	DestinationNode   string `json:"DestinationNode,omitempty"`
+	Index             string `json:"index"`
+	PreviousTxnID     string `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32 `json:"PreviousTxnLgrSeq"`
+}
+
+// SignerList lists the accounts that can multi-sign for its owner
+type SignerList struct {
+	SignerQuorum      uint32        `json:"SignerQuorum"`
+	SignerEntries     []SignerEntry `json:"SignerEntries"`
+	SignerListID      uint32        `json:"SignerListID"`
+	Flags             uint32        `json:"Flags"`
+	OwnerNode         string        `json:"OwnerNode"`
+	Index             string        `json:"index"`
+	PreviousTxnID     string        `json:"PreviousTxnID"`
+	PreviousTxnLgrSeq uint32        `json:"PreviousTxnLgrSeq"`
+}
+
+// SignerEntry is a member of a signer list. In JSON each entry is wrapped in
+// an object with a single SignerEntry field.
+type SignerEntry struct {
+	Account       string `json:"Account"`
+	SignerWeight  uint16 `json:"SignerWeight"`
+	WalletLocator string `json:"WalletLocator,omitempty"`
+}
+
+func (e SignerEntry) MarshalJSON() ([]byte, error) {
+	type signerEntry SignerEntry
+	return json.Marshal(map[string]signerEntry{"SignerEntry": signerEntry(e)})
+}
+
+func (e *SignerEntry) UnmarshalJSON(data []byte) error {
+	type signerEntry SignerEntry
+	var wrapper map[string]signerEntry
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+	entry, ok := wrapper["SignerEntry"]
+	if !ok {
+		return fmt.Errorf("signer entry is not wrapped in a SignerEntry field")
+	}
+	*e = SignerEntry(entry)
+	return nil
+}
@@ -0,0 +1,108 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LedgerSpecifier selects the ledger a request reads from: a ledger sequence
+// number, a ledger hash or one of the shorthands "validated", "closed" and
+// "current". The zero value selects the latest validated ledger. It is
+// accepted by every *LedgerIndex option of the client's helpers.
+//
+// Example usage:
+//
+//	info, err := client.AccountInfo(account, xrpl.AccountInfoLedgerIndex(xrpl.LedgerIndex(85000000)))
+//	book, err := client.BookOffers(pays, gets, xrpl.BookOffersLedgerIndex(xrpl.LedgerHash(hash)))
+type LedgerSpecifier struct {
+	index interface{} // uint32 or shorthand string
+	hash  string
+}
+
+// LedgerValidated selects the latest validated ledger
+func LedgerValidated() LedgerSpecifier {
+	return LedgerSpecifier{index: "validated"}
+}
+
+// LedgerClosed selects the latest closed ledger, which may not be validated
+// yet
+func LedgerClosed() LedgerSpecifier {
+	return LedgerSpecifier{index: "closed"}
+}
+
+// LedgerCurrent selects the server's open ledger
+func LedgerCurrent() LedgerSpecifier {
+	return LedgerSpecifier{index: "current"}
+}
+
+// LedgerIndex selects the ledger of sequence number n
+func LedgerIndex(n uint32) LedgerSpecifier {
+	return LedgerSpecifier{index: n}
+}
+
+// LedgerHash selects the ledger of hash h, 64 hex characters
+func LedgerHash(h string) LedgerSpecifier {
+	return LedgerSpecifier{hash: h}
+}
+
+func (s LedgerSpecifier) String() string {
+	if s.hash != "" {
+		return s.hash
+	}
+	if s.index == nil {
+		return "validated"
+	}
+	return fmt.Sprint(s.index)
+}
+
+// apply sets the ledger_hash or the ledger_index of req to the ledger
+// selected, removing the other one
+func (s LedgerSpecifier) apply(req BaseRequest) error {
+	if s.hash != "" {
+		if b, err := hex.DecodeString(s.hash); err != nil || len(b) != 32 {
+			return fmt.Errorf("invalid ledger_hash %q: must be 32 bytes of hex", s.hash)
+		}
+		req["ledger_hash"] = strings.ToUpper(s.hash)
+		delete(req, "ledger_index")
+		return nil
+	}
+	index := s.index
+	if index == nil {
+		index = "validated"
+	}
+	req["ledger_index"] = index
+	delete(req, "ledger_hash")
+	return nil
+}
+
+// resolveLedgerSpecifier checks the ledger_index of a request and rewrites it
+// to what the server expects. A LedgerSpecifier is applied, a decimal string
+// becomes a sequence number, and the shorthands are kept. A ledger hash given
+// as ledger_index is rejected rather than guessed at: it must be passed with
+// LedgerHash.
+func resolveLedgerSpecifier(req BaseRequest) error {
+	ledgerIndex, ok := req["ledger_index"]
+	if !ok {
+		return nil
+	}
+	switch v := ledgerIndex.(type) {
+	case LedgerSpecifier:
+		return v.apply(req)
+	case string:
+		switch v {
+		case "validated", "closed", "current":
+			return nil
+		}
+		if index, err := strconv.ParseUint(v, 10, 32); err == nil {
+			req["ledger_index"] = uint32(index)
+			return nil
+		}
+		if b, err := hex.DecodeString(v); err == nil && len(b) == 32 {
+			return fmt.Errorf("ambiguous ledger_index %q: looks like a ledger hash, use LedgerHash to select a ledger by hash", v)
+		}
+		return fmt.Errorf("invalid ledger_index %q: must be a ledger sequence number, \"validated\", \"closed\" or \"current\"", v)
+	}
+	return nil
+}
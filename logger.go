@@ -0,0 +1,62 @@
+package xrpl
+
+import (
+	"log"
+)
+
+// Logger receives the client's diagnostic messages. Messages carry request
+// ids, commands, response statuses and connection events, but never request
+// or response bodies, so seeds, private keys and signed blobs are not logged
+// at any level.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// ClientOption configures a Client beyond its ClientConfig
+type ClientOption func(*Client)
+
+// WithLogger sends the client's diagnostic messages to logger. Without it,
+// warnings and errors are written with the standard log package, as they
+// always have been, and debug and info messages, such as the trace of every
+// request and response, are discarded.
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config, xrpl.WithLogger(myLogger))
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = NopLogger()
+		}
+		c.logger = logger
+	}
+}
+
+// NopLogger returns a Logger that discards all messages
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+func (nopLogger) Infof(format string, args ...interface{})  {}
+func (nopLogger) Warnf(format string, args ...interface{})  {}
+func (nopLogger) Errorf(format string, args ...interface{}) {}
+
+// stdLogger is the default Logger. It writes warnings and errors with the
+// standard log package and discards debug and info messages.
+type stdLogger struct {
+	nopLogger
+}
+
+func (stdLogger) Warnf(format string, args ...interface{}) {
+	log.Printf(format, args...)
+}
+
+func (stdLogger) Errorf(format string, args ...interface{}) {
+	log.Printf(format, args...)
+}
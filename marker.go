@@ -0,0 +1,34 @@
+package xrpl
+
+import "encoding/json"
+
+// Marker is the pagination cursor of a paginated command such as
+// account_lines or ledger_data. Its shape differs from command to command, a
+// string for some and an object for others, so it is kept exactly as the
+// server sent it and sent back unchanged with the next request.
+//
+// A zero Marker means there are no more pages. A Marker can be persisted as
+// string(marker) and restored with Marker(s) to resume paging later, from the
+// same ledger.
+type Marker json.RawMessage
+
+// IsZero reports whether the marker is empty, as on the last page
+func (m Marker) IsZero() bool {
+	return len(m) == 0
+}
+
+func (m Marker) MarshalJSON() ([]byte, error) {
+	if m.IsZero() {
+		return []byte("null"), nil
+	}
+	return m, nil
+}
+
+func (m *Marker) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = nil
+		return nil
+	}
+	*m = append((*m)[:0], data...)
+	return nil
+}
@@ -0,0 +1,48 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// feeTransport answers fee with a base and open ledger fee of 10 drops, and
+// server_state with an owner reserve of 2 XRP
+var feeTransport = transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	result := map[string]interface{}{}
+	switch req["command"] {
+	case "fee":
+		result["drops"] = map[string]interface{}{
+			"base_fee":        "10",
+			"median_fee":      "5000",
+			"minimum_fee":     "10",
+			"open_ledger_fee": "10",
+		}
+	case "server_state":
+		result["state"] = map[string]interface{}{
+			"validated_ledger": map[string]interface{}{"reserve_base": 10000000, "reserve_inc": 2000000},
+		}
+	}
+	return BaseResponse{"status": "success", "result": result}, nil
+})
+
+func TestEstimateFeeAboveMax(t *testing.T) {
+	client := NewClient(ClientConfig{URL: "custom://", Transport: feeTransport, MaxFeeXRP: 1}, WithLogger(NopLogger()))
+
+	// 2 XRP for an AccountDelete is above the maximum of 1 XRP
+	fee, err := client.EstimateFee(map[string]interface{}{"TransactionType": "AccountDelete"})
+	if !errors.Is(err, ErrFeeAboveMax) {
+		t.Errorf("AccountDelete: fee %q, error %v, want ErrFeeAboveMax", fee, err)
+	}
+	tx := map[string]interface{}{"TransactionType": "AccountDelete", "Account": "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1", "Sequence": 1}
+	if err := client.Autofill(tx); !errors.Is(err, ErrFeeAboveMax) {
+		t.Errorf("Autofill of AccountDelete: %v, want ErrFeeAboveMax", err)
+	}
+	if _, ok := tx["Fee"]; ok {
+		t.Errorf("Autofill set Fee %v", tx["Fee"])
+	}
+
+	if fee, err := client.EstimateFee(map[string]interface{}{"TransactionType": "Payment"}); err != nil || fee != "10" {
+		t.Errorf("Payment: fee %q, %v, want 10", fee, err)
+	}
+}
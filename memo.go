@@ -0,0 +1,112 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Memo is a memo of a transaction with its fields decoded. A nil field is
+// absent from the memo, which the network distinguishes from an empty field.
+// Fields that are not valid UTF-8 hold the raw hex of the transaction.
+type Memo struct {
+	MemoType   *string // e.g. a URL or MIME type describing the data
+	MemoData   *string
+	MemoFormat *string // MIME type of the data, e.g. "text/plain"
+}
+
+// Sub-fields of a memo, in the order of their field codes
+var memoFields = []string{"MemoType", "MemoData", "MemoFormat"}
+
+// NewMemo builds an entry of a transaction's Memos array, hex encoding each
+// field into the {"Memo": {...}} wrapper the network expects. Empty
+// arguments are omitted from the memo.
+//
+// Example usage:
+//
+//	tx["Memos"] = []interface{}{
+//		xrpl.NewMemo("invoice", "INV-2024-0042", "text/plain"),
+//	}
+func NewMemo(memoType, data, format string) map[string]interface{} {
+	memo := make(map[string]interface{}, len(memoFields))
+	for i, value := range []string{memoType, data, format} {
+		if value != "" {
+			memo[memoFields[i]] = ToHexField(value)
+		}
+	}
+	return map[string]interface{}{"Memo": memo}
+}
+
+// DecodeMemos returns the memos of a transaction with their fields decoded,
+// in order, or none if the transaction has no Memos field.
+//
+// Example usage:
+//
+//	memos, err := xrpl.DecodeMemos(tx)
+//	if err != nil {
+//		return err
+//	}
+//	for _, memo := range memos {
+//		if memo.MemoData != nil {
+//			fmt.Println(*memo.MemoData)
+//		}
+//	}
+func DecodeMemos(tx map[string]interface{}) ([]Memo, error) {
+	value, ok := tx["Memos"]
+	if !ok {
+		return nil, nil
+	}
+	entries, ok := value.([]interface{})
+	if !ok {
+		if maps, isMaps := value.([]map[string]interface{}); isMaps {
+			for _, m := range maps {
+				entries = append(entries, m)
+			}
+		} else {
+			return nil, fmt.Errorf("invalid Memos: expected array, got %T", value)
+		}
+	}
+
+	memos := make([]Memo, 0, len(entries))
+	for i, entry := range entries {
+		wrapper, _ := entry.(map[string]interface{})
+		fields, ok := wrapper["Memo"].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid memo %d: expected {\"Memo\": {...}}, got %v", i, entry)
+		}
+
+		var memo Memo
+		targets := []**string{&memo.MemoType, &memo.MemoData, &memo.MemoFormat}
+		for j, name := range memoFields {
+			raw, ok := fields[name]
+			if !ok {
+				continue
+			}
+			decoded, err := decodeMemoField(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s of memo %d: %w", name, i, err)
+			}
+			*targets[j] = &decoded
+		}
+		memos = append(memos, memo)
+	}
+	return memos, nil
+}
+
+// decodeMemoField decodes a hex encoded memo field to text, or returns the
+// hex as is when it does not encode valid UTF-8.
+func decodeMemoField(raw interface{}) (string, error) {
+	s, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("expected hex string, got %T", raw)
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("expected hex string: %w", err)
+	}
+	if !utf8.Valid(data) {
+		return strings.ToUpper(s), nil
+	}
+	return string(data), nil
+}
@@ -0,0 +1,57 @@
+package xrpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewMemo(t *testing.T) {
+	got := NewMemo("invoice", "INV-2024-0042", "")
+	want := map[string]interface{}{
+		"Memo": map[string]interface{}{
+			"MemoType": "696E766F696365",
+			"MemoData": "494E562D323032342D30303432",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDecodeMemos(t *testing.T) {
+	text := func(s string) *string { return &s }
+	tx := map[string]interface{}{
+		"Memos": []interface{}{
+			NewMemo("invoice", "INV-2024-0042", "text/plain"),
+			// An empty field is kept apart from an absent one
+			map[string]interface{}{"Memo": map[string]interface{}{"MemoData": ""}},
+			// Binary data is returned as hex
+			map[string]interface{}{"Memo": map[string]interface{}{"MemoData": "ff00fe"}},
+		},
+	}
+	memos, err := DecodeMemos(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Memo{
+		{MemoType: text("invoice"), MemoData: text("INV-2024-0042"), MemoFormat: text("text/plain")},
+		{MemoData: text("")},
+		{MemoData: text("FF00FE")},
+	}
+	if !reflect.DeepEqual(memos, want) {
+		t.Errorf("got %+v, want %+v", memos, want)
+	}
+
+	if memos, err := DecodeMemos(map[string]interface{}{}); memos != nil || err != nil {
+		t.Errorf("no Memos: got %v, %v", memos, err)
+	}
+	for _, invalid := range []interface{}{
+		"memo",
+		[]interface{}{map[string]interface{}{"MemoData": "00"}},
+		[]interface{}{map[string]interface{}{"Memo": map[string]interface{}{"MemoData": "zz"}}},
+	} {
+		if _, err := DecodeMemos(map[string]interface{}{"Memos": invalid}); err == nil {
+			t.Errorf("Memos %v: no error", invalid)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package xrpl
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Metrics receives measurements of the client's requests and connection, to
+// be bridged to Prometheus, OpenTelemetry or similar without this package
+// depending on them. Methods are called on the request path and the read
+// loop, so they must be safe for concurrent use and return quickly.
+type Metrics interface {
+	// RequestStarted is called as a request for command is sent
+	RequestStarted(command string)
+	// RequestFinished is called once the request has a response, or failed
+	// without one, in which case err is set. Error responses from the server
+	// are responses: err is nil for them.
+	RequestFinished(command string, latency time.Duration, err error)
+	// Reconnected is called each time the client has reconnected
+	Reconnected()
+	// MessageDropped is called for each message discarded by the client: a
+	// stream message of type messageType dropped by a full stream queue, see
+	// WithStreamBuffer, or a response matching no pending request, of type
+	// "response"
+	MessageDropped(messageType string)
+}
+
+// WithMetrics sends the client's measurements to metrics. Without it they
+// are discarded.
+//
+// Example usage:
+//
+//	metrics := xrpl.NewCounterMetrics()
+//	client := xrpl.NewClient(config, xrpl.WithMetrics(metrics))
+//	// Later, e.g. from a /metrics handler:
+//	stats := metrics.Stats()
+//	fmt.Println(stats.Requests["account_info"], stats.InFlight, stats.Reconnects)
+func WithMetrics(metrics Metrics) ClientOption {
+	return func(c *Client) {
+		if metrics == nil {
+			metrics = NopMetrics()
+		}
+		c.metrics = metrics
+	}
+}
+
+// NopMetrics returns a Metrics that discards all measurements
+func NopMetrics() Metrics {
+	return nopMetrics{}
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) RequestStarted(command string)                                    {}
+func (nopMetrics) RequestFinished(command string, latency time.Duration, err error) {}
+func (nopMetrics) Reconnected()                                                     {}
+func (nopMetrics) MessageDropped(messageType string)                                {}
+
+// latencyBuckets are the upper bounds of the latency histogram of
+// CounterMetrics, as in Prometheus' default buckets
+var latencyBuckets = [...]time.Duration{
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+	10 * time.Second,
+}
+
+// CounterMetrics is a Metrics keeping counters in memory, read with Stats.
+// Recording takes no locks once a command has been seen.
+type CounterMetrics struct {
+	requests    sync.Map // command -> *atomic.Uint64
+	failures    atomic.Uint64
+	inFlight    atomic.Int64
+	reconnects  atomic.Uint64
+	dropped     atomic.Uint64
+	latencySum  atomic.Int64
+	latencyHist [len(latencyBuckets) + 1]atomic.Uint64 // then +Inf
+}
+
+// MetricsStats is a snapshot of CounterMetrics
+type MetricsStats struct {
+	Requests        map[string]uint64 // Requests sent, by command
+	Failures        uint64            // Requests that failed without a response
+	InFlight        int64             // Requests awaiting a response
+	Reconnects      uint64
+	DroppedMessages uint64
+	// LatencyBuckets are the upper bounds of the latency histogram, from
+	// 5ms to 10s. LatencyCounts counts finished requests by latency:
+	// LatencyCounts[i] those within LatencyBuckets[i] but above the previous
+	// bound, and its last entry those above every bound.
+	LatencyBuckets []time.Duration
+	LatencyCounts  []uint64
+	LatencySum     time.Duration // Total latency of finished requests
+}
+
+var _ Metrics = (*CounterMetrics)(nil)
+
+// NewCounterMetrics returns a CounterMetrics with all counters at zero
+func NewCounterMetrics() *CounterMetrics {
+	return &CounterMetrics{}
+}
+
+func (m *CounterMetrics) RequestStarted(command string) {
+	counter, ok := m.requests.Load(command)
+	if !ok {
+		counter, _ = m.requests.LoadOrStore(command, new(atomic.Uint64))
+	}
+	counter.(*atomic.Uint64).Add(1)
+	m.inFlight.Add(1)
+}
+
+func (m *CounterMetrics) RequestFinished(command string, latency time.Duration, err error) {
+	m.inFlight.Add(-1)
+	if err != nil {
+		m.failures.Add(1)
+	}
+	m.latencySum.Add(int64(latency))
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	m.latencyHist[bucket].Add(1)
+}
+
+func (m *CounterMetrics) Reconnected() {
+	m.reconnects.Add(1)
+}
+
+func (m *CounterMetrics) MessageDropped(messageType string) {
+	m.dropped.Add(1)
+}
+
+// Stats returns a snapshot of the counters. Counters are read one at a time
+// while requests may still be recorded, so they may be off from each other
+// by the requests in progress.
+func (m *CounterMetrics) Stats() MetricsStats {
+	stats := MetricsStats{
+		Requests:        make(map[string]uint64),
+		Failures:        m.failures.Load(),
+		InFlight:        m.inFlight.Load(),
+		Reconnects:      m.reconnects.Load(),
+		DroppedMessages: m.dropped.Load(),
+		LatencyBuckets:  append([]time.Duration(nil), latencyBuckets[:]...),
+		LatencyCounts:   make([]uint64, len(m.latencyHist)),
+		LatencySum:      time.Duration(m.latencySum.Load()),
+	}
+	m.requests.Range(func(command, counter interface{}) bool {
+		stats.Requests[command.(string)] = counter.(*atomic.Uint64).Load()
+		return true
+	})
+	for i := range m.latencyHist {
+		stats.LatencyCounts[i] = m.latencyHist[i].Load()
+	}
+	return stats
+}
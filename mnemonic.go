@@ -0,0 +1,192 @@
+package xrpl
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// English word list of BIP39
+// https://github.com/bitcoin/bips/blob/master/bip-0039/english.txt
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+var bip39Words, bip39WordIndex = parseBIP39Words(bip39English)
+
+func parseBIP39Words(list string) ([]string, map[string]int) {
+	words := strings.Fields(list)
+	index := make(map[string]int, len(words))
+	for i, word := range words {
+		index[word] = i
+	}
+	return words, index
+}
+
+// Coin type registered for XRP in SLIP-0044
+const bip44CoinTypeXRP = 144
+
+// Offset of hardened BIP32 child indexes
+const bip32Hardened uint32 = 0x80000000
+
+// WalletFromMnemonic derives a secp256k1 wallet from a BIP39 mnemonic, as
+// hardware wallets and most multi-coin wallets do. The mnemonic's checksum is
+// validated, the BIP39 seed is derived with the optional passphrase, and the
+// key is derived along the BIP44 path m/44'/144'/0'/0/accountIndex. The
+// returned Wallet has no Seed, since the key cannot be expressed as a family
+// seed. Mnemonic and passphrase are expected in Unicode NFKD form, which is
+// the case for the English word list and ASCII passphrases.
+//
+// Example usage:
+//
+//	wallet, err := xrpl.WalletFromMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", 0)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(wallet.ClassicAddress)
+func WalletFromMnemonic(mnemonic, passphrase string, accountIndex uint32) (*Wallet, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if err := validateMnemonic(words); err != nil {
+		return nil, err
+	}
+
+	seed := pbkdf2SHA512([]byte(strings.Join(words, " ")), []byte("mnemonic"+passphrase), 2048, 64)
+	path := []uint32{
+		44 | bip32Hardened,
+		bip44CoinTypeXRP | bip32Hardened,
+		0 | bip32Hardened,
+		0,
+		accountIndex,
+	}
+	privateKey, err := deriveBIP32Key(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := privateKey.PubKey().SerializeCompressed()
+	address, err := DeriveAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{
+		PublicKey:      strings.ToUpper(hex.EncodeToString(publicKey)),
+		PrivateKey:     privateKey.Serialize(),
+		ClassicAddress: address,
+		KeyType:        KeyTypeSecp256k1,
+	}, nil
+}
+
+// validateMnemonic checks that words form a BIP39 mnemonic of 12 to 24 words
+// from the English word list with a valid checksum. Each word encodes 11
+// bits; the entropy is followed by the first bits of its SHA-256 hash, one
+// bit for every 32 bits of entropy.
+func validateMnemonic(words []string) error {
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return fmt.Errorf("invalid mnemonic: must have 12, 15, 18, 21 or 24 words, got %d", len(words))
+	}
+
+	bits := make([]byte, 0, len(words)*11)
+	for i, word := range words {
+		index, ok := bip39WordIndex[word]
+		if !ok {
+			return fmt.Errorf("invalid mnemonic: word %d %q is not in the BIP39 English word list", i+1, word)
+		}
+		for b := 10; b >= 0; b-- {
+			bits = append(bits, byte(index>>b)&1)
+		}
+	}
+
+	checksumBits := len(bits) / 33
+	entropy := make([]byte, (len(bits)-checksumBits)/8)
+	for i := range entropy {
+		for _, bit := range bits[i*8 : i*8+8] {
+			entropy[i] = entropy[i]<<1 | bit
+		}
+	}
+	checksum := sha256.Sum256(entropy)
+	for i, bit := range bits[len(entropy)*8:] {
+		if checksum[i/8]>>(7-i%8)&1 != bit {
+			return fmt.Errorf("invalid mnemonic: checksum mismatch")
+		}
+	}
+	return nil
+}
+
+// deriveBIP32Key derives the secp256k1 private key at path from a BIP32 seed.
+// Indexes at or above bip32Hardened denote hardened children.
+func deriveBIP32Key(seed []byte, path []uint32) (*secp256k1.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	var key secp256k1.ModNScalar
+	if overflow := key.SetByteSlice(sum[:32]); overflow || key.IsZero() {
+		return nil, fmt.Errorf("invalid BIP32 master key")
+	}
+	chainCode := sum[32:]
+
+	data := make([]byte, 0, 37)
+	for _, index := range path {
+		data = data[:0]
+		if index >= bip32Hardened {
+			keyBytes := key.Bytes()
+			data = append(append(data, 0), keyBytes[:]...)
+		} else {
+			data = append(data, secp256k1.NewPrivateKey(&key).PubKey().SerializeCompressed()...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		mac := hmac.New(sha512.New, chainCode)
+		mac.Write(data)
+		sum := mac.Sum(nil)
+
+		var tweak secp256k1.ModNScalar
+		if overflow := tweak.SetByteSlice(sum[:32]); overflow {
+			return nil, fmt.Errorf("invalid BIP32 child key at index %d", index)
+		}
+		key.Add(&tweak)
+		if key.IsZero() {
+			return nil, fmt.Errorf("invalid BIP32 child key at index %d", index)
+		}
+		chainCode = sum[32:]
+	}
+	return secp256k1.NewPrivateKey(&key), nil
+}
+
+// pbkdf2SHA512 derives a key of keyLen bytes from password and salt with
+// PBKDF2 using HMAC-SHA512, as specified in RFC 8018.
+func pbkdf2SHA512(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha512.New, password)
+	key := make([]byte, 0, keyLen)
+	for block := uint32(1); len(key) < keyLen; block++ {
+		key = append(key, pbkdf2Block(prf, salt, iterations, block)...)
+	}
+	return key[:keyLen]
+}
+
+func pbkdf2Block(prf hash.Hash, salt []byte, iterations int, block uint32) []byte {
+	prf.Reset()
+	prf.Write(salt)
+	prf.Write(binary.BigEndian.AppendUint32(nil, block))
+	u := prf.Sum(nil)
+	t := append([]byte{}, u...)
+	for i := 1; i < iterations; i++ {
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		for j := range t {
+			t[j] ^= u[j]
+		}
+	}
+	return t
+}
@@ -0,0 +1,127 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SignFor signs a transaction on behalf of one signer of the sending account's
+// SignerList. It sets the transaction's SigningPubKey to the empty string, as
+// multi-signed transactions require, and returns the signer entry
+// {Account, SigningPubKey, TxnSignature} to be added to the transaction's
+// Signers array. Every signer signs the same transaction, so the Signers
+// array itself is not part of the signed data.
+//
+// Example usage:
+//
+//	entry1, err := xrpl.SignFor(tx, seed1)
+//	entry2, err := xrpl.SignFor(tx, seed2)
+//	tx["Signers"] = []interface{}{entry1, entry2}
+//	res, err := client.SubmitMultisigned(tx)
+func SignFor(tx map[string]interface{}, signerSeed string) (signerEntry map[string]interface{}, err error) {
+	if err := validateTxFlags(tx); err != nil {
+		return nil, err
+	}
+	privateKey, keyType, err := DecodeSeed(signerSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode family seed: %w", err)
+	}
+
+	publicKey := publicKeyFor(privateKey, keyType)
+	account, err := DeriveAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	tx["SigningPubKey"] = ""
+	message, err := EncodeForMultisigning(tx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize transaction for multi-signing: %w", err)
+	}
+	signature := signWithKey(privateKey, keyType, message)
+
+	return map[string]interface{}{
+		"Account":       account,
+		"SigningPubKey": strings.ToUpper(hex.EncodeToString(publicKey)),
+		"TxnSignature":  strings.ToUpper(hex.EncodeToString(signature)),
+	}, nil
+}
+
+// SubmitMultisigned submits a multi-signed transaction with the
+// submit_multisigned command. The transaction's Signers may hold bare entries
+// as returned by SignFor or entries already wrapped as {"Signer": entry}; they
+// are wrapped where needed and sorted by the numeric value of their Account,
+// as the network requires. The engine result is classified in the returned
+// SubmitResult; a request the server rejects fails with an *XRPLError.
+func (c *Client) SubmitMultisigned(tx map[string]interface{}) (*SubmitResult, error) {
+	signers, err := sortSigners(tx["Signers"])
+	if err != nil {
+		return nil, err
+	}
+	tx["Signers"] = signers
+	tx["SigningPubKey"] = ""
+
+	req := BaseRequest{
+		"command": "submit_multisigned",
+		"tx_json": tx,
+	}
+	res, err := c.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubmitResult(req, res)
+}
+
+// sortSigners returns the Signers array of a transaction wrapped in Signer
+// objects and ordered by the signers' AccountIDs.
+func sortSigners(value interface{}) ([]interface{}, error) {
+	var entries []map[string]interface{}
+	switch v := value.(type) {
+	case []map[string]interface{}:
+		entries = v
+	case []interface{}:
+		for _, item := range v {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid Signers entry: %v", item)
+			}
+			entries = append(entries, entry)
+		}
+	case nil:
+		return nil, fmt.Errorf("transaction has no Signers")
+	default:
+		return nil, fmt.Errorf("invalid Signers field: %T", value)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("transaction has no Signers")
+	}
+
+	type signer struct {
+		accountID []byte
+		entry     map[string]interface{}
+	}
+	sorted := make([]signer, 0, len(entries))
+	for _, entry := range entries {
+		if inner, ok := entry["Signer"].(map[string]interface{}); ok {
+			entry = inner
+		}
+		account, _ := entry["Account"].(string)
+		accountID, err := decodeAccountID(account)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signer account %s: %w", account, err)
+		}
+		sorted = append(sorted, signer{accountID: accountID, entry: entry})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].accountID, sorted[j].accountID) < 0
+	})
+
+	signers := make([]interface{}, len(sorted))
+	for i, s := range sorted {
+		signers[i] = map[string]interface{}{"Signer": s.entry}
+	}
+	return signers, nil
+}
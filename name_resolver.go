@@ -0,0 +1,95 @@
+package xrpl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NameResolver resolves a human-readable account name, such as an alias
+// published in an xrpl.toml or kept by a custom service, to the address it
+// stands for and the destination tag to use with it, if any
+type NameResolver interface {
+	Resolve(name string) (address string, tag *uint32, err error)
+}
+
+// NameResolverFunc adapts a function to a NameResolver
+type NameResolverFunc func(name string) (address string, tag *uint32, err error)
+
+// Resolve calls f(name)
+func (f NameResolverFunc) Resolve(name string) (string, *uint32, error) {
+	return f(name)
+}
+
+// DestinationNamePrefix marks a destination as a name to be resolved with a
+// NameResolver, as in "name:alice"
+const DestinationNamePrefix = "name:"
+
+// XAddressResolver resolves X-addresses to the classic address and tag they
+// encode. ResolveDestination applies it to every destination, so it needs no
+// configuring.
+type XAddressResolver struct{}
+
+// Resolve decodes the X-address name
+func (XAddressResolver) Resolve(name string) (string, *uint32, error) {
+	classic, tag, _, err := DecodeXAddress(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid X-address %q: %w", name, err)
+	}
+	return classic, tag, nil
+}
+
+// ResolveDestination returns the classic address and destination tag of
+// destination, which is, in order of precedence:
+//
+//   - a classic address, returned as is without a tag;
+//   - an X-address, decoded by XAddressResolver to its classic address and
+//     tag;
+//   - a name prefixed with DestinationNamePrefix, resolved by resolver,
+//     which may return a classic address or an X-address.
+//
+// A name without a resolver, or any other destination, fails with an error.
+func ResolveDestination(destination string, resolver NameResolver) (address string, tag *uint32, err error) {
+	if IsValidClassicAddress(destination) {
+		return destination, nil, nil
+	}
+	if IsValidXAddress(destination) {
+		return XAddressResolver{}.Resolve(destination)
+	}
+	name, isName := strings.CutPrefix(destination, DestinationNamePrefix)
+	if !isName {
+		return "", nil, fmt.Errorf("invalid destination %q: not a classic address, an X-address or a %q name", destination, DestinationNamePrefix)
+	}
+	if resolver == nil {
+		return "", nil, fmt.Errorf("cannot resolve destination %q: no NameResolver", destination)
+	}
+	address, tag, err = resolver.Resolve(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to resolve destination %q: %w", destination, err)
+	}
+	if IsValidXAddress(address) {
+		classic, xTag, err := XAddressResolver{}.Resolve(address)
+		if err != nil {
+			return "", nil, err
+		}
+		if tag, err = mergeDestinationTags(tag, xTag); err != nil {
+			return "", nil, fmt.Errorf("destination %q resolved to %s: %w", destination, address, err)
+		}
+		address = classic
+	}
+	if err := ValidateClassicAddress(address); err != nil {
+		return "", nil, fmt.Errorf("destination %q resolved to an invalid address: %w", destination, err)
+	}
+	return address, tag, nil
+}
+
+// mergeDestinationTags returns the destination tag given by either a or b, or
+// an error when both give different tags
+func mergeDestinationTags(a, b *uint32) (*uint32, error) {
+	if a == nil {
+		return b, nil
+	}
+	if b != nil && *a != *b {
+		return nil, fmt.Errorf("conflicting destination tags %d and %d", *a, *b)
+	}
+	return a, nil
+}
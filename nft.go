@@ -0,0 +1,315 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bounds of NFTokenMint fields
+const (
+	nftokenMaxTransferFee = 50000 // 50%, in units of 0.001%
+	nftokenMaxURILength   = 256   // Bytes
+)
+
+// NFTokenMintOption configures BuildNFTokenMint
+type NFTokenMintOption func(*nftokenMintOptions)
+
+type nftokenMintOptions struct {
+	flags       uint32
+	uri         *string
+	transferFee uint16
+	issuer      string
+}
+
+// NFTokenMintURI sets the URI of the token's data or metadata, such as an
+// ipfs:// link. It is given as text and hex encoded in the transaction, and
+// may be up to 256 bytes long.
+func NFTokenMintURI(uri string) NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.uri = &uri
+	}
+}
+
+// NFTokenMintTransferFee sets the fee the issuer receives when the token is
+// sold by one holder to another, in units of 0.001%, from 0 to 50000, i.e.
+// 50%. The token must be NFTokenMintTransferable.
+func NFTokenMintTransferFee(fee uint16) NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.transferFee = fee
+	}
+}
+
+// NFTokenMintBurnable lets the issuer burn the token even when another
+// account holds it, TfBurnable
+func NFTokenMintBurnable() NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.flags |= TfBurnable
+	}
+}
+
+// NFTokenMintOnlyXRP only allows the token to be bought and sold for XRP,
+// TfOnlyXRP
+func NFTokenMintOnlyXRP() NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.flags |= TfOnlyXRP
+	}
+}
+
+// NFTokenMintTransferable lets holders other than the issuer transfer the
+// token, TfTransferable. Without it the token can only be sent back to its
+// issuer.
+func NFTokenMintTransferable() NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.flags |= TfTransferable
+	}
+}
+
+// NFTokenMintIssuer mints the token on behalf of issuer, which must have
+// made account its NFTokenMinter with AccountSet
+func NFTokenMintIssuer(issuer string) NFTokenMintOption {
+	return func(o *nftokenMintOptions) {
+		o.issuer = issuer
+	}
+}
+
+// BuildNFTokenMint builds an NFTokenMint transaction from account, ready to
+// be autofilled and signed, minting a token of the issuer's taxon, a number
+// grouping tokens of one collection.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildNFTokenMint(
+//		wallet.ClassicAddress,
+//		0,
+//		xrpl.NFTokenMintURI("ipfs://bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"),
+//		xrpl.NFTokenMintTransferable(),
+//		xrpl.NFTokenMintTransferFee(2500))
+func BuildNFTokenMint(account string, taxon uint32, opts ...NFTokenMintOption) (map[string]interface{}, error) {
+	var options nftokenMintOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.transferFee > nftokenMaxTransferFee {
+		return nil, fmt.Errorf("NFToken TransferFee out of bounds: %d, at most %d", options.transferFee, nftokenMaxTransferFee)
+	}
+	if options.transferFee != 0 && options.flags&TfTransferable == 0 {
+		return nil, fmt.Errorf("NFToken TransferFee requires a transferable token")
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeNFTokenMint),
+		"Account":         account,
+		"NFTokenTaxon":    taxon,
+	}
+	if options.flags != 0 {
+		tx["Flags"] = FlagsFor(TxTypeNFTokenMint).Set(options.flags)
+	}
+	if options.uri != nil {
+		if len(*options.uri) == 0 || len(*options.uri) > nftokenMaxURILength {
+			return nil, fmt.Errorf("NFToken URI out of bounds: %d bytes, from 1 to %d", len(*options.uri), nftokenMaxURILength)
+		}
+		tx["URI"] = ToHexField(*options.uri)
+	}
+	if options.transferFee != 0 {
+		tx["TransferFee"] = options.transferFee
+	}
+	if options.issuer != "" {
+		tx["Issuer"] = options.issuer
+	}
+	return tx, nil
+}
+
+// BuildNFTokenBurn builds an NFTokenBurn transaction from account destroying
+// the token nftokenID. The owner is the account holding the token, for
+// issuers burning a burnable token they no longer hold, or "" when account
+// holds it.
+func BuildNFTokenBurn(account, nftokenID, owner string) (map[string]interface{}, error) {
+	info, err := ParseNFTokenID(nftokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeNFTokenBurn),
+		"Account":         account,
+		"NFTokenID":       info.NFTokenID,
+	}
+	if owner != "" && owner != account {
+		tx["Owner"] = owner
+	}
+	return tx, nil
+}
+
+// NFTokenOfferOption configures BuildNFTokenCreateOffer
+type NFTokenOfferOption func(*nftokenOfferOptions)
+
+type nftokenOfferOptions struct {
+	sell        bool
+	owner       string
+	destination string
+	expiration  time.Time
+}
+
+// NFTokenOfferSell makes the offer one to sell a token account holds,
+// TfSellNFToken. Offers are offers to buy otherwise.
+func NFTokenOfferSell() NFTokenOfferOption {
+	return func(o *nftokenOfferOptions) {
+		o.sell = true
+	}
+}
+
+// NFTokenOfferOwner sets the account holding the token a buy offer is made
+// for. Buy offers require it, sell offers must not set it.
+func NFTokenOfferOwner(owner string) NFTokenOfferOption {
+	return func(o *nftokenOfferOptions) {
+		o.owner = owner
+	}
+}
+
+// NFTokenOfferDestination restricts who may accept the offer to destination,
+// e.g. a broker matching offers
+func NFTokenOfferDestination(destination string) NFTokenOfferOption {
+	return func(o *nftokenOfferOptions) {
+		o.destination = destination
+	}
+}
+
+// NFTokenOfferExpiration sets the time after which the offer is no longer
+// active
+func NFTokenOfferExpiration(t time.Time) NFTokenOfferOption {
+	return func(o *nftokenOfferOptions) {
+		o.expiration = t
+	}
+}
+
+// BuildNFTokenCreateOffer builds an NFTokenCreateOffer transaction from
+// account, ready to be autofilled and signed, offering to buy the token
+// nftokenID from its owner for amount, or with NFTokenOfferSell to sell it
+// for amount. Sell offers may ask for nothing, to give the token away.
+//
+// Example usage:
+//
+//	// Sell a token for 10 XRP
+//	tx, err := xrpl.BuildNFTokenCreateOffer(
+//		wallet.ClassicAddress,
+//		"000B013A95F14B0044F78A264E41713C64B5F89242540EE208C3098E00000D65",
+//		xrpl.XRPAmount("10000000"),
+//		xrpl.NFTokenOfferSell())
+func BuildNFTokenCreateOffer(account, nftokenID string, amount Amount, opts ...NFTokenOfferOption) (map[string]interface{}, error) {
+	var options nftokenOfferOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	info, err := ParseNFTokenID(nftokenID)
+	if err != nil {
+		return nil, err
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid NFToken offer Amount: %w", err)
+	}
+	value, err := amount.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid NFToken offer Amount: %w", err)
+	}
+	if value.Sign() < 0 || (!options.sell && value.Sign() == 0) {
+		return nil, fmt.Errorf("NFToken offer Amount must be positive, or zero for a sell offer: %s", amount)
+	}
+	if info.Flags&uint16(TfOnlyXRP) != 0 && !amount.IsXRP() {
+		return nil, fmt.Errorf("NFToken %s can only be traded for XRP", info.NFTokenID)
+	}
+	switch {
+	case options.sell && options.owner != "":
+		return nil, fmt.Errorf("NFToken sell offer cannot set an Owner, the seller holds the token")
+	case !options.sell && options.owner == "":
+		return nil, fmt.Errorf("NFToken buy offer needs the Owner of the token")
+	case !options.sell && options.owner == account:
+		return nil, fmt.Errorf("NFToken buy offer cannot be made to its own account")
+	case options.destination == account:
+		return nil, fmt.Errorf("NFToken offer Destination cannot be its own account")
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeNFTokenCreateOffer),
+		"Account":         account,
+		"NFTokenID":       info.NFTokenID,
+		"Amount":          amount,
+	}
+	if options.sell {
+		tx["Flags"] = FlagsFor(TxTypeNFTokenCreateOffer).Set(TfSellNFToken)
+	}
+	if options.owner != "" {
+		tx["Owner"] = options.owner
+	}
+	if options.destination != "" {
+		tx["Destination"] = options.destination
+	}
+	if !options.expiration.IsZero() {
+		expiration, err := TimeToRippleTime(options.expiration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid NFToken offer Expiration: %w", err)
+		}
+		tx["Expiration"] = expiration
+	}
+	return tx, nil
+}
+
+// NFTokenAcceptOfferOption configures BuildNFTokenAcceptOffer
+type NFTokenAcceptOfferOption func(*nftokenAcceptOfferOptions)
+
+type nftokenAcceptOfferOptions struct {
+	brokerFee *Amount
+}
+
+// NFTokenAcceptBrokerFee sets the fee a broker matching a sell and a buy
+// offer keeps from the buyer's amount, in the currency of the offers
+func NFTokenAcceptBrokerFee(fee Amount) NFTokenAcceptOfferOption {
+	return func(o *nftokenAcceptOfferOptions) {
+		o.brokerFee = &fee
+	}
+}
+
+// BuildNFTokenAcceptOffer builds an NFTokenAcceptOffer transaction from
+// account accepting the NFToken offer sellOfferID or buyOfferID, identified
+// by their ledger object IDs, with the other one "". A broker gives both,
+// trading the token from the seller to the buyer, and may keep a fee with
+// NFTokenAcceptBrokerFee.
+func BuildNFTokenAcceptOffer(account, sellOfferID, buyOfferID string, opts ...NFTokenAcceptOfferOption) (map[string]interface{}, error) {
+	var options nftokenAcceptOfferOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if sellOfferID == "" && buyOfferID == "" {
+		return nil, fmt.Errorf("NFToken accept offer needs a sell offer, a buy offer or both")
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeNFTokenAcceptOffer),
+		"Account":         account,
+	}
+	for _, offer := range [][2]string{{"NFTokenSellOffer", sellOfferID}, {"NFTokenBuyOffer", buyOfferID}} {
+		field, id := offer[0], offer[1]
+		if id == "" {
+			continue
+		}
+		if b, err := hex.DecodeString(id); err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid %s %q: must be 32 bytes of hex", field, id)
+		}
+		tx[field] = strings.ToUpper(id)
+	}
+	if options.brokerFee != nil {
+		if sellOfferID == "" || buyOfferID == "" {
+			return nil, fmt.Errorf("NFToken broker fee requires both a sell and a buy offer")
+		}
+		if err := options.brokerFee.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid NFTokenBrokerFee: %w", err)
+		}
+		fee, err := options.brokerFee.Rat()
+		if err != nil || fee.Sign() <= 0 {
+			return nil, fmt.Errorf("NFTokenBrokerFee must be positive: %s", options.brokerFee)
+		}
+		tx["NFTokenBrokerFee"] = *options.brokerFee
+	}
+	return tx, nil
+}
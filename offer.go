@@ -0,0 +1,130 @@
+package xrpl
+
+import (
+	"fmt"
+	"time"
+)
+
+// OfferOption configures BuildOfferCreate
+type OfferOption func(*offerOptions)
+
+type offerOptions struct {
+	flags         uint32
+	expiration    time.Time
+	offerSequence *uint32
+}
+
+// OfferPassive does not consume offers that exactly match the new offer,
+// TfPassive, only those that cross it
+func OfferPassive() OfferOption {
+	return func(o *offerOptions) {
+		o.flags |= TfPassive
+	}
+}
+
+// OfferImmediateOrCancel trades what the order book can fill right away and
+// never places the remainder in the book, TfImmediateOrCancel
+func OfferImmediateOrCancel() OfferOption {
+	return func(o *offerOptions) {
+		o.flags |= TfImmediateOrCancel
+	}
+}
+
+// OfferFillOrKill trades only if the full TakerPays can be received right
+// away, without placing an offer in the book, TfFillOrKill
+func OfferFillOrKill() OfferOption {
+	return func(o *offerOptions) {
+		o.flags |= TfFillOrKill
+	}
+}
+
+// OfferSell spends all of TakerGets even when that receives more than
+// TakerPays, TfSell
+func OfferSell() OfferOption {
+	return func(o *offerOptions) {
+		o.flags |= TfSell
+	}
+}
+
+// OfferExpiration sets the time after which the offer is no longer active
+func OfferExpiration(t time.Time) OfferOption {
+	return func(o *offerOptions) {
+		o.expiration = t
+	}
+}
+
+// OfferReplace cancels the account's offer created by the transaction of
+// sequence number offerSequence, replacing it with the new offer
+func OfferReplace(offerSequence uint32) OfferOption {
+	return func(o *offerOptions) {
+		o.offerSequence = &offerSequence
+	}
+}
+
+// BuildOfferCreate builds an OfferCreate transaction from account, ready to
+// be autofilled and signed, offering to give takerGets in exchange for
+// takerPays. The amounts are named from the point of view of whoever takes
+// the offer, as in BookOffers.
+//
+// Example usage:
+//
+//	// Sell 100 XRP for at least 50 USD
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "50")
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildOfferCreate(
+//		wallet.ClassicAddress,
+//		xrpl.XRPAmount("100000000"),
+//		usd,
+//		xrpl.OfferSell(),
+//		xrpl.OfferExpiration(time.Now().Add(time.Hour)))
+func BuildOfferCreate(account string, takerGets, takerPays Amount, opts ...OfferOption) (map[string]interface{}, error) {
+	var options offerOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := takerGets.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid offer TakerGets: %w", err)
+	}
+	if err := takerPays.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid offer TakerPays: %w", err)
+	}
+	if takerGets.IsXRP() && takerPays.IsXRP() {
+		return nil, fmt.Errorf("offer cannot exchange XRP for XRP")
+	}
+	if options.flags&TfFillOrKill != 0 && options.flags&TfImmediateOrCancel != 0 {
+		return nil, fmt.Errorf("offer cannot be both FillOrKill and ImmediateOrCancel")
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeOfferCreate),
+		"Account":         account,
+		"TakerGets":       takerGets,
+		"TakerPays":       takerPays,
+	}
+	if options.flags != 0 {
+		tx["Flags"] = FlagsFor(TxTypeOfferCreate).Set(options.flags)
+	}
+	if !options.expiration.IsZero() {
+		expiration, err := TimeToRippleTime(options.expiration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid offer Expiration: %w", err)
+		}
+		tx["Expiration"] = expiration
+	}
+	if options.offerSequence != nil {
+		tx["OfferSequence"] = *options.offerSequence
+	}
+	return tx, nil
+}
+
+// BuildOfferCancel builds an OfferCancel transaction from account removing
+// its offer created by the transaction of sequence number offerSequence
+func BuildOfferCancel(account string, offerSequence uint32) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType": string(TxTypeOfferCancel),
+		"Account":         account,
+		"OfferSequence":   offerSequence,
+	}
+}
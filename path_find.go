@@ -0,0 +1,257 @@
+package xrpl
+
+import "sync"
+
+// PathStep is a step of a payment path: an account to ripple through, or a
+// currency and optionally an issuer to convert through in the order books.
+type PathStep struct {
+	Account  string `json:"account,omitempty"`
+	Currency string `json:"currency,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+}
+
+// Path is a sequence of steps from the source to the destination of a
+// payment, not including either end.
+type Path []PathStep
+
+// PaymentPath is an alternative found by ripple_path_find: the paths to
+// attach as the Paths field of a Payment, and the source amount the payment
+// would cost, which is typically used, with some slippage margin, as its
+// SendMax. Paths is empty when the payment can be made directly, as for XRP
+// sent to an account that accepts it.
+type PaymentPath struct {
+	Paths        []Path `json:"paths_computed"`
+	SourceAmount Amount `json:"source_amount"`
+}
+
+// PathFindOption configures a ripple_path_find request
+type PathFindOption func(req BaseRequest)
+
+// PathFindSourceCurrencies restricts the search to paths spending the given
+// currencies of the source account. Only the currencies and issuers of the
+// amounts are used, not their values; an empty issuer allows any issuer of
+// the currency. Rippled accepts at most 18 source currencies.
+func PathFindSourceCurrencies(currencies ...Amount) PathFindOption {
+	return func(req BaseRequest) {
+		sources := make([]map[string]interface{}, 0, len(currencies))
+		for _, currency := range currencies {
+			source := bookCurrency(currency)
+			if source["issuer"] == "" {
+				delete(source, "issuer")
+			}
+			sources = append(sources, source)
+		}
+		req["source_currencies"] = sources
+	}
+}
+
+// PathFindSendMax limits the paths to those costing at most sendMax, which
+// also searches for paths delivering as much as possible when destAmount is
+// "-1".
+func PathFindSendMax(sendMax Amount) PathFindOption {
+	return func(req BaseRequest) {
+		req["send_max"] = sendMax
+	}
+}
+
+// PathFindLedgerIndex selects the ledger to find paths in, see
+// LedgerSpecifier. The default is the latest validated ledger.
+func PathFindLedgerIndex(ledger LedgerSpecifier) PathFindOption {
+	return func(req BaseRequest) {
+		req["ledger_index"] = ledger
+	}
+}
+
+// Find the ways source can pay destAmount to destination. Each returned
+// alternative spends a different source currency. No alternatives and no
+// error are returned when no path exists.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "10")
+//	if err != nil {
+//		return err
+//	}
+//	alternatives, err := client.RipplePathFind(
+//		"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		usd,
+//		xrpl.PathFindSourceCurrencies(xrpl.XRPAmount("0")))
+//	if err != nil {
+//		return err
+//	}
+//	if len(alternatives) > 0 {
+//		tx["Paths"] = alternatives[0].Paths
+//		tx["SendMax"] = alternatives[0].SourceAmount
+//	}
+func (c *Client) RipplePathFind(source, destination string, destAmount Amount, opts ...PathFindOption) ([]PaymentPath, error) {
+	req := BaseRequest{
+		"command":             "ripple_path_find",
+		"source_account":      source,
+		"destination_account": destination,
+		"destination_amount":  destAmount,
+		"ledger_index":        "validated",
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	var result struct {
+		Alternatives []PaymentPath `json:"alternatives"`
+	}
+	if err := c.requestResult(req, &result); err != nil {
+		return nil, err
+	}
+	return paymentPaths(result.Alternatives), nil
+}
+
+// paymentPaths returns the alternatives of a path finding result, with empty
+// rather than nil Paths and no alternatives rather than nil
+func paymentPaths(alternatives []PaymentPath) []PaymentPath {
+	paths := make([]PaymentPath, 0, len(alternatives))
+	for _, alt := range alternatives {
+		if alt.Paths == nil {
+			alt.Paths = []Path{}
+		}
+		paths = append(paths, alt)
+	}
+	return paths
+}
+
+// pathFindSubscription is the path_find request opened by PathFindCreate.
+// The server keeps one per connection.
+type pathFindSubscription struct {
+	source      string
+	destination string
+	destAmount  Amount
+	handler     func([]PaymentPath)
+	stream      *subscriptionHandler // Queue of path_find stream messages
+	mutex       sync.Mutex           // Serializes handler calls
+	updated     bool                 // An update was delivered
+}
+
+func (p *pathFindSubscription) request(subcommand string) BaseRequest {
+	req := BaseRequest{
+		"command":    "path_find",
+		"subcommand": subcommand,
+	}
+	if subcommand == "create" {
+		req["source_account"] = p.source
+		req["destination_account"] = p.destination
+		req["destination_amount"] = p.destAmount
+	}
+	return req
+}
+
+// deliverInitial passes the alternatives of the create response to the
+// handler, unless an update already superseded them
+func (p *pathFindSubscription) deliverInitial(alternatives []PaymentPath) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.updated {
+		p.handler(paymentPaths(alternatives))
+	}
+	p.updated = true
+}
+
+// deliverUpdate passes the alternatives of an asynchronous path_find message
+// to the handler
+func (p *pathFindSubscription) deliverUpdate(m BaseResponse) {
+	var update struct {
+		Alternatives []PaymentPath `json:"alternatives"`
+	}
+	if err := remarshal(m, &update); err != nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.updated = true
+	p.handler(paymentPaths(update.Alternatives))
+}
+
+// PathFindCreate opens a path_find request for the ways source can pay
+// destAmount to destination, which the server keeps updating as ledgers
+// close. The handler receives the alternatives of the initial response
+// first, then those of every update, one call at a time, on a worker
+// goroutine queueing updates as described by WithStreamBuffer. The request
+// is reopened when the client reconnects, and closed by calling closeFn. The
+// server keeps one path_find request per connection, so opening another one
+// replaces it, after which calling the replaced request's closeFn has no
+// effect.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "10")
+//	if err != nil {
+//		return err
+//	}
+//	closeFn, err := client.PathFindCreate(
+//		"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		usd,
+//		func(alternatives []xrpl.PaymentPath) {
+//			for _, alt := range alternatives {
+//				fmt.Println(alt.SourceAmount)
+//			}
+//		})
+//	if err != nil {
+//		return err
+//	}
+//	defer closeFn()
+func (c *Client) PathFindCreate(source, destination string, destAmount Amount, handler func([]PaymentPath)) (closeFn func() error, err error) {
+	sub := &pathFindSubscription{
+		source:      source,
+		destination: destination,
+		destAmount:  destAmount,
+		handler:     handler,
+	}
+	sub.stream = c.newSubscriptionHandler(sub.deliverUpdate)
+	c.mutex.Lock()
+	c.pathFind = sub
+	c.mutex.Unlock()
+
+	var result struct {
+		Alternatives []PaymentPath `json:"alternatives"`
+	}
+	if err := c.requestResult(sub.request("create"), &result); err != nil {
+		c.mutex.Lock()
+		if c.pathFind == sub {
+			c.pathFind = nil
+		}
+		c.mutex.Unlock()
+		return nil, err
+	}
+	sub.deliverInitial(result.Alternatives)
+
+	return func() error {
+		c.mutex.Lock()
+		if c.pathFind != sub {
+			c.mutex.Unlock()
+			return nil
+		}
+		c.pathFind = nil
+		c.mutex.Unlock()
+		_, err := c.RequestChecked(sub.request("close"))
+		return err
+	}, nil
+}
+
+// reopenPathFind opens the path_find request of PathFindCreate again after a
+// reconnect
+func (c *Client) reopenPathFind() {
+	c.mutex.Lock()
+	sub := c.pathFind
+	c.mutex.Unlock()
+	if sub == nil {
+		return
+	}
+
+	var result struct {
+		Alternatives []PaymentPath `json:"alternatives"`
+	}
+	if err := c.requestResult(sub.request("create"), &result); err != nil {
+		c.logger.Errorf("WS path_find reopen error: %s", err)
+		return
+	}
+	sub.deliverUpdate(BaseResponse{"alternatives": result.Alternatives})
+}
@@ -0,0 +1,216 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrDestinationTagRequired is returned by BuildPayment with
+// PaymentEnforceDestinationTag for a payment without a DestinationTag to an
+// account that requires one
+var ErrDestinationTagRequired = errors.New("destination requires a DestinationTag")
+
+// DestinationTagChecker tells whether an account requires a DestinationTag
+// on incoming payments. *Client is one.
+type DestinationTagChecker interface {
+	RequiresDestinationTag(account string) (bool, error)
+}
+
+// PaymentOption configures BuildPayment
+type PaymentOption func(*paymentOptions)
+
+type paymentOptions struct {
+	destinationTag *uint32
+	sourceTag      *uint32
+	invoiceID      string
+	sendMax        Amount
+	deliverMin     Amount
+	paths          []Path
+	flags          uint32
+	nameResolver   NameResolver
+	tagChecker     DestinationTagChecker
+}
+
+// PaymentDestinationTag sets the tag identifying the recipient at the
+// destination, e.g. a customer of an exchange
+func PaymentDestinationTag(tag uint32) PaymentOption {
+	return func(o *paymentOptions) {
+		o.destinationTag = &tag
+	}
+}
+
+// PaymentSourceTag sets the tag identifying the sender on whose behalf the
+// payment is made
+func PaymentSourceTag(tag uint32) PaymentOption {
+	return func(o *paymentOptions) {
+		o.sourceTag = &tag
+	}
+}
+
+// PaymentInvoiceID sets the 32 byte hex identifier, e.g. a hash, of what the
+// payment is for
+func PaymentInvoiceID(invoiceIDHex string) PaymentOption {
+	return func(o *paymentOptions) {
+		o.invoiceID = invoiceIDHex
+	}
+}
+
+// PaymentSendMax sets the most the sender is willing to spend, including
+// transfer fees and exchange rates, for cross-currency payments
+func PaymentSendMax(sendMax Amount) PaymentOption {
+	return func(o *paymentOptions) {
+		o.sendMax = sendMax
+	}
+}
+
+// PaymentDeliverMin sets the least a partial payment must deliver to
+// succeed. It requires PaymentPartial.
+func PaymentDeliverMin(deliverMin Amount) PaymentOption {
+	return func(o *paymentOptions) {
+		o.deliverMin = deliverMin
+	}
+}
+
+// PaymentPaths sets the paths the payment may take, as found by
+// RipplePathFind
+func PaymentPaths(paths []Path) PaymentOption {
+	return func(o *paymentOptions) {
+		o.paths = paths
+	}
+}
+
+// PaymentPartial makes a partial payment, TfPartialPayment, which delivers
+// what SendMax buys rather than failing when amount cannot be delivered in
+// full. It requires PaymentSendMax. Recipients must read the delivered
+// amount from the metadata rather than Amount.
+func PaymentPartial() PaymentOption {
+	return func(o *paymentOptions) {
+		o.flags |= TfPartialPayment
+	}
+}
+
+// PaymentNameResolver resolves a destination given as a name, such as
+// "name:alice", with resolver, see ResolveDestination
+func PaymentNameResolver(resolver NameResolver) PaymentOption {
+	return func(o *paymentOptions) {
+		o.nameResolver = resolver
+	}
+}
+
+// PaymentEnforceDestinationTag looks up whether the destination requires a
+// DestinationTag with checker, usually the client, and fails with
+// ErrDestinationTagRequired if it does but the payment has none. Exchanges
+// commonly require tags on their deposit addresses to tell customers apart.
+func PaymentEnforceDestinationTag(checker DestinationTagChecker) PaymentOption {
+	return func(o *paymentOptions) {
+		o.tagChecker = checker
+	}
+}
+
+// BuildPayment builds a Payment transaction of amount from from to to, ready
+// to be autofilled and signed. Payments in another currency than the sender
+// spends need PaymentSendMax, and usually PaymentPaths.
+//
+// The destination to is a classic address, an X-address, whose tag becomes
+// the DestinationTag, or a name resolved with PaymentNameResolver, in that
+// order of precedence; see ResolveDestination. A tag it resolves to must
+// agree with PaymentDestinationTag.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildPayment(
+//		wallet.ClassicAddress,
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		xrpl.XRPAmount("1000000"),
+//		xrpl.PaymentDestinationTag(12345))
+//	if err != nil {
+//		return err
+//	}
+//	res, err := client.SignAndSubmitRequest(tx, seed)
+func BuildPayment(from, to string, amount Amount, opts ...PaymentOption) (map[string]interface{}, error) {
+	var options paymentOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := amount.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid payment Amount: %w", err)
+	}
+	destination, tag, err := ResolveDestination(to, options.nameResolver)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment Destination: %w", err)
+	}
+	if options.destinationTag, err = mergeDestinationTags(options.destinationTag, tag); err != nil {
+		return nil, fmt.Errorf("invalid payment Destination %s: %w", to, err)
+	}
+	hasSendMax := options.sendMax != (Amount{})
+	hasDeliverMin := options.deliverMin != (Amount{})
+	if hasSendMax {
+		if err := options.sendMax.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid payment SendMax: %w", err)
+		}
+	}
+	if hasDeliverMin {
+		if err := options.deliverMin.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid payment DeliverMin: %w", err)
+		}
+	}
+	partial := options.flags&TfPartialPayment != 0
+	if partial && !hasSendMax {
+		return nil, fmt.Errorf("partial payment needs a SendMax")
+	}
+	if hasDeliverMin && !partial {
+		return nil, fmt.Errorf("payment DeliverMin requires a partial payment")
+	}
+	if amount.IsXRP() && (!hasSendMax || options.sendMax.IsXRP()) {
+		if hasSendMax {
+			return nil, fmt.Errorf("XRP to XRP payment cannot have a SendMax")
+		}
+		if len(options.paths) > 0 {
+			return nil, fmt.Errorf("XRP to XRP payment cannot have Paths")
+		}
+	}
+
+	if options.tagChecker != nil && options.destinationTag == nil {
+		required, err := options.tagChecker.RequiresDestinationTag(destination)
+		if err != nil {
+			return nil, fmt.Errorf("cannot tell whether payment Destination %s requires a tag: %w", destination, err)
+		}
+		if required {
+			return nil, fmt.Errorf("payment to %s: %w", destination, ErrDestinationTagRequired)
+		}
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypePayment),
+		"Account":         from,
+		"Destination":     destination,
+		"Amount":          amount,
+	}
+	if options.flags != 0 {
+		tx["Flags"] = FlagsFor(TxTypePayment).Set(options.flags)
+	}
+	if options.destinationTag != nil {
+		tx["DestinationTag"] = *options.destinationTag
+	}
+	if options.sourceTag != nil {
+		tx["SourceTag"] = *options.sourceTag
+	}
+	if options.invoiceID != "" {
+		if b, err := hex.DecodeString(options.invoiceID); err != nil || len(b) != 32 {
+			return nil, fmt.Errorf("invalid payment InvoiceID %q: must be 32 bytes of hex", options.invoiceID)
+		}
+		tx["InvoiceID"] = strings.ToUpper(options.invoiceID)
+	}
+	if hasSendMax {
+		tx["SendMax"] = options.sendMax
+	}
+	if hasDeliverMin {
+		tx["DeliverMin"] = options.deliverMin
+	}
+	if len(options.paths) > 0 {
+		tx["Paths"] = options.paths
+	}
+	return tx, nil
+}
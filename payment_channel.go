@@ -0,0 +1,316 @@
+package xrpl
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentChannelCreateOption configures BuildPaymentChannelCreate
+type PaymentChannelCreateOption func(*paymentChannelCreateOptions)
+
+type paymentChannelCreateOptions struct {
+	cancelAfter    time.Time
+	destinationTag *uint32
+	sourceTag      *uint32
+}
+
+// ChannelCancelAfter sets the time after which the channel expires, no
+// matter whether claims against it were made
+func ChannelCancelAfter(t time.Time) PaymentChannelCreateOption {
+	return func(o *paymentChannelCreateOptions) {
+		o.cancelAfter = t
+	}
+}
+
+// ChannelDestinationTag sets the tag identifying the recipient at the
+// destination, e.g. a customer of an exchange
+func ChannelDestinationTag(tag uint32) PaymentChannelCreateOption {
+	return func(o *paymentChannelCreateOptions) {
+		o.destinationTag = &tag
+	}
+}
+
+// ChannelSourceTag sets the tag identifying the sender on whose behalf the
+// channel is created
+func ChannelSourceTag(tag uint32) PaymentChannelCreateOption {
+	return func(o *paymentChannelCreateOptions) {
+		o.sourceTag = &tag
+	}
+}
+
+// BuildPaymentChannelCreate builds a PaymentChannelCreate transaction,
+// ready to be autofilled and signed, opening a channel from account to
+// destination funded with amount of XRP. Claims against the channel are
+// signed with the key publicKeyHex, given as in SigningPubKey, and the
+// sender has to wait settleDelay seconds for the destination to redeem
+// outstanding claims before closing a funded channel.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildPaymentChannelCreate(
+//		wallet.ClassicAddress,
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		xrpl.XRPAmount("10000000"),
+//		86400,
+//		wallet.PublicKey)
+func BuildPaymentChannelCreate(account, destination string, amount Amount, settleDelay uint32, publicKeyHex string, opts ...PaymentChannelCreateOption) (map[string]interface{}, error) {
+	var options paymentChannelCreateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := validateChannelAmount(amount); err != nil {
+		return nil, err
+	}
+	if _, err := hex.DecodeString(publicKeyHex); err != nil || len(publicKeyHex) != 66 {
+		return nil, fmt.Errorf("invalid channel PublicKey %q: must be 33 bytes of hex", publicKeyHex)
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypePaymentChannelCreate),
+		"Account":         account,
+		"Destination":     destination,
+		"Amount":          amount,
+		"SettleDelay":     settleDelay,
+		"PublicKey":       strings.ToUpper(publicKeyHex),
+	}
+	if !options.cancelAfter.IsZero() {
+		cancelAfter, err := TimeToRippleTime(options.cancelAfter)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel CancelAfter: %w", err)
+		}
+		tx["CancelAfter"] = cancelAfter
+	}
+	if options.destinationTag != nil {
+		tx["DestinationTag"] = *options.destinationTag
+	}
+	if options.sourceTag != nil {
+		tx["SourceTag"] = *options.sourceTag
+	}
+	return tx, nil
+}
+
+// PaymentChannelFundOption configures BuildPaymentChannelFund
+type PaymentChannelFundOption func(*paymentChannelFundOptions)
+
+type paymentChannelFundOptions struct {
+	expiration time.Time
+}
+
+// ChannelExpiration sets a new expiration time of the channel. It must be
+// at least the channel's settle delay from now.
+func ChannelExpiration(t time.Time) PaymentChannelFundOption {
+	return func(o *paymentChannelFundOptions) {
+		o.expiration = t
+	}
+}
+
+// BuildPaymentChannelFund builds a PaymentChannelFund transaction adding
+// amount of XRP to the channel channelID, which account owns
+func BuildPaymentChannelFund(account, channelID string, amount Amount, opts ...PaymentChannelFundOption) (map[string]interface{}, error) {
+	var options paymentChannelFundOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := validateChannelAmount(amount); err != nil {
+		return nil, err
+	}
+	if _, err := decodeChannelID(channelID); err != nil {
+		return nil, err
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypePaymentChannelFund),
+		"Account":         account,
+		"Channel":         strings.ToUpper(channelID),
+		"Amount":          amount,
+	}
+	if !options.expiration.IsZero() {
+		expiration, err := TimeToRippleTime(options.expiration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid channel Expiration: %w", err)
+		}
+		tx["Expiration"] = expiration
+	}
+	return tx, nil
+}
+
+// PaymentChannelClaimOption configures BuildPaymentChannelClaim
+type PaymentChannelClaimOption func(*paymentChannelClaimOptions)
+
+type paymentChannelClaimOptions struct {
+	balance      string
+	amount       string
+	signature    string
+	publicKeyHex string
+	flags        uint32
+}
+
+// ChannelClaimBalance sets the total amount of XRP, in drops, delivered by
+// the channel after the claim
+func ChannelClaimBalance(drops string) PaymentChannelClaimOption {
+	return func(o *paymentChannelClaimOptions) {
+		o.balance = drops
+	}
+}
+
+// ChannelClaimSignature redeems a claim of amount drops signed by the
+// channel's key publicKeyHex, as produced by SignChannelClaim. The claim's
+// Balance defaults to amount.
+func ChannelClaimSignature(amount, signatureHex, publicKeyHex string) PaymentChannelClaimOption {
+	return func(o *paymentChannelClaimOptions) {
+		o.amount = amount
+		o.signature = signatureHex
+		o.publicKeyHex = publicKeyHex
+	}
+}
+
+// ChannelClaimClose requests closing the channel, TfClose
+func ChannelClaimClose() PaymentChannelClaimOption {
+	return func(o *paymentChannelClaimOptions) {
+		o.flags |= TfClose
+	}
+}
+
+// ChannelClaimRenew clears the channel's expiration, TfRenew. Only the
+// channel's owner can renew it.
+func ChannelClaimRenew() PaymentChannelClaimOption {
+	return func(o *paymentChannelClaimOptions) {
+		o.flags |= TfRenew
+	}
+}
+
+// BuildPaymentChannelClaim builds a PaymentChannelClaim transaction sent by
+// account for the channel channelID. The destination redeems signed claims
+// with ChannelClaimSignature; the owner closes or renews the channel, or
+// delivers XRP with ChannelClaimBalance and no signature. The signature is
+// verified before the transaction is returned.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildPaymentChannelClaim(
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		channelID,
+//		xrpl.ChannelClaimSignature("1000000", signature, ownerPublicKey))
+func BuildPaymentChannelClaim(account, channelID string, opts ...PaymentChannelClaimOption) (map[string]interface{}, error) {
+	var options paymentChannelClaimOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if _, err := decodeChannelID(channelID); err != nil {
+		return nil, err
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypePaymentChannelClaim),
+		"Account":         account,
+		"Channel":         strings.ToUpper(channelID),
+	}
+	if options.flags != 0 {
+		tx["Flags"] = FlagsFor(TxTypePaymentChannelClaim).Set(options.flags)
+	}
+	if options.signature != "" {
+		ok, err := VerifyChannelClaim(channelID, options.amount, options.signature, options.publicKeyHex)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, fmt.Errorf("channel claim signature does not match the claim of %s drops", options.amount)
+		}
+		tx["Amount"] = options.amount
+		tx["Signature"] = strings.ToUpper(options.signature)
+		tx["PublicKey"] = strings.ToUpper(options.publicKeyHex)
+		if options.balance == "" {
+			options.balance = options.amount
+		}
+	}
+	if options.balance != "" {
+		if _, err := parseChannelDrops(options.balance); err != nil {
+			return nil, err
+		}
+		tx["Balance"] = options.balance
+	}
+	return tx, nil
+}
+
+// SignChannelClaim signs an off-ledger claim of amount drops, the total the
+// destination may redeem from the channel channelID, with the key of the
+// family seed. The signature is made over 'CLM\0', the 32 byte channel ID and
+// the amount as 64 bit big-endian integer, and is what rippled's
+// channel_authorize returns, without sending the seed to a server.
+//
+// Example usage:
+//
+//	signature, err := xrpl.SignChannelClaim(channelID, "1000000", seed)
+//	if err != nil {
+//		return err
+//	}
+//	// hand the channel ID, amount and signature to the destination
+func SignChannelClaim(channelID string, amount string, seed string) (signatureHex string, err error) {
+	message, err := channelClaimMessage(channelID, amount)
+	if err != nil {
+		return "", err
+	}
+	privateKey, keyType, err := DecodeSeed(seed)
+	if err != nil {
+		return "", err
+	}
+	signature := signWithKey(privateKey, keyType, message)
+	return strings.ToUpper(hex.EncodeToString(signature)), nil
+}
+
+// VerifyChannelClaim checks that signatureHex is a claim of amount drops
+// from the channel channelID signed by the channel's key publicKeyHex, as
+// rippled's channel_verify does. A well-formed signature that does not match
+// reports false.
+func VerifyChannelClaim(channelID string, amount string, signatureHex string, publicKeyHex string) (bool, error) {
+	message, err := channelClaimMessage(channelID, amount)
+	if err != nil {
+		return false, err
+	}
+	return Verify(message, signatureHex, publicKeyHex)
+}
+
+// channelClaimMessage returns the message signed by a payment channel claim
+func channelClaimMessage(channelID string, amount string) ([]byte, error) {
+	channel, err := decodeChannelID(channelID)
+	if err != nil {
+		return nil, err
+	}
+	drops, err := parseChannelDrops(amount)
+	if err != nil {
+		return nil, err
+	}
+	message := uint32Bytes(HashPrefixPaymentChannelClaim)
+	message = append(message, channel...)
+	return binary.BigEndian.AppendUint64(message, drops), nil
+}
+
+func decodeChannelID(channelID string) ([]byte, error) {
+	channel, err := hex.DecodeString(channelID)
+	if err != nil || len(channel) != 32 {
+		return nil, fmt.Errorf("invalid channel ID %q: must be 32 bytes of hex", channelID)
+	}
+	return channel, nil
+}
+
+func parseChannelDrops(drops string) (uint64, error) {
+	n, err := strconv.ParseUint(drops, 10, 64)
+	if err != nil || n > MaxDrops {
+		return 0, fmt.Errorf("invalid channel amount %q: must be an amount of drops", drops)
+	}
+	return n, nil
+}
+
+func validateChannelAmount(amount Amount) error {
+	if !amount.IsXRP() {
+		return fmt.Errorf("payment channels hold XRP only, got %s", amount)
+	}
+	if err := amount.Validate(); err != nil {
+		return fmt.Errorf("invalid channel amount: %w", err)
+	}
+	return nil
+}
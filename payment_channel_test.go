@@ -0,0 +1,64 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+const testChannelID = "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"
+
+func TestSignChannelClaim(t *testing.T) {
+	tests := []struct {
+		seed      string
+		signature string
+	}{
+		// authorizeChannel vector of xrpl.js
+		{"snGHNrPbHrdUcszeuDEigMdC1Lyyd", "304402204E7052F33DDAFAAA55C9F5B132A5E50EE95B2CF68C0902F61DFE77299BC893740220353640B951DCD24371C16868B3F91B78D38B6F3FD1E826413CDF891FA8250AAC"},
+		// ed25519 signatures are deterministic as well
+		{"sEdSuqBPSQaood2DmNYVkwWTn1oQTj2", "7E1C217A3E4B3C107B7A356E665088B4FBA6464C48C58267BEF64975E3375EA338AE22E6714E3F5E734AE33E6B97AAD59058E1E196C1F92346FC1498D0674404"},
+	}
+	for _, test := range tests {
+		signature, err := SignChannelClaim(testChannelID, "1000000", test.seed)
+		if err != nil {
+			t.Errorf("%s: %v", test.seed, err)
+			continue
+		}
+		if signature != test.signature {
+			t.Errorf("%s: got %s, want %s", test.seed, signature, test.signature)
+		}
+
+		wallet, err := WalletFromSeed(test.seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := VerifyChannelClaim(testChannelID, "1000000", signature, wallet.PublicKey); err != nil || !ok {
+			t.Errorf("%s: verifying own claim: got %v, %v", test.seed, ok, err)
+		}
+		if ok, err := VerifyChannelClaim(testChannelID, "1000001", signature, wallet.PublicKey); err != nil || ok {
+			t.Errorf("%s: verifying claim of another amount: got %v, %v", test.seed, ok, err)
+		}
+	}
+}
+
+func TestVerifyChannelClaim(t *testing.T) {
+	// channel_verify example of the rippled documentation, whose public key
+	// aB44YfzW24VDEJQ2UuLPV2PvqcPCSoLnL7y5M1EzhdW4LnK5xMS3 is given in base58
+	_, publicKey, err := NewBase58().DecodeCheck("aB44YfzW24VDEJQ2UuLPV2PvqcPCSoLnL7y5M1EzhdW4LnK5xMS3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	const signature = "304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064"
+	if ok, err := VerifyChannelClaim(testChannelID, "1000000", signature, hex.EncodeToString(publicKey)); err != nil || !ok {
+		t.Errorf("got %v, %v, want a valid claim", ok, err)
+	}
+
+	for _, test := range []struct{ channelID, amount string }{
+		{testChannelID[:62], "1000000"},
+		{testChannelID, "1.5"},
+		{testChannelID, "100000000000000001"},
+	} {
+		if _, err := VerifyChannelClaim(test.channelID, test.amount, signature, hex.EncodeToString(publicKey)); err == nil {
+			t.Errorf("channel %s, amount %s: no error", test.channelID, test.amount)
+		}
+	}
+}
@@ -0,0 +1,393 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Requester sends requests to an XRPL server. Client and Pool implement it,
+// so code written against Requester works with a single connection as well
+// as with a pool of servers.
+type Requester interface {
+	Request(req BaseRequest) (BaseResponse, error)
+	RequestCtx(ctx context.Context, req BaseRequest) (BaseResponse, error)
+}
+
+var (
+	_ Requester = (*Client)(nil)
+	_ Requester = (*Pool)(nil)
+)
+
+// ErrNoHealthyServer is returned by Pool when none of its servers passed the
+// last health check.
+var ErrNoHealthyServer = errors.New("no healthy server available in pool")
+
+// PoolOption configures a Pool
+type PoolOption func(*poolOptions)
+
+type poolOptions struct {
+	config        ClientConfig
+	probeInterval time.Duration
+	probeTimeout  time.Duration
+	clientOptions []ClientOption
+}
+
+// PoolClientConfig sets the configuration of the pool's clients. Its URL is
+// replaced by each of the pool's server URLs.
+func PoolClientConfig(config ClientConfig) PoolOption {
+	return func(o *poolOptions) {
+		o.config = config
+	}
+}
+
+// PoolClientOptions sets the options of the pool's clients, e.g. WithLogger.
+// The pool logs its own failovers with the clients' logger.
+func PoolClientOptions(opts ...ClientOption) PoolOption {
+	return func(o *poolOptions) {
+		o.clientOptions = append(o.clientOptions, opts...)
+	}
+}
+
+// PoolProbeInterval sets how often the health of every server is checked.
+// Default is 10 seconds.
+func PoolProbeInterval(interval time.Duration) PoolOption {
+	return func(o *poolOptions) {
+		o.probeInterval = interval
+	}
+}
+
+// PoolProbeTimeout bounds each health check. Default is 5 seconds.
+func PoolProbeTimeout(timeout time.Duration) PoolOption {
+	return func(o *poolOptions) {
+		o.probeTimeout = timeout
+	}
+}
+
+// Pool routes requests to one of several rippled servers. One healthy server
+// is active at a time and receives every request; when a request to it fails
+// with a connection error, or a health check finds it unhealthy, the pool
+// fails over to the next healthy server. A server is healthy when its
+// ServerInfo reports IsHealthy. All servers are re-probed periodically, so
+// failed servers rejoin the pool once they recover.
+type Pool struct {
+	clients       []*Client
+	options       poolOptions
+	mutex         sync.Mutex
+	switching     sync.Mutex // Serializes changes of the active server
+	healthy       []bool
+	active        int // Index of the active client, or -1
+	subscriptions []poolSubscription
+	closed        bool
+	done          chan struct{}
+	logger        Logger
+}
+
+type poolSubscription struct {
+	streams []string
+	handler func(BaseResponse)
+}
+
+// NewPool creates a client for each of urls and starts health checking them.
+// It panics when urls is empty or the client configuration is invalid, like
+// NewClient.
+//
+// A Pool implements Requester and stream subscriptions, not the whole
+// ClientInterface of Client: the typed helpers such as AccountInfo are not
+// available on it. Send their commands with Request and decode the result.
+//
+// Example usage:
+//
+//	pool := xrpl.NewPool([]string{
+//		"wss://xrplcluster.com",
+//		"wss://s1.ripple.com",
+//	})
+//	defer pool.Close()
+//	res, err := pool.Request(xrpl.BaseRequest{"command": "server_info"})
+func NewPool(urls []string, opts ...PoolOption) *Pool {
+	if len(urls) == 0 {
+		panic(errors.New("cannot create a pool without server URLs"))
+	}
+	options := poolOptions{
+		probeInterval: 10 * time.Second,
+		probeTimeout:  5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	p := &Pool{
+		options: options,
+		healthy: make([]bool, len(urls)),
+		active:  -1,
+		done:    make(chan struct{}),
+	}
+	for _, url := range urls {
+		config := options.config
+		config.URL = url
+		p.clients = append(p.clients, NewClient(config, options.clientOptions...))
+	}
+	p.logger = p.clients[0].logger
+
+	p.probe()
+	go p.probeLoop()
+	return p
+}
+
+// Send a request to the active server, as with Client.Request
+func (p *Pool) Request(req BaseRequest) (BaseResponse, error) {
+	return p.RequestCtx(context.Background(), req)
+}
+
+// Send a request to the active server and wait for its response until ctx is
+// done, as with Client.RequestCtx. When the request fails with a connection
+// error, the server is marked unhealthy and the request is retried on the
+// next healthy server, trying each server at most once. Other errors, such as
+// ErrRateLimited or ErrInvalidRequest, are returned as they are and leave the
+// server active.
+func (p *Pool) RequestCtx(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	var lastErr error
+	for attempt := 0; attempt < len(p.clients); attempt++ {
+		c, i, err := p.activeClient()
+		if err != nil {
+			if lastErr != nil {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		res, err := c.RequestCtx(ctx, req)
+		if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+			return res, err
+		}
+		c.logger.Errorf("Pool request error: %s %s", c.config.URL, err)
+		lastErr = err
+		p.setHealthy(i, false)
+	}
+	return nil, lastErr
+}
+
+// isConnectionError reports whether a request failed because of the server
+// or the connection to it, rather than a local limit or an invalid request
+// that would fail on any server
+func isConnectionError(err error) bool {
+	return !errors.Is(err, ErrRateLimited) &&
+		!errors.Is(err, ErrInvalidRequest) &&
+		!errors.Is(err, ErrClientClosed)
+}
+
+// SubscribeWithHandler subscribes to streams on the active server and routes
+// their messages to handler, as with Client.SubscribeWithHandler. The
+// subscription follows the pool on failover: it is re-established on every
+// server that becomes active. If no server is healthy yet, the subscription
+// is established once one is, and ErrNoHealthyServer is returned.
+func (p *Pool) SubscribeWithHandler(streams []string, handler func(msg BaseResponse)) (BaseResponse, error) {
+	p.switching.Lock()
+	defer p.switching.Unlock()
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil, ErrClientClosed
+	}
+	p.subscriptions = append(p.subscriptions, poolSubscription{streams: streams, handler: handler})
+	active := p.active
+	p.mutex.Unlock()
+
+	if active < 0 {
+		return nil, ErrNoHealthyServer
+	}
+	return p.clients[active].SubscribeWithHandler(streams, handler)
+}
+
+// Unsubscribe removes the pool's subscriptions to streams and unsubscribes
+// them on the active server.
+func (p *Pool) Unsubscribe(streams []string) (BaseResponse, error) {
+	p.switching.Lock()
+	defer p.switching.Unlock()
+
+	remove := make(map[string]bool, len(streams))
+	for _, stream := range streams {
+		remove[stream] = true
+	}
+	p.mutex.Lock()
+	var kept []poolSubscription
+	for _, sub := range p.subscriptions {
+		var remaining []string
+		for _, stream := range sub.streams {
+			if !remove[stream] {
+				remaining = append(remaining, stream)
+			}
+		}
+		if len(remaining) > 0 {
+			kept = append(kept, poolSubscription{streams: remaining, handler: sub.handler})
+		}
+	}
+	p.subscriptions = kept
+	active := p.active
+	p.mutex.Unlock()
+
+	if active < 0 {
+		return nil, ErrNoHealthyServer
+	}
+	return p.clients[active].Unsubscribe(streams)
+}
+
+// Close stops health checking and closes the clients of all servers
+func (p *Pool) Close() error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.done)
+	p.mutex.Unlock()
+
+	var firstErr error
+	for _, c := range p.clients {
+		if err := c.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// activeClient returns the active client, failing over first if the active
+// server is missing or unhealthy.
+func (p *Pool) activeClient() (*Client, int, error) {
+	for retried := false; ; retried = true {
+		p.mutex.Lock()
+		closed, active := p.closed, p.active
+		ok := active >= 0 && p.healthy[active]
+		p.mutex.Unlock()
+
+		switch {
+		case closed:
+			return nil, -1, ErrClientClosed
+		case ok:
+			return p.clients[active], active, nil
+		case retried:
+			return nil, -1, ErrNoHealthyServer
+		}
+		p.failover()
+	}
+}
+
+func (p *Pool) setHealthy(i int, healthy bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.healthy[i] = healthy
+}
+
+func (p *Pool) probeLoop() {
+	ticker := time.NewTicker(p.options.probeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.probe()
+		}
+	}
+}
+
+// probe checks the health of every server concurrently and fails over when
+// the active server is no longer healthy.
+func (p *Pool) probe() {
+	var wg sync.WaitGroup
+	for i, c := range p.clients {
+		wg.Add(1)
+		go func(i int, c *Client) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), p.options.probeTimeout)
+			defer cancel()
+			info, err := c.serverInfoCtx(ctx)
+			p.setHealthy(i, err == nil && info.IsHealthy())
+		}(i, c)
+	}
+	wg.Wait()
+
+	p.mutex.Lock()
+	healthy := p.active >= 0 && p.healthy[p.active]
+	p.mutex.Unlock()
+	if !healthy {
+		p.failover()
+	}
+}
+
+// failover makes the first healthy server after the current one active. The
+// pool's subscriptions are released on the previous server and established
+// on the new one, whose own reconnect logic then keeps them alive.
+func (p *Pool) failover() {
+	p.switching.Lock()
+	defer p.switching.Unlock()
+
+	p.mutex.Lock()
+	previous := p.active
+	if p.closed || previous >= 0 && p.healthy[previous] {
+		// Closed, or another goroutine failed over already
+		p.mutex.Unlock()
+		return
+	}
+	next := -1
+	for k := 1; k <= len(p.clients); k++ {
+		if i := (previous + k) % len(p.clients); p.healthy[i] {
+			next = i
+			break
+		}
+	}
+	p.active = next
+	subscriptions := append([]poolSubscription{}, p.subscriptions...)
+	p.mutex.Unlock()
+
+	if previous == next {
+		return
+	}
+	if previous >= 0 {
+		p.logger.Warnf("Pool failover from: %s", p.clients[previous].config.URL)
+		p.releaseSubscriptions(p.clients[previous], subscriptions)
+	}
+	if next < 0 {
+		p.logger.Errorf("Pool has no healthy server")
+		return
+	}
+	for _, sub := range subscriptions {
+		if _, err := p.clients[next].SubscribeWithHandler(sub.streams, sub.handler); err != nil {
+			p.logger.Errorf("Pool stream subscription error: %s %s", p.clients[next].config.URL, err)
+		}
+	}
+}
+
+// releaseSubscriptions unsubscribes the pool's streams on a server that is no
+// longer active, so it stops delivering to the pool's handlers and does not
+// re-subscribe them when it reconnects.
+func (p *Pool) releaseSubscriptions(c *Client, subscriptions []poolSubscription) {
+	var streams []string
+	for _, sub := range subscriptions {
+		streams = append(streams, sub.streams...)
+	}
+	if len(streams) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.options.probeTimeout)
+	defer cancel()
+	req := BaseRequest{
+		"command": "unsubscribe",
+		"streams": streams,
+	}
+	if _, err := c.RequestCtx(ctx, req); err != nil {
+		c.logger.Errorf("Pool stream unsubscription error: %s %s", c.config.URL, err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, stream := range streams {
+		delete(c.streamSubscriptions, stream)
+		delete(c.channelSubscriptions, stream)
+		delete(c.streamHandlers, stream)
+	}
+}
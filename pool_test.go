@@ -0,0 +1,73 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newTestPool returns a pool of two servers answered by first and second,
+// with the first one active and both healthy. It is not health checked.
+func newTestPool(first, second Transport, opts ...ClientOption) *Pool {
+	opts = append([]ClientOption{WithLogger(NopLogger())}, opts...)
+	return &Pool{
+		clients: []*Client{
+			NewClient(ClientConfig{URL: "custom://first", Transport: first}, opts...),
+			NewClient(ClientConfig{URL: "custom://second", Transport: second}, opts...),
+		},
+		healthy: []bool{true, true},
+		active:  0,
+		done:    make(chan struct{}),
+		logger:  NopLogger(),
+	}
+}
+
+func successTransport(server string) Transport {
+	return transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+		return BaseResponse{"status": "success", "result": map[string]interface{}{"server": server}}, nil
+	})
+}
+
+func TestPoolFailsOverOnConnectionError(t *testing.T) {
+	down := transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+		return nil, ErrDisconnected
+	})
+	pool := newTestPool(down, successTransport("second"))
+
+	res, err := pool.Request(BaseRequest{"command": "server_info"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result, _ := res["result"].(map[string]interface{}); result["server"] != "second" {
+		t.Errorf("response %v, want the second server's", res)
+	}
+	if pool.active != 1 || pool.healthy[0] {
+		t.Errorf("active server %d, healthy %v, want the second server active", pool.active, pool.healthy)
+	}
+}
+
+func TestPoolKeepsServerOnRequestError(t *testing.T) {
+	invalid := transportFunc(func(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+		return nil, ErrInvalidRequest
+	})
+	pool := newTestPool(invalid, successTransport("second"))
+	if _, err := pool.Request(BaseRequest{"command": "server_info"}); !errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("invalid request: %v, want ErrInvalidRequest", err)
+	}
+	if pool.active != 0 || !pool.healthy[0] {
+		t.Errorf("invalid request: active server %d, healthy %v, want the first server active", pool.active, pool.healthy)
+	}
+
+	// The first request takes the only token, the second is rejected
+	pool = newTestPool(successTransport("first"), successTransport("second"),
+		WithRateLimit(1, 1), WithRateLimitMode(RateLimitReject))
+	if _, err := pool.Request(BaseRequest{"command": "server_info"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Request(BaseRequest{"command": "server_info"}); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("throttled request: %v, want ErrRateLimited", err)
+	}
+	if pool.active != 0 || !pool.healthy[0] {
+		t.Errorf("throttled request: active server %d, healthy %v, want the first server active", pool.active, pool.healthy)
+	}
+}
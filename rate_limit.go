@@ -0,0 +1,150 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned for requests refused by the client's rate
+// limiter in RateLimitReject mode
+var ErrRateLimited = errors.New("request rate limit exceeded")
+
+// RateLimitMode selects what a request does when the rate limit is reached
+type RateLimitMode int
+
+const (
+	// RateLimitWait delays the request until the limit allows it, or until
+	// its context is done. It is the default.
+	RateLimitWait RateLimitMode = iota
+	// RateLimitReject fails the request with ErrRateLimited right away
+	RateLimitReject
+)
+
+// WithRateLimit limits the requests the client sends to perSecond on
+// average, allowing bursts of up to burst requests, with a token bucket.
+// Every request, including those of the typed helpers and Batch, takes a
+// token before it is written. Public servers disconnect clients sending too
+// many requests, so long running indexers should stay below their limits.
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config, xrpl.WithRateLimit(10, 20))
+func WithRateLimit(perSecond, burst int) ClientOption {
+	return func(c *Client) {
+		c.rateLimit.perSecond = perSecond
+		c.rateLimit.burst = burst
+	}
+}
+
+// WithRateLimitMode selects whether requests over the rate limit of
+// WithRateLimit wait or fail with ErrRateLimited
+func WithRateLimitMode(mode RateLimitMode) ClientOption {
+	return func(c *Client) {
+		c.rateLimit.mode = mode
+	}
+}
+
+type rateLimitConfig struct {
+	perSecond int
+	burst     int
+	mode      RateLimitMode
+}
+
+// newLimiter returns the limiter for the config, or nil without a limit
+func (r rateLimitConfig) newLimiter(k clock) (*rateLimiter, error) {
+	if r.perSecond == 0 && r.burst == 0 {
+		return nil, nil
+	}
+	if r.perSecond <= 0 || r.burst <= 0 {
+		return nil, fmt.Errorf("rate limit out of bounds: %d per second, burst %d", r.perSecond, r.burst)
+	}
+	return &rateLimiter{
+		rate:   float64(r.perSecond),
+		burst:  float64(r.burst),
+		tokens: float64(r.burst),
+		load:   1,
+		last:   k.now(),
+		clock:  k,
+		reject: r.mode == RateLimitReject,
+	}, nil
+}
+
+// rateLimiter is a token bucket holding up to burst tokens, refilled at rate
+// tokens per second divided by load, the server's reported load
+type rateLimiter struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	load   float64
+	last   time.Time
+	reject bool
+	clock  clock
+}
+
+// refill adds the tokens refilled since the last refill. The caller must
+// hold l.mutex.
+func (l *rateLimiter) refill() {
+	now := l.clock.now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate / l.load
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// setLoad slows the refill down by load, at least 1, from now on
+func (l *rateLimiter) setLoad(load float64) {
+	if load < 1 {
+		load = 1
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.refill()
+	l.load = load
+}
+
+// wait takes a token, waiting for one to be refilled unless the limiter
+// rejects requests, or until ctx is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mutex.Lock()
+		l.refill()
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mutex.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / (l.rate / l.load) * float64(time.Second))
+		l.mutex.Unlock()
+		if l.reject {
+			return ErrRateLimited
+		}
+
+		if err := l.clock.sleep(ctx, delay); err != nil {
+			return fmt.Errorf("request not sent: %w", err)
+		}
+	}
+}
+
+// maxServerLoadScale is the most the rate limit is divided by under server
+// load, so requests still flow when the load spikes.
+const maxServerLoadScale = 10
+
+// applyServerLoad scales the rate limit down by the load of status, as
+// reported by the server stream, up to maxServerLoadScale, or back to the
+// configured rate for a nil status. It does nothing without a rate limit.
+func (c *Client) applyServerLoad(status *ServerStatus) {
+	if c.limiter == nil {
+		return
+	}
+	if status == nil {
+		c.limiter.setLoad(1)
+		return
+	}
+	c.limiter.setLoad(math.Min(status.LoadRatio(), maxServerLoadScale))
+}
@@ -0,0 +1,56 @@
+package xrpl
+
+import "fmt"
+
+// RawRequest sends command with params, for commands the client has no
+// typed helper for, such as admin commands, Clio extensions or those of new
+// amendments. The params are the fields of the request besides command and
+// id, which the client sets; they are sent as given, along with the
+// client's api_version, and are not modified. Like RequestChecked, it
+// returns an *XRPLError when the server responds with an error.
+//
+// Example usage:
+//
+//	res, err := client.RawRequest("ledger_entry", map[string]interface{}{
+//		"index":        "7DB0788C020F02780A673DC74757F23823FA3014C1866E72CC4CD8B226CD6EF4",
+//		"ledger_index": "validated",
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(res["result"])
+func (c *Client) RawRequest(command string, params map[string]interface{}) (BaseResponse, error) {
+	if command == "" {
+		return nil, fmt.Errorf("raw request needs a command")
+	}
+	req := BaseRequest{"command": command}
+	for k, v := range params {
+		if k == "command" || k == "id" {
+			return nil, fmt.Errorf("raw request params cannot set %q", k)
+		}
+		req[k] = v
+	}
+	return c.RequestChecked(req)
+}
+
+// RegisterStreamType routes asynchronous messages whose type field is
+// typeName to handler, for message types the client does not know, such as
+// those of servers with custom streams. Without a handler such messages are
+// sent to StreamDefault. Several handlers may be registered for a type, and
+// each is a subscription with its own queue, see WithStreamBuffer. The
+// server has to be asked for the messages separately, e.g. with RawRequest.
+//
+// Example usage:
+//
+//	client.RegisterStreamType("bookChanges", func(msg xrpl.BaseResponse) {
+//		fmt.Println(msg["ledger_index"], msg["changes"])
+//	})
+//	_, err := client.RawRequest("subscribe", map[string]interface{}{
+//		"streams": []string{"book_changes"},
+//	})
+func (c *Client) RegisterStreamType(typeName string, handler func(msg BaseResponse)) {
+	h := c.newSubscriptionHandler(handler)
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.typeHandlers[typeName] = append(c.typeHandlers[typeName], h)
+}
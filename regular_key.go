@@ -0,0 +1,91 @@
+package xrpl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrWouldBrickAccount is returned by DisableMasterKey for an account that
+// has neither a regular key nor a signer list that can be satisfied. With
+// its master key disabled, such an account could never sign a transaction
+// again.
+var ErrWouldBrickAccount = errors.New("disabling the master key would leave the account without a way to sign")
+
+// BuildSetRegularKey builds a SetRegularKey transaction from account, ready
+// to be autofilled and signed, authorizing the key pair of the address
+// regularKey to sign for account as well as its master key. An empty
+// regularKey removes the regular key instead.
+//
+// Example usage:
+//
+//	regular, err := xrpl.GenerateWallet(xrpl.KeyTypeEd25519)
+//	if err != nil {
+//		return err
+//	}
+//	tx, err := xrpl.BuildSetRegularKey(wallet.ClassicAddress, regular.ClassicAddress)
+func BuildSetRegularKey(account, regularKey string) (map[string]interface{}, error) {
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeSetRegularKey),
+		"Account":         account,
+	}
+	if regularKey == "" {
+		return tx, nil
+	}
+	if err := ValidateClassicAddress(regularKey); err != nil {
+		return nil, fmt.Errorf("invalid RegularKey: %w", err)
+	}
+	if regularKey == account {
+		return nil, fmt.Errorf("RegularKey cannot be the account's own master key")
+	}
+	tx["RegularKey"] = regularKey
+	return tx, nil
+}
+
+// DisableMasterKey builds an AccountSet transaction from account, ready to be
+// autofilled and signed, disabling its master key so that only its regular
+// key or signer list can sign for it. The account is first read from the
+// latest validated ledger: unless it has a regular key, or a signer list
+// whose weights reach its quorum, the error matches ErrWouldBrickAccount and
+// no transaction is built.
+//
+// Example usage:
+//
+//	tx, err := client.DisableMasterKey(wallet.ClassicAddress)
+//	if errors.Is(err, xrpl.ErrWouldBrickAccount) {
+//		// Set a regular key or a signer list first
+//	}
+func (c *Client) DisableMasterKey(account string) (map[string]interface{}, error) {
+	info, err := c.AccountInfo(account, AccountInfoSignerLists(true))
+	if err != nil {
+		return nil, err
+	}
+	if info.RegularKey == "" {
+		satisfiable, err := hasSatisfiableSignerList(info.SignerLists)
+		if err != nil {
+			return nil, err
+		}
+		if !satisfiable {
+			return nil, fmt.Errorf("cannot disable the master key of %s: %w", account, ErrWouldBrickAccount)
+		}
+	}
+	return BuildAccountSet(account, AccountSetFlag(AsfDisableMaster, true))
+}
+
+// hasSatisfiableSignerList reports whether one of signerLists, as returned by
+// account_info, has signers whose weights reach its quorum
+func hasSatisfiableSignerList(signerLists []BaseResponse) (bool, error) {
+	for _, raw := range signerLists {
+		var list SignerList
+		if err := remarshal(raw, &list); err != nil {
+			return false, fmt.Errorf("invalid signer list: %w", err)
+		}
+		var total uint32
+		for _, signer := range list.SignerEntries {
+			total += uint32(signer.SignerWeight)
+		}
+		if list.SignerQuorum > 0 && total >= list.SignerQuorum {
+			return true, nil
+		}
+	}
+	return false, nil
+}
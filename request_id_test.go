@@ -0,0 +1,106 @@
+package xrpl
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newEchoServer starts a websocket server answering every request with its
+// tag field, after a random delay so that responses arrive out of order
+func newEchoServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var writeMutex sync.Mutex
+		for {
+			var req BaseRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			go func() {
+				time.Sleep(time.Duration(rand.Intn(2000)) * time.Microsecond)
+				writeMutex.Lock()
+				defer writeMutex.Unlock()
+				conn.WriteJSON(BaseResponse{
+					"id":     req["id"],
+					"type":   "response",
+					"status": "success",
+					"result": map[string]interface{}{"tag": req["tag"]},
+				})
+			}()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConcurrentRequests(t *testing.T) {
+	server := newEchoServer(t)
+	client := NewClient(ClientConfig{URL: "ws" + strings.TrimPrefix(server.URL, "http")})
+	defer client.Close()
+
+	const requests = 2000
+	var wg sync.WaitGroup
+	errs := make(chan string, requests)
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(tag float64) {
+			defer wg.Done()
+			res, err := client.Request(BaseRequest{"command": "ping", "tag": tag})
+			if err != nil {
+				errs <- fmt.Sprintf("request %v: %v", tag, err)
+				return
+			}
+			result, _ := res["result"].(map[string]interface{})
+			if result["tag"] != tag {
+				errs <- fmt.Sprintf("request %v got the response to %v", tag, result["tag"])
+			}
+		}(float64(i))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	if n := client.OrphanedResponses(); n != 0 {
+		t.Errorf("%d responses matched no request", n)
+	}
+}
+
+func TestNextIDConcurrent(t *testing.T) {
+	client := NewClient(ClientConfig{URL: "custom://", Transport: transportFunc(nil)})
+	const goroutines, perGoroutine = 32, 1000
+	ids := make(chan string, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				ids <- client.NextID()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("ID %s handed out twice", id)
+		}
+		seen[id] = true
+	}
+}
@@ -0,0 +1,104 @@
+package xrpl
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// accountReserve holds the reserve an account must keep, in drops, and its
+// balance
+type accountReserve struct {
+	base    *big.Int // Base reserve of any account
+	owner   *big.Int // Owner reserve times the number of objects owned
+	balance *big.Int
+}
+
+func (r accountReserve) total() *big.Int {
+	return new(big.Int).Add(r.base, r.owner)
+}
+
+// Retrieve the XRP an account must hold in reserve, in drops: the base
+// reserve of every account, the owner reserve for the objects it owns, such
+// as trust lines and offers, and their total. Reserves are those of the
+// latest validated ledger, as the network changes them by vote, and the
+// account is read in that ledger too.
+//
+// Example usage:
+//
+//	base, owner, total, err := client.AccountReserve("rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn")
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("reserve", total, "=", base, "+", owner)
+func (c *Client) AccountReserve(account string) (baseDrops, ownerDrops, totalReserveDrops string, err error) {
+	reserve, err := c.accountReserve(account)
+	if err != nil {
+		return "", "", "", err
+	}
+	return reserve.base.String(), reserve.owner.String(), reserve.total().String(), nil
+}
+
+// Retrieve the XRP an account can spend, in drops: its balance less its
+// reserve, see AccountReserve, or "0" when the balance does not cover the
+// reserve. Transaction fees are paid from the spendable balance too.
+func (c *Client) SpendableBalance(account string) (string, error) {
+	reserve, err := c.accountReserve(account)
+	if err != nil {
+		return "", err
+	}
+	spendable := new(big.Int).Sub(reserve.balance, reserve.total())
+	if spendable.Sign() < 0 {
+		spendable.SetInt64(0)
+	}
+	return spendable.String(), nil
+}
+
+// reserveSettings holds the reserves of the latest validated ledger, in drops
+type reserveSettings struct {
+	ReserveBase uint64 `json:"reserve_base"`
+	ReserveInc  uint64 `json:"reserve_inc"`
+}
+
+// ledgerReserves returns the reserves of the latest validated ledger as
+// reported by server_state
+func (c *Client) ledgerReserves() (*reserveSettings, error) {
+	var state struct {
+		State struct {
+			ValidatedLedger *reserveSettings `json:"validated_ledger"`
+		} `json:"state"`
+	}
+	if err := c.requestResult(BaseRequest{"command": "server_state"}, &state); err != nil {
+		return nil, err
+	}
+	if state.State.ValidatedLedger == nil {
+		return nil, fmt.Errorf("server has no validated ledger to read reserves from")
+	}
+	return state.State.ValidatedLedger, nil
+}
+
+func (c *Client) accountReserve(account string) (*accountReserve, error) {
+	settings, err := c.ledgerReserves()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := c.AccountInfo(account)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := info.Balance.Rat()
+	if err != nil {
+		return nil, fmt.Errorf("invalid account Balance: %w", err)
+	}
+	if !info.Balance.IsXRP() || !balance.IsInt() {
+		return nil, fmt.Errorf("invalid account Balance %s: not drops of XRP", info.Balance)
+	}
+
+	owner := new(big.Int).SetUint64(settings.ReserveInc)
+	owner.Mul(owner, new(big.Int).SetUint64(uint64(info.OwnerCount)))
+	return &accountReserve{
+		base:    new(big.Int).SetUint64(settings.ReserveBase),
+		owner:   owner,
+		balance: balance.Num(),
+	}, nil
+}
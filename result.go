@@ -0,0 +1,45 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// requestResult sends req and decodes the "result" object of the response
+// into v. Responses reporting an error status are returned as *XRPLError.
+func (c *Client) requestResult(req BaseRequest, v interface{}) error {
+	return c.requestResultCtx(context.Background(), req, v)
+}
+
+// requestResultCtx is requestResult bounded by ctx. The ledger_index of req
+// is resolved first, see resolveLedgerSpecifier.
+func (c *Client) requestResultCtx(ctx context.Context, req BaseRequest, v interface{}) error {
+	if err := resolveLedgerSpecifier(req); err != nil {
+		return err
+	}
+	res, err := c.RequestCheckedCtx(ctx, req)
+	if err != nil {
+		return err
+	}
+	return decodeResult(res, v)
+}
+
+// decodeResult decodes the "result" object of a response into v
+func decodeResult(res BaseResponse, v interface{}) error {
+	result, ok := res["result"]
+	if !ok {
+		return fmt.Errorf("response has no result")
+	}
+	return remarshal(result, v)
+}
+
+// remarshal converts a generic JSON value, as found in BaseResponse maps, into
+// the typed value v via its JSON encoding.
+func remarshal(in interface{}, v interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
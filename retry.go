@@ -0,0 +1,160 @@
+package xrpl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RetryConfig controls RequestWithRetry. Delays between attempts grow from
+// InitialDelay by Multiplier after each failed attempt, up to MaxDelay.
+type RetryConfig struct {
+	MaxAttempts    int           // Default is 3, including the first attempt
+	InitialDelay   time.Duration // Default is 500 milliseconds
+	MaxDelay       time.Duration // Default is 10 seconds
+	Multiplier     float64       // Default is 2
+	AttemptTimeout time.Duration // Default is 0, i.e. attempts are only bounded by ctx
+	Commands       []string      // Commands safe to retry, default is DefaultRetryCommands
+}
+
+// DefaultRetryCommands are the read-only commands RequestWithRetry retries
+// unless RetryConfig.Commands is set. Sending them again has no effect on the
+// ledger.
+var DefaultRetryCommands = []string{
+	"account_channels",
+	"account_currencies",
+	"account_info",
+	"account_lines",
+	"account_nfts",
+	"account_objects",
+	"account_offers",
+	"account_tx",
+	"book_offers",
+	"deposit_authorized",
+	"fee",
+	"gateway_balances",
+	"ledger",
+	"ledger_closed",
+	"ledger_current",
+	"ledger_data",
+	"ledger_entry",
+	"nft_buy_offers",
+	"nft_sell_offers",
+	"ping",
+	"ripple_path_find",
+	"server_definitions",
+	"server_info",
+	"server_state",
+	"transaction_entry",
+	"tx",
+}
+
+// Commands that are never retried: sending a transaction again after a lost
+// response could apply it twice, should it not have a Sequence or Ticket
+// that prevents it.
+var nonRetryableCommands = map[string]bool{
+	"submit":             true,
+	"submit_multisigned": true,
+}
+
+// Errors of rippled that report a temporary condition of the server rather
+// than a problem with the request
+var transientResponseErrors = map[string]bool{
+	"tooBusy":   true,
+	"noNetwork": true,
+	"noCurrent": true,
+	"noClosed":  true,
+	"slowDown":  true,
+}
+
+// RequestWithRetry sends a request like RequestCtx and retries it with
+// exponential backoff when it fails transiently: on connection errors, when
+// an attempt exceeds RetryConfig.AttemptTimeout, and when the server responds
+// that it is too busy or not synced. Only commands on the allowlist
+// RetryConfig.Commands are retried, other commands are sent once. The submit
+// and submit_multisigned commands cannot be retried and are refused when
+// allowlisted. When every attempt fails, the error reports the number of
+// attempts and wraps the last failure.
+//
+// Example usage:
+//
+//	res, err := client.RequestWithRetry(ctx, xrpl.BaseRequest{
+//		"command": "account_info",
+//		"account": "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//	}, xrpl.RetryConfig{MaxAttempts: 5})
+func (c *Client) RequestWithRetry(ctx context.Context, req BaseRequest, config RetryConfig) (BaseResponse, error) {
+	if config.MaxAttempts == 0 {
+		config.MaxAttempts = 3
+	}
+	if config.InitialDelay == 0 {
+		config.InitialDelay = 500 * time.Millisecond
+	}
+	if config.MaxDelay == 0 {
+		config.MaxDelay = 10 * time.Second
+	}
+	if config.Multiplier == 0 {
+		config.Multiplier = 2
+	}
+	if config.Commands == nil {
+		config.Commands = DefaultRetryCommands
+	}
+	if config.MaxAttempts < 0 || config.InitialDelay < 0 || config.MaxDelay < config.InitialDelay || config.Multiplier < 1 {
+		return nil, fmt.Errorf("invalid retry config %+v", config)
+	}
+
+	command, _ := req["command"].(string)
+	retryable := false
+	for _, allowed := range config.Commands {
+		if allowed == command {
+			retryable = true
+		}
+	}
+	if retryable && nonRetryableCommands[command] {
+		return nil, fmt.Errorf("%s requests cannot be retried, they could apply a transaction twice", command)
+	}
+	if !retryable {
+		return c.RequestCtx(ctx, req)
+	}
+
+	delay := config.InitialDelay
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		res, err := c.requestAttempt(ctx, req, config.AttemptTimeout)
+		if err == nil {
+			xrplErr := &XRPLError{}
+			if errors.As(checkResponse(req, res), &xrplErr) && transientResponseErrors[xrplErr.Err] {
+				err = xrplErr
+			} else {
+				return res, nil
+			}
+		}
+		if ctx.Err() != nil || errors.Is(err, ErrClientClosed) {
+			return nil, err
+		}
+		lastErr = err
+		if attempt >= config.MaxAttempts {
+			return nil, fmt.Errorf("%s failed after %d attempts: %w", command, attempt, lastErr)
+		}
+		c.logger.Debugf("Retrying %s after attempt %d error: %s", command, attempt, err)
+
+		if err := c.clock.sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("%s aborted after %d attempts: %w", command, attempt, err)
+		}
+		delay = time.Duration(float64(delay) * config.Multiplier)
+		if delay > config.MaxDelay {
+			delay = config.MaxDelay
+		}
+	}
+}
+
+// requestAttempt sends a request bounded by timeout, when positive, as well
+// as by ctx
+func (c *Client) requestAttempt(ctx context.Context, req BaseRequest, timeout time.Duration) (BaseResponse, error) {
+	if timeout <= 0 {
+		return c.RequestCtx(ctx, req)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return c.RequestCtx(attemptCtx, req)
+}
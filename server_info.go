@@ -0,0 +1,84 @@
+package xrpl
+
+import (
+	"context"
+)
+
+// Possible server_state values of a rippled server
+// https://xrpl.org/docs/references/http-websocket-apis/api-conventions/rippled-server-states
+const (
+	ServerStateDisconnected = "disconnected"
+	ServerStateConnected    = "connected"
+	ServerStateSyncing      = "syncing"
+	ServerStateTracking     = "tracking"
+	ServerStateFull         = "full"
+	ServerStateValidating   = "validating"
+	ServerStateProposing    = "proposing"
+)
+
+// ServerInfoResult holds the info object returned by the server_info command
+type ServerInfoResult struct {
+	BuildVersion          string           `json:"build_version"`
+	CompleteLedgers       string           `json:"complete_ledgers"` // Ranges such as "32570-62964580"
+	HostID                string           `json:"hostid"`
+	NetworkID             uint32           `json:"network_id"`
+	PubkeyNode            string           `json:"pubkey_node"`
+	ServerState           string           `json:"server_state"`
+	ServerStateDurationUs string           `json:"server_state_duration_us"`
+	AmendmentBlocked      bool             `json:"amendment_blocked"`
+	LoadFactor            float64          `json:"load_factor"`
+	Peers                 uint32           `json:"peers"`
+	IOLatencyMs           uint32           `json:"io_latency_ms"`
+	JqTransOverflow       string           `json:"jq_trans_overflow"`
+	Uptime                uint64           `json:"uptime"` // Seconds
+	Time                  string           `json:"time"`
+	ValidationQuorum      uint32           `json:"validation_quorum"`
+	ValidatedLedger       *ValidatedLedger `json:"validated_ledger"` // Absent while the server has no validated ledger
+	LastClose             struct {
+		ConvergeTimeS float64 `json:"converge_time_s"`
+		Proposers     uint32  `json:"proposers"`
+	} `json:"last_close"`
+}
+
+// ValidatedLedger describes the latest validated ledger known to a server.
+// Fees and reserves are in XRP, as reported by server_info.
+type ValidatedLedger struct {
+	Age            uint32  `json:"age"` // Seconds since the ledger was validated
+	Hash           string  `json:"hash"`
+	Seq            uint32  `json:"seq"`
+	BaseFeeXRP     float64 `json:"base_fee_xrp"`
+	ReserveBaseXRP float64 `json:"reserve_base_xrp"`
+	ReserveIncXRP  float64 `json:"reserve_inc_xrp"`
+}
+
+// IsHealthy reports whether the server is fully synced with the network,
+// i.e. in the full or proposing state, and not amendment blocked.
+func (s *ServerInfoResult) IsHealthy() bool {
+	if s.AmendmentBlocked {
+		return false
+	}
+	return s.ServerState == ServerStateFull || s.ServerState == ServerStateProposing
+}
+
+// Retrieve the status of the server the client is connected to.
+//
+// Example usage:
+//
+//	info, err := client.ServerInfo()
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(info.ServerState, info.CompleteLedgers, info.IsHealthy())
+func (c *Client) ServerInfo() (*ServerInfoResult, error) {
+	return c.serverInfoCtx(context.Background())
+}
+
+func (c *Client) serverInfoCtx(ctx context.Context) (*ServerInfoResult, error) {
+	var result struct {
+		Info ServerInfoResult `json:"info"`
+	}
+	if err := c.requestResultCtx(ctx, BaseRequest{"command": "server_info"}, &result); err != nil {
+		return nil, err
+	}
+	return &result.Info, nil
+}
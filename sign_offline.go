@@ -0,0 +1,91 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// offlineRequiredFields are the fields SignOffline cannot fill in itself.
+// Autofill sets Sequence and Fee from the network.
+var offlineRequiredFields = []string{"TransactionType", "Account", "Sequence", "Fee"}
+
+// SignOfflineOption configures SignOffline
+type SignOfflineOption func(*signOfflineOptions)
+
+type signOfflineOptions struct {
+	regularKey string
+}
+
+// SignOfflineRegularKey allows signing with the key of regularKey, the
+// address of the regular key set for the transaction's Account, which
+// SignOffline cannot look up itself
+func SignOfflineRegularKey(regularKey string) SignOfflineOption {
+	return func(o *signOfflineOptions) {
+		o.regularKey = regularKey
+	}
+}
+
+// SignOffline signs tx with seed without any network access, for keys kept
+// on an air-gapped machine, and returns the signed transaction blob in hex,
+// to be submitted elsewhere with SubmitBlob, and its transaction ID. Every
+// field must be set beforehand, as Autofill would: at least TransactionType,
+// Account, Sequence and Fee, and LastLedgerSequence so that the transaction
+// cannot be submitted indefinitely. tx itself is not modified. A seed that is
+// not the master key of Account, or the regular key given with
+// SignOfflineRegularKey, is rejected with an error wrapping
+// ErrSigningAccountMismatch.
+//
+// Example usage:
+//
+//	tx["Sequence"] = 12
+//	tx["Fee"] = "12"
+//	tx["LastLedgerSequence"] = 87654321
+//	blob, txid, err := xrpl.SignOffline(tx, seed)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(txid, blob)
+func SignOffline(tx map[string]interface{}, seed string, opts ...SignOfflineOption) (signedBlob string, txid string, err error) {
+	var options signOfflineOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	var missing []string
+	for _, field := range offlineRequiredFields {
+		if tx[field] == nil {
+			missing = append(missing, field)
+		}
+	}
+	if len(missing) > 0 {
+		return "", "", fmt.Errorf("transaction must be complete to be signed offline: missing %s", strings.Join(missing, ", "))
+	}
+	if err := checkSigningAccount(tx, seed, options.regularKey); err != nil {
+		return "", "", err
+	}
+	wallet, err := WalletFromSeed(seed)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decode family seed: %w", err)
+	}
+	return wallet.Sign(tx)
+}
+
+// SubmitBlob submits a signed transaction blob in hex, as returned by
+// SignOffline or produced by another signer, with the submit command. The
+// blob is sent as given; its engine result is in the SubmitResult, and an
+// *XRPLError is returned when the server rejects the request itself, e.g.
+// for a malformed blob.
+func (c *Client) SubmitBlob(blobHex string) (*SubmitResult, error) {
+	if b, err := hex.DecodeString(blobHex); err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid transaction blob: must be non-empty hex")
+	}
+	req := BaseRequest{
+		"command": "submit",
+		"tx_blob": strings.ToUpper(blobHex),
+	}
+	res, err := c.Request(req)
+	if err != nil {
+		return nil, err
+	}
+	return parseSubmitResult(req, res)
+}
@@ -0,0 +1,78 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// signerListMaxEntries is the most signers a SignerList may have
+const signerListMaxEntries = 32
+
+// BuildSignerListSet builds a SignerListSet transaction from account, ready
+// to be autofilled and signed, replacing its signer list with signers. A
+// multi-signed transaction of account is authorized once the weights of its
+// signers reach quorum. A quorum of 0 with no signers deletes the signer
+// list instead.
+//
+// The list is checked so that it can be used: it has from 1 to 32 signers,
+// each a valid address other than account and listed once, with a weight
+// above 0 and an optional WalletLocator of 32 bytes of hex, and the weights
+// add up to at least quorum.
+//
+// Example usage:
+//
+//	// Any two of three keys authorize a transaction
+//	tx, err := xrpl.BuildSignerListSet(wallet.ClassicAddress, 2, []xrpl.SignerEntry{
+//		{Account: "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn", SignerWeight: 1},
+//		{Account: "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", SignerWeight: 1},
+//		{Account: "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", SignerWeight: 1},
+//	})
+func BuildSignerListSet(account string, quorum uint32, signers []SignerEntry) (map[string]interface{}, error) {
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeSignerListSet),
+		"Account":         account,
+		"SignerQuorum":    quorum,
+	}
+	if quorum == 0 {
+		if len(signers) > 0 {
+			return nil, fmt.Errorf("signer list with a SignerQuorum of 0 cannot have signers, it deletes the list")
+		}
+		return tx, nil
+	}
+	if len(signers) == 0 || len(signers) > signerListMaxEntries {
+		return nil, fmt.Errorf("signer list out of bounds: %d signers, from 1 to %d", len(signers), signerListMaxEntries)
+	}
+
+	seen := make(map[string]bool, len(signers))
+	entries := make([]SignerEntry, 0, len(signers))
+	var total uint64
+	for _, signer := range signers {
+		if err := ValidateClassicAddress(signer.Account); err != nil {
+			return nil, fmt.Errorf("invalid signer: %w", err)
+		}
+		if signer.Account == account {
+			return nil, fmt.Errorf("signer list of %s cannot include the account itself", account)
+		}
+		if seen[signer.Account] {
+			return nil, fmt.Errorf("signer %s listed more than once", signer.Account)
+		}
+		seen[signer.Account] = true
+		if signer.SignerWeight == 0 {
+			return nil, fmt.Errorf("signer %s has a SignerWeight of 0", signer.Account)
+		}
+		total += uint64(signer.SignerWeight)
+		if signer.WalletLocator != "" {
+			if b, err := hex.DecodeString(signer.WalletLocator); err != nil || len(b) != 32 {
+				return nil, fmt.Errorf("invalid WalletLocator of signer %s: must be 32 bytes of hex", signer.Account)
+			}
+			signer.WalletLocator = strings.ToUpper(signer.WalletLocator)
+		}
+		entries = append(entries, signer)
+	}
+	if uint64(quorum) > total {
+		return nil, fmt.Errorf("signer list can never be satisfied: SignerQuorum %d exceeds the total SignerWeight %d", quorum, total)
+	}
+	tx["SignerEntries"] = entries
+	return tx, nil
+}
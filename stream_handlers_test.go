@@ -0,0 +1,213 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// failingTransport answers requests with a success, with a connection error
+// while fail is set, or with an error response while reject is set
+type failingTransport struct {
+	mutex  sync.Mutex
+	fail   bool
+	reject bool
+}
+
+func (t *failingTransport) Send(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.fail {
+		return nil, errors.New("connection refused")
+	}
+	if t.reject {
+		return BaseResponse{"status": "error", "error": "noPermission"}, nil
+	}
+	return BaseResponse{"status": "success", "result": map[string]interface{}{}}, nil
+}
+
+func (t *failingTransport) setFail(fail bool) {
+	t.mutex.Lock()
+	t.fail = fail
+	t.mutex.Unlock()
+}
+
+func (t *failingTransport) setReject(reject bool) {
+	t.mutex.Lock()
+	t.reject = reject
+	t.mutex.Unlock()
+}
+
+func newHandlerTestClient() (*Client, *failingTransport) {
+	transport := &failingTransport{}
+	return NewClient(ClientConfig{URL: "custom://", Transport: transport}, WithLogger(NopLogger())), transport
+}
+
+// receive returns the next message of ch, failing the test after a while
+func receive(t *testing.T, ch chan BaseResponse, name string) BaseResponse {
+	t.Helper()
+	select {
+	case msg := <-ch:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatalf("%s: no message", name)
+		return nil
+	}
+}
+
+func TestSubscribeWithHandlerFailureKeepsOtherHandlers(t *testing.T) {
+	client, transport := newHandlerTestClient()
+	first := make(chan BaseResponse, 1)
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeLedger}, func(msg BaseResponse) { first <- msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	transport.setFail(true)
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeLedger}, func(msg BaseResponse) {
+		t.Errorf("handler of the failed subscription got %v", msg)
+	}); err == nil {
+		t.Fatal("failed subscribe: no error")
+	}
+
+	if !client.dispatchStream(BaseResponse{"type": "ledgerClosed", "ledger_index": 7}) {
+		t.Fatal("the handler of the first subscription was removed")
+	}
+	if msg := receive(t, first, "first handler"); msg["ledger_index"] != 7 {
+		t.Errorf("first handler got %v", msg)
+	}
+}
+
+func TestSubscribeTypedFailureKeepsOtherHandlers(t *testing.T) {
+	client, transport := newHandlerTestClient()
+	first := make(chan LedgerClosedEvent, 1)
+	if _, err := client.SubscribeLedger(func(ledger LedgerClosedEvent) { first <- ledger }); err != nil {
+		t.Fatal(err)
+	}
+
+	transport.setReject(true)
+	if _, err := client.SubscribeLedger(func(ledger LedgerClosedEvent) {
+		t.Errorf("handler of the failed subscription got ledger %d", ledger.LedgerIndex)
+	}); err == nil {
+		t.Fatal("rejected subscribe: no error")
+	}
+	if !client.IsSubscribed(StreamTypeLedger) {
+		t.Error("the ledger stream of the first subscription was unsubscribed")
+	}
+
+	if !client.dispatchStream(BaseResponse{"type": "ledgerClosed", "ledger_index": 7}) {
+		t.Fatal("the handler of the first subscription was removed")
+	}
+	select {
+	case ledger := <-first:
+		if ledger.LedgerIndex != 7 {
+			t.Errorf("first handler got ledger %d, want 7", ledger.LedgerIndex)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("first handler: no ledger")
+	}
+}
+
+func transactionMessage(t *testing.T, validated bool) []byte {
+	t.Helper()
+	message, err := json.Marshal(BaseResponse{
+		"type":      "transaction",
+		"validated": validated,
+		"transaction": map[string]interface{}{
+			"TransactionType": "Payment",
+			"Account":         "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1",
+			"Destination":     "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+			"Amount":          "1000000",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return message
+}
+
+func TestStreamChannelsWithHandlers(t *testing.T) {
+	client, _ := newHandlerTestClient()
+	if _, err := client.Subscribe([]string{StreamTypeTransaction}); err != nil {
+		t.Fatal(err)
+	}
+	accountTxs := make(chan BaseResponse, 1)
+	if _, err := client.SubscribeAccounts([]string{"rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"}, func(msg BaseResponse) { accountTxs <- msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	// The transactions stream was subscribed without a handler: its channel
+	// keeps receiving transactions that an account handler takes too
+	client.resolveStream(transactionMessage(t, true))
+	receive(t, accountTxs, "account handler")
+	select {
+	case <-client.StreamTransaction:
+	default:
+		t.Error("StreamTransaction: no message")
+	}
+}
+
+func TestStreamHandlersOnly(t *testing.T) {
+	client, _ := newHandlerTestClient()
+	validated := make(chan BaseResponse, 2)
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeTransaction}, func(msg BaseResponse) { validated <- msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	client.resolveStream(transactionMessage(t, true))
+	receive(t, validated, "transactions handler")
+	select {
+	case message := <-client.StreamTransaction:
+		t.Errorf("StreamTransaction got %s, want it taken by the handler", message)
+	default:
+	}
+}
+
+func TestTransactionStreamsSplit(t *testing.T) {
+	client, _ := newHandlerTestClient()
+	validated := make(chan BaseResponse, 2)
+	proposed := make(chan BaseResponse, 2)
+	both := make(chan BaseResponse, 4)
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeTransaction}, func(msg BaseResponse) { validated <- msg }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeTransactionsProposed}, func(msg BaseResponse) { proposed <- msg }); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.SubscribeWithHandler([]string{StreamTypeTransaction, StreamTypeTransactionsProposed}, func(msg BaseResponse) { both <- msg }); err != nil {
+		t.Fatal(err)
+	}
+
+	// A proposed transaction only goes to transactions_proposed handlers
+	client.resolveStream(transactionMessage(t, false))
+	if msg := receive(t, proposed, "transactions_proposed handler"); msg["validated"] != false {
+		t.Errorf("transactions_proposed handler got %v, want the proposed transaction", msg)
+	}
+	if msg := receive(t, both, "handler of both streams"); msg["validated"] != false {
+		t.Errorf("handler of both streams got %v, want the proposed transaction", msg)
+	}
+
+	// A validated one goes to the handlers of both streams, once each
+	client.resolveStream(transactionMessage(t, true))
+	if msg := receive(t, validated, "transactions handler"); msg["validated"] != true {
+		t.Errorf("transactions handler got %v, want the validated transaction", msg)
+	}
+	if msg := receive(t, proposed, "transactions_proposed handler"); msg["validated"] != true {
+		t.Errorf("transactions_proposed handler got %v, want the validated transaction", msg)
+	}
+	if msg := receive(t, both, "handler of both streams"); msg["validated"] != true {
+		t.Errorf("handler of both streams got %v, want the validated transaction", msg)
+	}
+
+	// Wait for the workers to go idle before checking nothing else arrived
+	time.Sleep(50 * time.Millisecond)
+	for name, ch := range map[string]chan BaseResponse{"transactions": validated, "transactions_proposed": proposed, "both streams": both} {
+		select {
+		case msg := <-ch:
+			t.Errorf("handler of %s got an extra message %v", name, msg)
+		default:
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package xrpl
+
+import "fmt"
+
+// StreamOverflowPolicy selects what happens to a stream message when the
+// queue of a subscription handler is full
+type StreamOverflowPolicy int
+
+const (
+	// StreamOverflowBlock makes the read loop wait until the handler takes a
+	// message off its queue. No message is lost, but responses and the
+	// messages of other subscriptions wait too. It is the default.
+	StreamOverflowBlock StreamOverflowPolicy = iota
+	// StreamOverflowDropOldest discards the oldest queued message to make
+	// room for the new one
+	StreamOverflowDropOldest
+	// StreamOverflowCallback discards the new message and passes it to the
+	// handler of WithStreamOverflowHandler
+	StreamOverflowCallback
+)
+
+// WithStreamBuffer sets how many stream messages may wait for each
+// subscription handler, and what happens to messages arriving while its
+// queue is full. The default is ClientConfig.QueueCapacity messages and
+// StreamOverflowBlock.
+//
+// Handlers of SubscribeWithHandler, SubscribeAccounts, SubscribeBooks and
+// PathFindCreate run on a worker goroutine of their subscription rather than
+// on the goroutine reading the connection, so a slow handler only stalls the
+// reading of responses and other streams when its queue overflows with
+// StreamOverflowBlock.
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config,
+//		xrpl.WithStreamBuffer(1024, xrpl.StreamOverflowDropOldest))
+func WithStreamBuffer(size int, policy StreamOverflowPolicy) ClientOption {
+	return func(c *Client) {
+		c.streamQueue.size = size
+		c.streamQueue.policy = policy
+	}
+}
+
+// WithStreamOverflowHandler sets the handler receiving the stream messages
+// discarded with StreamOverflowCallback. It runs on the goroutine reading the
+// connection, so it must not block.
+func WithStreamOverflowHandler(handler func(msg BaseResponse)) ClientOption {
+	return func(c *Client) {
+		c.streamQueue.onOverflow = handler
+	}
+}
+
+type streamQueueConfig struct {
+	size       int
+	policy     StreamOverflowPolicy
+	onOverflow func(BaseResponse)
+}
+
+func (q streamQueueConfig) validate() error {
+	if q.size <= 0 {
+		return fmt.Errorf("stream buffer out of bounds: %d", q.size)
+	}
+	switch q.policy {
+	case StreamOverflowBlock, StreamOverflowDropOldest:
+	case StreamOverflowCallback:
+		if q.onOverflow == nil {
+			return fmt.Errorf("stream overflow policy StreamOverflowCallback requires WithStreamOverflowHandler")
+		}
+	default:
+		return fmt.Errorf("unknown stream overflow policy: %d", q.policy)
+	}
+	return nil
+}
+
+// newSubscriptionHandler returns a handler with an empty queue of the
+// client's stream buffer size
+func (c *Client) newSubscriptionHandler(handler func(BaseResponse)) *subscriptionHandler {
+	return &subscriptionHandler{
+		handle:   handler,
+		messages: make(chan BaseResponse, c.streamQueue.size),
+	}
+}
+
+// enqueue queues m for h, applying the client's overflow policy when the
+// queue is full, and starts the worker of h unless it is running
+func (c *Client) enqueue(h *subscriptionHandler, m BaseResponse) {
+	switch c.streamQueue.policy {
+	case StreamOverflowDropOldest:
+		for queued := false; !queued; {
+			select {
+			case h.messages <- m:
+				queued = true
+			default:
+				select {
+				case dropped := <-h.messages:
+					messageType, _ := dropped["type"].(string)
+					c.metrics.MessageDropped(messageType)
+					c.logger.Debugf("WS stream queue full, oldest %s message dropped", messageType)
+				default:
+				}
+			}
+		}
+	case StreamOverflowCallback:
+		select {
+		case h.messages <- m:
+		default:
+			messageType, _ := m["type"].(string)
+			c.metrics.MessageDropped(messageType)
+			c.streamQueue.onOverflow(m)
+			return
+		}
+	default:
+		h.messages <- m
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if !h.running {
+		h.running = true
+		go h.run()
+	}
+}
+
+// run handles the queued messages of h one at a time and returns once the
+// queue is empty
+func (h *subscriptionHandler) run() {
+	for {
+		h.mutex.Lock()
+		var m BaseResponse
+		select {
+		case m = <-h.messages:
+		default:
+			h.running = false
+			h.mutex.Unlock()
+			return
+		}
+		h.mutex.Unlock()
+		h.handle(m)
+	}
+}
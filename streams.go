@@ -0,0 +1,262 @@
+package xrpl
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// LedgerClosedEvent describes a validated ledger, as sent by the ledger stream
+// for every ledger closed and in the subscribe response for the latest one.
+// Fees and reserves are in drops.
+type LedgerClosedEvent struct {
+	LedgerIndex      uint32 `json:"ledger_index"`
+	LedgerHash       string `json:"ledger_hash"`
+	LedgerTime       uint32 `json:"ledger_time"` // Close time, in seconds since the Ripple epoch
+	FeeBase          uint64 `json:"fee_base"`
+	FeeRef           uint64 `json:"fee_ref,omitempty"` // Not reported by newer servers
+	ReserveBase      uint64 `json:"reserve_base"`
+	ReserveInc       uint64 `json:"reserve_inc"`
+	TxnCount         uint32 `json:"txn_count,omitempty"` // Not reported in the subscribe response
+	ValidatedLedgers string `json:"validated_ledgers,omitempty"`
+	NetworkID        uint32 `json:"network_id,omitempty"`
+}
+
+// ValidationReceivedEvent is a ledger validation, as sent by the validations
+// stream for every validation the server receives, including those of
+// validators it does not trust
+type ValidationReceivedEvent struct {
+	LedgerIndex         uint32   `json:"ledger_index,string"`
+	LedgerHash          string   `json:"ledger_hash"`
+	ValidatedHash       string   `json:"validated_hash,omitempty"`
+	ValidationPublicKey string   `json:"validation_public_key"` // Signing key of the validator
+	MasterKey           string   `json:"master_key,omitempty"`  // Master key of the validator, if it uses a manifest
+	Signature           string   `json:"signature"`
+	SigningTime         uint32   `json:"signing_time"` // In seconds since the Ripple epoch
+	Flags               uint32   `json:"flags"`
+	Full                bool     `json:"full"` // A full validation, rather than a partial one
+	Cookie              string   `json:"cookie,omitempty"`
+	Data                string   `json:"data,omitempty"` // Serialized validation, in hex
+	Amendments          []string `json:"amendments,omitempty"`
+	BaseFee             uint64   `json:"base_fee,omitempty"`
+	LoadFee             uint64   `json:"load_fee,omitempty"`
+	ReserveBase         uint64   `json:"reserve_base,omitempty"`
+	ReserveInc          uint64   `json:"reserve_inc,omitempty"`
+	ServerVersion       string   `json:"server_version,omitempty"`
+	NetworkID           uint32   `json:"network_id,omitempty"`
+}
+
+// ConsensusPhaseEvent reports that the server's consensus process entered a
+// new phase, "open", "establish" or "accepted", as sent by the consensus
+// stream
+type ConsensusPhaseEvent struct {
+	Consensus string `json:"consensus"`
+}
+
+// ServerStatus is the load and state of the server, as sent by the server
+// stream whenever either changes and in the subscribe response. Load factors
+// are relative to LoadBase: a LoadFactor of twice LoadBase means transaction
+// costs are doubled, and the server is busy.
+type ServerStatus struct {
+	ServerStatus            string `json:"server_status"` // e.g. "full", "syncing" or "disconnected"
+	LoadBase                uint32 `json:"load_base"`
+	LoadFactor              uint32 `json:"load_factor"`
+	LoadFactorServer        uint32 `json:"load_factor_server,omitempty"`
+	LoadFactorFeeEscalation uint64 `json:"load_factor_fee_escalation,omitempty"`
+	LoadFactorFeeQueue      uint64 `json:"load_factor_fee_queue,omitempty"`
+	LoadFactorFeeReference  uint64 `json:"load_factor_fee_reference,omitempty"`
+	BaseFee                 uint64 `json:"base_fee,omitempty"` // in drops
+	HostID                  string `json:"hostid,omitempty"`
+}
+
+// LoadRatio returns LoadFactor relative to LoadBase, 1 for a server under
+// normal load
+func (s ServerStatus) LoadRatio() float64 {
+	if s.LoadBase == 0 {
+		return 1
+	}
+	return float64(s.LoadFactor) / float64(s.LoadBase)
+}
+
+// SubscribeLedger subscribes to the ledger stream and passes every ledger
+// closed to handler, as with SubscribeWithHandler. It returns the latest
+// validated ledger, which the server reports when subscribing, so that no
+// separate request is needed for the state the events start from. Use
+// Unsubscribe with StreamTypeLedger to stop.
+//
+// Example usage:
+//
+//	latest, err := client.SubscribeLedger(func(ledger xrpl.LedgerClosedEvent) {
+//		fmt.Println("ledger", ledger.LedgerIndex, "with", ledger.TxnCount, "transactions")
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("starting after", latest.LedgerIndex)
+func (c *Client) SubscribeLedger(handler func(LedgerClosedEvent)) (*LedgerClosedEvent, error) {
+	res, err := c.subscribeTyped(StreamTypeLedger, func(m BaseResponse) {
+		var ledger LedgerClosedEvent
+		if err := remarshal(m, &ledger); err != nil {
+			c.logger.Warnf("WS invalid %s message: %s", StreamTypeLedger, err)
+			return
+		}
+		handler(ledger)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var latest LedgerClosedEvent
+	if err := decodeResult(res, &latest); err != nil {
+		return nil, err
+	}
+	return &latest, nil
+}
+
+// SubscribeValidations subscribes to the validations stream and passes every
+// validation received to handler, as with SubscribeWithHandler. Use
+// Unsubscribe with StreamTypeValidations to stop.
+func (c *Client) SubscribeValidations(handler func(ValidationReceivedEvent)) error {
+	_, err := c.subscribeTyped(StreamTypeValidations, func(m BaseResponse) {
+		var validation ValidationReceivedEvent
+		if err := remarshal(m, &validation); err != nil {
+			c.logger.Warnf("WS invalid %s message: %s", StreamTypeValidations, err)
+			return
+		}
+		handler(validation)
+	})
+	return err
+}
+
+// SubscribeConsensus subscribes to the consensus stream and passes every
+// change of consensus phase to handler, as with SubscribeWithHandler. Use
+// Unsubscribe with StreamTypeConsensus to stop.
+func (c *Client) SubscribeConsensus(handler func(ConsensusPhaseEvent)) error {
+	_, err := c.subscribeTyped(StreamTypeConsensus, func(m BaseResponse) {
+		var phase ConsensusPhaseEvent
+		if err := remarshal(m, &phase); err != nil {
+			c.logger.Warnf("WS invalid %s message: %s", StreamTypeConsensus, err)
+			return
+		}
+		handler(phase)
+	})
+	return err
+}
+
+// SubscribeServer subscribes to the server stream and passes every change of
+// the server's load or state to handler, as with SubscribeWithHandler. It
+// returns the current status, which the server reports when subscribing. With
+// WithRateLimit, the rate limit is divided by the LoadRatio of the latest
+// status, by 10 at most, so the client sends fewer requests while the server
+// is busy; the configured rate applies again once the load drops or the
+// stream is unsubscribed. The subscription is renewed on reconnection, as
+// with Subscribe. Use Unsubscribe with StreamTypeServer to stop.
+//
+// Example usage:
+//
+//	status, err := client.SubscribeServer(func(status xrpl.ServerStatus) {
+//		if status.LoadRatio() > 2 {
+//			fmt.Println("server busy:", status.LoadFactor, "/", status.LoadBase)
+//		}
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("server", status.ServerStatus)
+func (c *Client) SubscribeServer(handler func(ServerStatus)) (*ServerStatus, error) {
+	res, err := c.subscribeTyped(StreamTypeServer, func(m BaseResponse) {
+		var status ServerStatus
+		if err := remarshal(m, &status); err != nil {
+			c.logger.Warnf("WS invalid %s message: %s", StreamTypeServer, err)
+			return
+		}
+		c.applyServerLoad(&status)
+		handler(status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var status ServerStatus
+	if err := decodeResult(res, &status); err != nil {
+		return nil, err
+	}
+	c.applyServerLoad(&status)
+	return &status, nil
+}
+
+// subscribeTyped subscribes handler to stream with SubscribeWithHandler,
+// undoing the subscription when the server responds with an error. Only the
+// new handler is removed, and the stream stays subscribed when it was before.
+func (c *Client) subscribeTyped(stream string, handler func(BaseResponse)) (BaseResponse, error) {
+	streams := []string{stream}
+	wasSubscribed := c.IsSubscribed(stream)
+	res, h, err := c.subscribeWithHandler(streams, handler)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResponse(BaseRequest{"command": "subscribe", "streams": streams}, res); err != nil {
+		if !wasSubscribed {
+			c.mutex.Lock()
+			delete(c.streamSubscriptions, stream)
+			c.mutex.Unlock()
+		}
+		c.removeStreamHandler(streams, h)
+		return nil, err
+	}
+	return res, nil
+}
+
+// waitForLedgerInterval is how often WaitForLedger polls the server
+const waitForLedgerInterval = time.Second
+
+// WaitForLedger blocks until the latest validated ledger reaches index, and
+// returns that ledger, or a later one when ledgers closed in between. It
+// polls server_state, so it works over HTTP too and leaves the ledger stream
+// subscription alone; TxnCount is not reported. Once ctx is done, waiting
+// stops and the returned error wraps ctx.Err().
+//
+// Example usage:
+//
+//	// Wait until the transaction can no longer be included
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	ledger, err := client.WaitForLedger(ctx, lastLedgerSequence+1)
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println("ledger", ledger.LedgerIndex, "validated")
+func (c *Client) WaitForLedger(ctx context.Context, index uint32) (*LedgerClosedEvent, error) {
+	for {
+		var state struct {
+			State struct {
+				CompleteLedgers string `json:"complete_ledgers"`
+				NetworkID       uint32 `json:"network_id"`
+				ValidatedLedger *struct {
+					Seq         uint32 `json:"seq"`
+					Hash        string `json:"hash"`
+					CloseTime   uint32 `json:"close_time"`
+					BaseFee     uint64 `json:"base_fee"`
+					ReserveBase uint64 `json:"reserve_base"`
+					ReserveInc  uint64 `json:"reserve_inc"`
+				} `json:"validated_ledger"`
+			} `json:"state"`
+		}
+		if err := c.requestResultCtx(ctx, BaseRequest{"command": "server_state"}, &state); err != nil {
+			return nil, fmt.Errorf("waiting for ledger %d: %w", index, err)
+		}
+		if ledger := state.State.ValidatedLedger; ledger != nil && ledger.Seq >= index {
+			return &LedgerClosedEvent{
+				LedgerIndex:      ledger.Seq,
+				LedgerHash:       ledger.Hash,
+				LedgerTime:       ledger.CloseTime,
+				FeeBase:          ledger.BaseFee,
+				ReserveBase:      ledger.ReserveBase,
+				ReserveInc:       ledger.ReserveInc,
+				ValidatedLedgers: state.State.CompleteLedgers,
+				NetworkID:        state.State.NetworkID,
+			}, nil
+		}
+		if err := c.clock.sleep(ctx, waitForLedgerInterval); err != nil {
+			return nil, fmt.Errorf("waiting for ledger %d aborted: %w", index, err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package xrpl
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrTxExpired is returned by SubmitAndWait when the latest validated ledger
+// passes the transaction's LastLedgerSequence without including it. Such a
+// transaction can never be included in a ledger.
+var ErrTxExpired = errors.New("transaction expired: LastLedgerSequence passed without validation")
+
+// TxResult is the outcome of a transaction submitted with SubmitAndWait
+type TxResult struct {
+	Hash              string       // Transaction ID computed from the signed blob
+	EngineResult      string       // Preliminary result reported by submit
+	TransactionResult string       // Final result from the validated metadata
+	LedgerIndex       uint32       // Validated ledger that includes the transaction
+	Validated         bool         // Whether the result is final
+	Meta              BaseResponse // Metadata of the validated transaction
+	Result            BaseResponse // Result object of the tx command
+}
+
+// WaitOption configures SubmitAndWait
+type WaitOption func(*waitOptions)
+
+type waitOptions struct {
+	ctx          context.Context
+	pollInterval time.Duration
+}
+
+// WaitContext bounds SubmitAndWait by ctx. Once ctx is done, waiting stops
+// and the returned error wraps ctx.Err(); the transaction may still be
+// validated later.
+func WaitContext(ctx context.Context) WaitOption {
+	return func(o *waitOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WaitPollInterval sets how often SubmitAndWait looks the transaction up.
+// Default is 1 second.
+func WaitPollInterval(interval time.Duration) WaitOption {
+	return func(o *waitOptions) {
+		o.pollInterval = interval
+	}
+}
+
+// Result code prefixes of transactions that were not applied and will not be
+// included in any ledger
+var unappliedResultPrefixes = []string{"tem", "tef", "tel"}
+
+// SubmitAndWait autofills, signs and submits a transaction, then polls the tx
+// command until the transaction appears in a validated ledger. Missing
+// Sequence, Fee and LastLedgerSequence fields are filled in with Autofill. As
+// with SignAndSubmitRequest, seed must be the master or regular key of the
+// transaction's Account.
+//
+// A transaction rejected on submission with a tem, tef or tel result is
+// returned with an error right away. A transaction still not validated once
+// the latest validated ledger passes its LastLedgerSequence fails with
+// ErrTxExpired. Otherwise the validated result is returned; note that
+// TransactionResult may be a tec code, which means the transaction was
+// included in a ledger and charged a fee, but did not succeed.
+//
+// Example usage:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	res, err := client.SubmitAndWait(tx, seed, xrpl.WaitContext(ctx))
+//	if err == nil && res.TransactionResult == "tesSUCCESS" {
+//		// payment is final
+//	}
+func (c *Client) SubmitAndWait(tx map[string]interface{}, seed string, opts ...WaitOption) (*TxResult, error) {
+	options := waitOptions{
+		ctx:          context.Background(),
+		pollInterval: time.Second,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	ctx := options.ctx
+
+	if err := c.checkSigningKey(tx, seed); err != nil {
+		return nil, err
+	}
+	if err := c.Autofill(tx); err != nil {
+		return nil, err
+	}
+	var lastLedgerSequence uint32
+	if err := remarshal(tx["LastLedgerSequence"], &lastLedgerSequence); err != nil {
+		return nil, fmt.Errorf("invalid LastLedgerSequence: %w", err)
+	}
+
+	txBlob, err := signTransaction(tx, seed)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := HashSignedTx(txBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	var submitted struct {
+		EngineResult        string `json:"engine_result"`
+		EngineResultMessage string `json:"engine_result_message"`
+	}
+	submitReq := BaseRequest{
+		"command": "submit",
+		"tx_blob": strings.ToUpper(hex.EncodeToString(txBlob)),
+	}
+	if err := c.requestResultCtx(ctx, submitReq, &submitted); err != nil {
+		return nil, err
+	}
+	result := &TxResult{
+		Hash:         hash,
+		EngineResult: submitted.EngineResult,
+	}
+	for _, prefix := range unappliedResultPrefixes {
+		if strings.HasPrefix(submitted.EngineResult, prefix) {
+			return result, fmt.Errorf("transaction %s rejected: %s: %s", hash, submitted.EngineResult, submitted.EngineResultMessage)
+		}
+	}
+
+	for {
+		if err := c.clock.sleep(ctx, options.pollInterval); err != nil {
+			return result, fmt.Errorf("waiting for transaction %s aborted: %w", hash, err)
+		}
+
+		// The validated ledger is read before the lookup: a transaction
+		// missing from the lookup was then not in any ledger up to that
+		// index, while one validated in between is found by the lookup, or
+		// the next one, rather than reported as expired.
+		ledgerIndex, err := c.validatedLedgerIndex(ctx)
+		if errors.Is(err, ErrDisconnected) {
+			continue
+		}
+		if err != nil {
+			return result, err
+		}
+
+		validated, err := c.lookupValidatedTx(ctx, hash, result)
+		if err != nil {
+			return result, err
+		}
+		if validated {
+			return result, nil
+		}
+		if ledgerIndex > lastLedgerSequence {
+			return result, fmt.Errorf("transaction %s: %w", hash, ErrTxExpired)
+		}
+	}
+}
+
+// lookupValidatedTx queries the tx command for hash and fills in result when
+// the transaction is in a validated ledger. A transaction that is not found
+// yet, or a connection that drops while the client reconnects, is not an
+// error: the lookup reports false and is retried on the next poll.
+func (c *Client) lookupValidatedTx(ctx context.Context, hash string, result *TxResult) (bool, error) {
+	req := BaseRequest{
+		"command":     "tx",
+		"transaction": hash,
+	}
+	res, err := c.RequestCheckedCtx(ctx, req)
+	if errors.Is(err, ErrDisconnected) || errors.Is(err, ErrTxNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var tx struct {
+		Validated   bool         `json:"validated"`
+		LedgerIndex uint32       `json:"ledger_index"`
+		Meta        BaseResponse `json:"meta"`
+	}
+	if err := decodeResult(res, &tx); err != nil {
+		return false, err
+	}
+	if !tx.Validated {
+		return false, nil
+	}
+
+	result.Validated = true
+	result.LedgerIndex = tx.LedgerIndex
+	result.Meta = tx.Meta
+	result.TransactionResult, _ = tx.Meta["TransactionResult"].(string)
+	result.Result, _ = res["result"].(map[string]interface{})
+	return true, nil
+}
@@ -0,0 +1,99 @@
+package xrpl_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	xrpl "github.com/andreimerlescu/xrpl-go"
+	"github.com/andreimerlescu/xrpl-go/xrpltest"
+)
+
+const (
+	testSeed    = "sp5fghtJtpUorTwvof1NpDXAzNwf5"
+	testAccount = "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"
+)
+
+// waitMock answers SubmitAndWait's requests. The validated ledger starts at
+// validated; onLookup is called on every tx lookup with the number of the
+// lookup, starting at 1, and returns the response of the lookup.
+func waitMock(validated uint32, onLookup func(n int, ledger *uint32) xrpl.BaseResponse) *xrpltest.MockClient {
+	mock := xrpltest.NewMockClient()
+	var mutex sync.Mutex
+	lookups := 0
+	mock.Result("submit", map[string]interface{}{"engine_result": "tesSUCCESS"})
+	mock.Handle("ledger", func(xrpl.BaseRequest) (xrpl.BaseResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return xrpltest.Success(map[string]interface{}{"ledger_index": validated}), nil
+	})
+	mock.Handle("tx", func(xrpl.BaseRequest) (xrpl.BaseResponse, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		lookups++
+		return onLookup(lookups, &validated), nil
+	})
+	return mock
+}
+
+func waitTx(lastLedgerSequence uint32) map[string]interface{} {
+	return map[string]interface{}{
+		"TransactionType":    "Payment",
+		"Account":            testAccount,
+		"Destination":        "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+		"Amount":             "1000000",
+		"Fee":                "12",
+		"Sequence":           7,
+		"LastLedgerSequence": lastLedgerSequence,
+	}
+}
+
+func validatedTx(ledger uint32) xrpl.BaseResponse {
+	return xrpltest.Success(map[string]interface{}{
+		"validated":    true,
+		"ledger_index": ledger,
+		"meta":         map[string]interface{}{"TransactionResult": "tesSUCCESS"},
+	})
+}
+
+// A transaction validated in its LastLedgerSequence right after a lookup
+// missed it, while the validated ledger moves past LastLedgerSequence, must be
+// found rather than reported as expired
+func TestSubmitAndWaitValidatedInLastLedger(t *testing.T) {
+	const lastLedger = 100
+	mock := waitMock(lastLedger, func(n int, ledger *uint32) xrpl.BaseResponse {
+		if n == 1 {
+			// Validated in lastLedger, which closes once this lookup is done
+			*ledger = lastLedger + 1
+			return xrpltest.Error("txnNotFound", "Transaction not found.")
+		}
+		return validatedTx(lastLedger)
+	})
+
+	res, err := mock.SubmitAndWait(waitTx(lastLedger), testSeed, xrpl.WaitPollInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("SubmitAndWait: %v", err)
+	}
+	if !res.Validated || res.LedgerIndex != lastLedger || res.TransactionResult != "tesSUCCESS" {
+		t.Fatalf("got %+v, want validated tesSUCCESS in ledger %d", res, lastLedger)
+	}
+}
+
+func TestSubmitAndWaitExpired(t *testing.T) {
+	const lastLedger = 100
+	mock := waitMock(lastLedger-1, func(n int, ledger *uint32) xrpl.BaseResponse {
+		*ledger++
+		return xrpltest.Error("txnNotFound", "Transaction not found.")
+	})
+
+	_, err := mock.SubmitAndWait(waitTx(lastLedger), testSeed, xrpl.WaitPollInterval(time.Millisecond))
+	if !errors.Is(err, xrpl.ErrTxExpired) {
+		t.Fatalf("got %v, want ErrTxExpired", err)
+	}
+	// Expiry is decided from the ledger index read before a missed lookup:
+	// the lookups after reading ledgers 99, 100 and 101
+	if n := len(mock.RequestsFor("tx")); n != 3 {
+		t.Errorf("looked the transaction up %d times, want 3", n)
+	}
+}
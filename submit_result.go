@@ -0,0 +1,140 @@
+package xrpl
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// EngineResultCategory classifies the engine result of a submitted
+// transaction by what the submitter should do next
+type EngineResultCategory int
+
+const (
+	// EngineResultUnknown: the engine result has no known prefix
+	EngineResultUnknown EngineResultCategory = iota
+	// EngineResultSuccess (tes): the transaction applied provisionally and
+	// succeeds once validated
+	EngineResultSuccess
+	// EngineResultClaimed (tec): the transaction failed but is included in a
+	// ledger to claim its fee, and uses up its sequence number
+	EngineResultClaimed
+	// EngineResultRetry (ter): the transaction could not apply yet, e.g.
+	// terPRE_SEQ for a sequence number ahead of the account's, but may once
+	// the ledger changes. For terQUEUED it is already queued and will apply
+	// later; SubmitResult.Queued is set.
+	EngineResultRetry
+	// EngineResultPastSequence (tefPAST_SEQ): the sequence number has already
+	// been used. The transaction can succeed with a new Sequence, signed
+	// again.
+	EngineResultPastSequence
+	// EngineResultFailure (tef): the transaction failed and cannot succeed as
+	// signed, e.g. because of a LastLedgerSequence in the past
+	EngineResultFailure
+	// EngineResultLocal (tel): the server rejected the transaction without
+	// relaying it, e.g. telINSUF_FEE_P for a fee too low for its load. It may
+	// succeed with a higher fee or on another server.
+	EngineResultLocal
+	// EngineResultMalformed (tem): the transaction is invalid and can never
+	// succeed
+	EngineResultMalformed
+)
+
+func (k EngineResultCategory) String() string {
+	switch k {
+	case EngineResultSuccess:
+		return "success"
+	case EngineResultClaimed:
+		return "claimed"
+	case EngineResultRetry:
+		return "retry"
+	case EngineResultPastSequence:
+		return "past sequence"
+	case EngineResultFailure:
+		return "failure"
+	case EngineResultLocal:
+		return "local"
+	case EngineResultMalformed:
+		return "malformed"
+	}
+	return "unknown"
+}
+
+// ClassifyEngineResult returns the category of an engine result such as
+// "tesSUCCESS" or "tefPAST_SEQ"
+func ClassifyEngineResult(engineResult string) EngineResultCategory {
+	if engineResult == "tefPAST_SEQ" {
+		return EngineResultPastSequence
+	}
+	if len(engineResult) < 3 {
+		return EngineResultUnknown
+	}
+	switch engineResult[:3] {
+	case "tes":
+		return EngineResultSuccess
+	case "tec":
+		return EngineResultClaimed
+	case "ter":
+		return EngineResultRetry
+	case "tef":
+		return EngineResultFailure
+	case "tel":
+		return EngineResultLocal
+	case "tem":
+		return EngineResultMalformed
+	}
+	return EngineResultUnknown
+}
+
+// SubmitResult is the result of submitting a transaction, as returned by
+// SignAndSubmitRequest, SubmitBlob and SubmitMultisigned. The engine result
+// is preliminary: only a validated ledger makes it final, see SubmitAndWait.
+//
+// Example usage:
+//
+//	res, err := client.SignAndSubmitRequest(req, seed)
+//	if err != nil {
+//		return err
+//	}
+//	switch res.Category {
+//	case xrpl.EngineResultSuccess, xrpl.EngineResultRetry:
+//		fmt.Println("submitted", res.Hash)
+//	case xrpl.EngineResultPastSequence:
+//		// Autofill a new Sequence and sign again
+//	default:
+//		return fmt.Errorf("%s: %s", res.EngineResult, res.EngineResultMessage)
+//	}
+type SubmitResult struct {
+	EngineResult        string               `json:"engine_result"` // e.g. "tesSUCCESS"
+	EngineResultCode    int                  `json:"engine_result_code"`
+	EngineResultMessage string               `json:"engine_result_message"`
+	Category            EngineResultCategory `json:"-"`
+	Hash                string               `json:"-"`         // Transaction ID
+	Accepted            bool                 `json:"accepted"`  // Applied to the open ledger or queued
+	Applied             bool                 `json:"applied"`   // Applied to the open ledger
+	Broadcast           bool                 `json:"broadcast"` // Relayed to the network
+	Kept                bool                 `json:"kept"`      // Kept to be retried later
+	Queued              bool                 `json:"queued"`    // Queued for a later ledger
+	TxBlob              string               `json:"tx_blob"`
+	TxJSON              BaseResponse         `json:"tx_json"`
+	Response            BaseResponse         `json:"-"` // The full response to submit
+}
+
+// parseSubmitResult decodes the response res to the submit request req. A
+// server error is returned as an *XRPLError.
+func parseSubmitResult(req BaseRequest, res BaseResponse) (*SubmitResult, error) {
+	if err := checkResponse(req, res); err != nil {
+		return nil, err
+	}
+	var result SubmitResult
+	if err := decodeResult(res, &result); err != nil {
+		return nil, err
+	}
+	result.Category = ClassifyEngineResult(result.EngineResult)
+	if hash, ok := result.TxJSON["hash"].(string); ok {
+		result.Hash = strings.ToUpper(hash)
+	} else if blob, err := hex.DecodeString(result.TxBlob); err == nil && len(blob) > 0 {
+		result.Hash, _ = HashSignedTx(blob)
+	}
+	result.Response = res
+	return &result, nil
+}
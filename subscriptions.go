@@ -0,0 +1,422 @@
+package xrpl
+
+import "sync"
+
+// BookSpec identifies an order book to subscribe to. Only the currencies and
+// issuers of TakerGets and TakerPays are used, not their values.
+type BookSpec struct {
+	TakerGets Amount
+	TakerPays Amount
+	Taker     string // Account whose perspective offer funding is reported from
+	Snapshot  bool   // Send the current offers of the book once on subscribing
+	Both      bool   // Also subscribe to the opposite side of the book
+}
+
+func (b BookSpec) request() map[string]interface{} {
+	book := map[string]interface{}{
+		"taker_gets": bookCurrency(b.TakerGets),
+		"taker_pays": bookCurrency(b.TakerPays),
+	}
+	if b.Taker != "" {
+		book["taker"] = b.Taker
+	}
+	if b.Snapshot {
+		book["snapshot"] = true
+	}
+	if b.Both {
+		book["both"] = true
+	}
+	return book
+}
+
+// key identifies the book by its currencies, regardless of the other options
+func (b BookSpec) key() string {
+	return issueKey(b.TakerGets.Currency(), b.TakerGets.Issuer()) + "/" + issueKey(b.TakerPays.Currency(), b.TakerPays.Issuer())
+}
+
+// matches reports whether an offer taking takerGets for takerPays, both
+// given as issue keys, is in the book.
+func (b BookSpec) matches(takerGets, takerPays string) bool {
+	gets := issueKey(b.TakerGets.Currency(), b.TakerGets.Issuer())
+	pays := issueKey(b.TakerPays.Currency(), b.TakerPays.Issuer())
+	return (takerGets == gets && takerPays == pays) || (b.Both && takerGets == pays && takerPays == gets)
+}
+
+func issueKey(currency, issuer string) string {
+	if currency == XRPL_NATIVE_ASSET {
+		return currency
+	}
+	return currency + "." + issuer
+}
+
+// subscriptionHandler wraps a handler so that a handler registered for
+// several streams, accounts or books can be told apart and invoked once per
+// message. It holds the queue of messages awaiting the handler, which a
+// worker goroutine drains while it is not empty.
+type subscriptionHandler struct {
+	handle   func(BaseResponse)
+	messages chan BaseResponse
+	mutex    sync.Mutex // Guards running
+	running  bool
+}
+
+type bookSubscription struct {
+	spec     BookSpec
+	handlers []*subscriptionHandler
+}
+
+// SubscribeAccounts subscribes to validated transactions affecting any of
+// accounts and routes them to handler. A transaction affecting several of
+// the accounts is passed to handler once. Like stream subscriptions, account
+// subscriptions are re-established when the client reconnects.
+//
+// Example usage:
+//
+//	_, err := client.SubscribeAccounts([]string{"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}, func(msg xrpl.BaseResponse) {
+//		tx, _ := msg["transaction"].(map[string]interface{})
+//		fmt.Println(tx["TransactionType"], tx["hash"])
+//	})
+func (c *Client) SubscribeAccounts(accounts []string, handler func(msg BaseResponse)) (BaseResponse, error) {
+	return c.subscribeAccounts("accounts", c.accountSubscriptions, accounts, handler)
+}
+
+// UnsubscribeAccounts ends the subscriptions to accounts and removes their
+// handlers.
+func (c *Client) UnsubscribeAccounts(accounts []string) (BaseResponse, error) {
+	return c.unsubscribeAccounts("accounts", c.accountSubscriptions, accounts)
+}
+
+// SubscribeAccountsProposed subscribes to the transactions affecting any of
+// accounts as soon as they are proposed, before they are validated, and
+// routes them to handler. Each transaction is usually passed to handler
+// twice: when proposed, with "validated" false, and once validated, with
+// "validated" true. Proposed transactions may still fail or never make it
+// into a validated ledger. Account subscriptions are re-established when the
+// client reconnects.
+//
+// Example usage:
+//
+//	_, err := client.SubscribeAccountsProposed([]string{"rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn"}, func(msg xrpl.BaseResponse) {
+//		tx, _ := msg["transaction"].(map[string]interface{})
+//		fmt.Println(tx["hash"], msg["engine_result"], msg["validated"])
+//	})
+func (c *Client) SubscribeAccountsProposed(accounts []string, handler func(msg BaseResponse)) (BaseResponse, error) {
+	return c.subscribeAccounts("accounts_proposed", c.proposedAccountSubscriptions, accounts, handler)
+}
+
+// UnsubscribeAccountsProposed ends the subscriptions of
+// SubscribeAccountsProposed to accounts and removes their handlers.
+func (c *Client) UnsubscribeAccountsProposed(accounts []string) (BaseResponse, error) {
+	return c.unsubscribeAccounts("accounts_proposed", c.proposedAccountSubscriptions, accounts)
+}
+
+// subscribeAccounts subscribes to accounts under the request field key, which
+// is "accounts" or "accounts_proposed", registering handler in subs
+func (c *Client) subscribeAccounts(key string, subs map[string][]*subscriptionHandler, accounts []string, handler func(msg BaseResponse)) (BaseResponse, error) {
+	h := c.newSubscriptionHandler(handler)
+	c.mutex.Lock()
+	for _, account := range accounts {
+		subs[account] = append(subs[account], h)
+	}
+	c.mutex.Unlock()
+
+	res, err := c.Request(BaseRequest{
+		"command": "subscribe",
+		key:       accounts,
+	})
+	if err != nil {
+		c.removeAccountHandler(subs, accounts, h)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (c *Client) unsubscribeAccounts(key string, subs map[string][]*subscriptionHandler, accounts []string) (BaseResponse, error) {
+	res, err := c.Request(BaseRequest{
+		"command": "unsubscribe",
+		key:       accounts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	for _, account := range accounts {
+		delete(subs, account)
+	}
+	c.mutex.Unlock()
+	return res, nil
+}
+
+func (c *Client) removeAccountHandler(subs map[string][]*subscriptionHandler, accounts []string, h *subscriptionHandler) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, account := range accounts {
+		subs[account] = removeSubscriptionHandler(subs[account], h)
+		if len(subs[account]) == 0 {
+			delete(subs, account)
+		}
+	}
+}
+
+// SubscribeBooks subscribes to changes of the order books and routes the
+// transactions creating, changing or consuming offers in them to handler.
+// With Snapshot set, the current offers are returned in the response. Book
+// subscriptions are re-established when the client reconnects.
+//
+// Example usage:
+//
+//	usd, err := xrpl.NewTokenAmount("USD", "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "0")
+//	if err != nil {
+//		return err
+//	}
+//	_, err = client.SubscribeBooks([]xrpl.BookSpec{{
+//		TakerGets: xrpl.XRPAmount("0"),
+//		TakerPays: usd,
+//		Both:      true,
+//	}}, func(msg xrpl.BaseResponse) {
+//		fmt.Println(msg["transaction"])
+//	})
+func (c *Client) SubscribeBooks(books []BookSpec, handler func(msg BaseResponse)) (BaseResponse, error) {
+	h := c.newSubscriptionHandler(handler)
+	c.mutex.Lock()
+	for _, book := range books {
+		sub, ok := c.bookSubscriptions[book.key()]
+		if !ok {
+			sub = &bookSubscription{}
+			c.bookSubscriptions[book.key()] = sub
+		}
+		sub.spec = book
+		sub.handlers = append(sub.handlers, h)
+	}
+	c.mutex.Unlock()
+
+	res, err := c.Request(BaseRequest{
+		"command": "subscribe",
+		"books":   bookRequests(books),
+	})
+	if err != nil {
+		c.removeBookHandler(books, h)
+		return nil, err
+	}
+	return res, nil
+}
+
+// UnsubscribeBooks ends the subscriptions to books and removes their
+// handlers. Books are matched by their currencies and issuers.
+func (c *Client) UnsubscribeBooks(books []BookSpec) (BaseResponse, error) {
+	res, err := c.Request(BaseRequest{
+		"command": "unsubscribe",
+		"books":   bookRequests(books),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	for _, book := range books {
+		delete(c.bookSubscriptions, book.key())
+	}
+	c.mutex.Unlock()
+	return res, nil
+}
+
+func (c *Client) removeBookHandler(books []BookSpec, h *subscriptionHandler) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, book := range books {
+		sub, ok := c.bookSubscriptions[book.key()]
+		if !ok {
+			continue
+		}
+		sub.handlers = removeSubscriptionHandler(sub.handlers, h)
+		if len(sub.handlers) == 0 {
+			delete(c.bookSubscriptions, book.key())
+		}
+	}
+}
+
+func bookRequests(books []BookSpec) []map[string]interface{} {
+	requests := make([]map[string]interface{}, 0, len(books))
+	for _, book := range books {
+		requests = append(requests, book.request())
+	}
+	return requests
+}
+
+func removeSubscriptionHandler(handlers []*subscriptionHandler, h *subscriptionHandler) []*subscriptionHandler {
+	kept := handlers[:0]
+	for _, handler := range handlers {
+		if handler != h {
+			kept = append(kept, handler)
+		}
+	}
+	return kept
+}
+
+// resubscribeAccountsAndBooks re-establishes account and book subscriptions
+// after a reconnect. Book snapshots are not requested again.
+func (c *Client) resubscribeAccountsAndBooks() {
+	c.mutex.Lock()
+	accounts := make([]string, 0, len(c.accountSubscriptions))
+	for account := range c.accountSubscriptions {
+		accounts = append(accounts, account)
+	}
+	proposedAccounts := make([]string, 0, len(c.proposedAccountSubscriptions))
+	for account := range c.proposedAccountSubscriptions {
+		proposedAccounts = append(proposedAccounts, account)
+	}
+	books := make([]BookSpec, 0, len(c.bookSubscriptions))
+	for _, sub := range c.bookSubscriptions {
+		spec := sub.spec
+		spec.Snapshot = false
+		books = append(books, spec)
+	}
+	c.mutex.Unlock()
+
+	if len(accounts) == 0 && len(proposedAccounts) == 0 && len(books) == 0 {
+		return
+	}
+	req := BaseRequest{"command": "subscribe"}
+	if len(accounts) > 0 {
+		req["accounts"] = accounts
+	}
+	if len(proposedAccounts) > 0 {
+		req["accounts_proposed"] = proposedAccounts
+	}
+	if len(books) > 0 {
+		req["books"] = bookRequests(books)
+	}
+	if _, err := c.Request(req); err != nil {
+		c.logger.Errorf("WS account and book subscription error: %s", err)
+	}
+}
+
+// transactionHandlers returns the account and book handlers that a
+// transaction stream message is routed to, each handler at most once.
+// Proposed transactions, those with "validated" false, only go to the
+// handlers of SubscribeAccountsProposed. The caller must hold c.mutex.
+func (c *Client) transactionHandlers(m BaseResponse) []*subscriptionHandler {
+	if len(c.accountSubscriptions) == 0 && len(c.proposedAccountSubscriptions) == 0 && len(c.bookSubscriptions) == 0 {
+		return nil
+	}
+	validated := m["validated"] != false
+
+	seen := make(map[*subscriptionHandler]bool)
+	var handlers []*subscriptionHandler
+	add := func(hs []*subscriptionHandler) {
+		for _, h := range hs {
+			if !seen[h] {
+				seen[h] = true
+				handlers = append(handlers, h)
+			}
+		}
+	}
+
+	if len(c.accountSubscriptions) > 0 || len(c.proposedAccountSubscriptions) > 0 {
+		for account := range affectedAccounts(m) {
+			if validated {
+				add(c.accountSubscriptions[account])
+			}
+			add(c.proposedAccountSubscriptions[account])
+		}
+	}
+	if validated && len(c.bookSubscriptions) > 0 {
+		for _, offer := range affectedOffers(m) {
+			for _, sub := range c.bookSubscriptions {
+				if sub.spec.matches(offer[0], offer[1]) {
+					add(sub.handlers)
+				}
+			}
+		}
+	}
+	return handlers
+}
+
+// affectedAccounts returns the accounts a transaction stream message
+// concerns, as rippled determines them: every AccountID field of the
+// transaction and of the ledger objects it affected, and the issuers of the
+// trust line limits and offer amounts among those objects.
+func affectedAccounts(m BaseResponse) map[string]bool {
+	accounts := make(map[string]bool)
+	addAccountFields := func(fields map[string]interface{}) {
+		for name, value := range fields {
+			field, ok := definitions.fields[name]
+			if !ok {
+				continue
+			}
+			switch {
+			case field.Type == "AccountID":
+				if account, ok := value.(string); ok {
+					accounts[account] = true
+				}
+			case name == "LowLimit" || name == "HighLimit" || name == "TakerGets" || name == "TakerPays":
+				amount, _ := value.(map[string]interface{})
+				if issuer, ok := amount["issuer"].(string); ok {
+					accounts[issuer] = true
+				}
+			}
+		}
+	}
+
+	tx, _ := m["transaction"].(map[string]interface{})
+	addAccountFields(tx)
+	for _, fields := range affectedNodeObjects(m) {
+		addAccountFields(fields)
+	}
+	return accounts
+}
+
+// affectedOffers returns the TakerGets and TakerPays issue keys of the offers
+// a transaction stream message created, modified or deleted.
+func affectedOffers(m BaseResponse) [][2]string {
+	var offers [][2]string
+	for _, fields := range affectedNodeObjects(m) {
+		if fields["LedgerEntryType"] != LedgerEntryTypeOffer {
+			continue
+		}
+		gets, okGets := amountIssueKey(fields["TakerGets"])
+		pays, okPays := amountIssueKey(fields["TakerPays"])
+		if okGets && okPays {
+			offers = append(offers, [2]string{gets, pays})
+		}
+	}
+	return offers
+}
+
+// affectedNodeObjects returns the fields of every node in the metadata of a
+// transaction stream message, along with its LedgerEntryType: the final
+// fields of modified and deleted nodes, and the new fields of created nodes.
+func affectedNodeObjects(m BaseResponse) []map[string]interface{} {
+	meta, _ := m["meta"].(map[string]interface{})
+	nodes, _ := meta["AffectedNodes"].([]interface{})
+	objects := make([]map[string]interface{}, 0, len(nodes))
+	for _, n := range nodes {
+		node, err := affectedNodeFields(n)
+		if err != nil || node.final == nil {
+			continue
+		}
+		fields := make(map[string]interface{}, len(node.final)+1)
+		for k, v := range node.final {
+			fields[k] = v
+		}
+		fields["LedgerEntryType"] = node.entryType
+		objects = append(objects, fields)
+	}
+	return objects
+}
+
+// amountIssueKey returns the issue key of an amount given as a drops string
+// or a token amount object.
+func amountIssueKey(v interface{}) (string, bool) {
+	switch amount := v.(type) {
+	case string:
+		return XRPL_NATIVE_ASSET, true
+	case map[string]interface{}:
+		currency, _ := amount["currency"].(string)
+		issuer, _ := amount["issuer"].(string)
+		return issueKey(currency, issuer), currency != ""
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,81 @@
+package xrpl_test
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	xrpl "github.com/andreimerlescu/xrpl-go"
+	"github.com/andreimerlescu/xrpl-go/xrpltest"
+)
+
+// Run with -race: subscriptions change while another goroutine reads them
+func TestConcurrentSubscriptions(t *testing.T) {
+	mock := xrpltest.NewMockClient()
+	streams := []string{
+		xrpl.StreamTypeLedger,
+		xrpl.StreamTypeTransaction,
+		xrpl.StreamTypeValidations,
+		xrpl.StreamTypeManifests,
+		xrpl.StreamTypePeerStatus,
+		xrpl.StreamTypeConsensus,
+	}
+
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, stream := range mock.Subscriptions() {
+				mock.IsSubscribed(stream)
+			}
+		}
+	}()
+
+	var writers sync.WaitGroup
+	for i, stream := range streams {
+		writers.Add(1)
+		go func(i int, stream string) {
+			defer writers.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := mock.Subscribe([]string{stream}); err != nil {
+					t.Error(err)
+					return
+				}
+				if _, err := mock.Unsubscribe([]string{stream}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+			// Every other stream stays subscribed
+			if i%2 == 0 {
+				if _, err := mock.Subscribe([]string{stream}); err != nil {
+					t.Error(err)
+				}
+			}
+		}(i, stream)
+	}
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	got := mock.Subscriptions()
+	sort.Strings(got)
+	want := []string{streams[0], streams[2], streams[4]}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subscribed to %v, want %v", got, want)
+	}
+	for i, stream := range streams {
+		if mock.IsSubscribed(stream) != (i%2 == 0) {
+			t.Errorf("IsSubscribed(%s) = %v", stream, !(i%2 == 0))
+		}
+	}
+}
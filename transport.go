@@ -0,0 +1,228 @@
+package xrpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Transport delivers a request to a rippled server and returns its response
+// in the websocket API's shape, i.e. with "status", "type" and "result" at the
+// top level and errors reported through the "error" fields.
+type Transport interface {
+	Send(ctx context.Context, req BaseRequest) (BaseResponse, error)
+}
+
+// ErrInvalidRequest is returned for requests that cannot be sent as given:
+// without a command, or with values that do not encode to JSON. Such requests
+// fail on any server.
+var ErrInvalidRequest = errors.New("invalid request")
+
+// newTransport picks the transport for config: config.Transport when set,
+// JSON-RPC over HTTP for http(s) URLs and websocket otherwise.
+func newTransport(c *Client) Transport {
+	if c.config.Transport != nil {
+		return c.config.Transport
+	}
+	if isHTTPURL(c.config.URL) {
+		return newHTTPTransport(c.config, c.logger, c.dial)
+	}
+	return &wsTransport{client: c}
+}
+
+func isHTTPURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// wsTransport sends requests over the client's websocket connection and
+// matches responses to requests by their id in the client's request queue.
+type wsTransport struct {
+	client *Client
+}
+
+func (t *wsTransport) Send(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	c := t.client
+	ch := make(chan BaseResponse, 1)
+
+	c.mutex.Lock()
+	if c.closed {
+		c.mutex.Unlock()
+		return nil, ErrClientClosed
+	}
+	if c.connection == nil {
+		c.mutex.Unlock()
+		return nil, ErrDisconnected
+	}
+	// Skip IDs still awaiting a response should the counter ever wrap around
+	requestId := c.NextID()
+	for c.requestQueue[requestId] != nil {
+		requestId = c.NextID()
+	}
+	req["id"] = requestId
+	data, err := json.Marshal(req)
+	if err != nil {
+		c.mutex.Unlock()
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+	c.requestQueue[requestId] = ch
+	err = c.connection.WriteMessage(websocket.TextMessage, data)
+	if err != nil {
+		delete(c.requestQueue, requestId)
+		c.mutex.Unlock()
+		return nil, err
+	}
+	c.mutex.Unlock()
+	command, _ := req["command"].(string)
+	c.logger.Debugf("WS request %s %s", requestId, command)
+
+	select {
+	case res, ok := <-ch:
+		if !ok {
+			if c.isClosed() {
+				return nil, ErrClientClosed
+			}
+			c.logger.Debugf("WS request %s %s failed: %s", requestId, command, ErrDisconnected)
+			return nil, ErrDisconnected
+		}
+		c.logger.Debugf("WS response %s %s %v", requestId, command, res["status"])
+		return res, nil
+	case <-ctx.Done():
+		c.mutex.Lock()
+		delete(c.requestQueue, requestId)
+		c.mutex.Unlock()
+		c.logger.Debugf("WS request %s %s aborted: %s", requestId, command, ctx.Err())
+		return nil, fmt.Errorf("request %s aborted: %w", requestId, ctx.Err())
+	}
+}
+
+// httpTransport sends requests to rippled's JSON-RPC endpoint. The websocket
+// request {"command": "x", ...params} is sent as {"method": "x", "params":
+// [{...params}]} and the JSON-RPC response {"result": {...}} is translated
+// back into the websocket response shape.
+type httpTransport struct {
+	url            string
+	authorization  string
+	header         http.Header
+	httpClient     *http.Client
+	logger         Logger
+	maxMessageSize int64 // Largest response body read, in bytes
+}
+
+func newHTTPTransport(config ClientConfig, logger Logger, dial dialOptions) *httpTransport {
+	return &httpTransport{
+		url:            config.URL,
+		authorization:  config.Authorization,
+		header:         dial.header,
+		logger:         logger,
+		maxMessageSize: dial.maxMessageSize,
+		httpClient: &http.Client{
+			Transport: dial.httpTransport(),
+			Timeout:   (config.WriteTimeout + config.ReadTimeout) * time.Second,
+		},
+	}
+}
+
+// Fields of a JSON-RPC result that the websocket API places at the top level
+// of the response rather than inside "result"
+var jsonRPCTopLevelFields = []string{
+	"status",
+	"error",
+	"error_code",
+	"error_message",
+	"error_exception",
+	"request",
+	"warnings",
+}
+
+func (t *httpTransport) Send(ctx context.Context, req BaseRequest) (BaseResponse, error) {
+	method, ok := req["command"].(string)
+	if !ok || method == "" {
+		return nil, fmt.Errorf("%w: missing a command", ErrInvalidRequest)
+	}
+	params := make(map[string]interface{}, len(req))
+	for k, v := range req {
+		if k == "command" || k == "id" {
+			continue
+		}
+		params[k] = v
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"method": method,
+		"params": []interface{}{params},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRequest, err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range t.header {
+		httpReq.Header[key] = values
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if t.authorization != "" {
+		httpReq.Header.Set("Authorization", t.authorization)
+	}
+
+	t.logger.Debugf("HTTP request %s", method)
+	httpRes, err := t.httpClient.Do(httpReq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request %s aborted: %w", method, ctx.Err())
+		}
+		return nil, err
+	}
+	defer httpRes.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpRes.Body, t.maxMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > t.maxMessageSize {
+		return nil, fmt.Errorf("json-rpc response to %s larger than %d bytes", method, t.maxMessageSize)
+	}
+	if httpRes.StatusCode < 200 || httpRes.StatusCode > 299 {
+		return nil, fmt.Errorf("json-rpc request %s failed: %s: %s", method, httpRes.Status, strings.TrimSpace(string(body)))
+	}
+
+	var rpc struct {
+		Result map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &rpc); err != nil {
+		return nil, fmt.Errorf("failed to decode json-rpc response: %w", err)
+	}
+	if rpc.Result == nil {
+		return nil, fmt.Errorf("json-rpc response is missing a result")
+	}
+
+	res := BaseResponse{"type": StreamTypeResponse}
+	if id, ok := req["id"]; ok {
+		res["id"] = id
+	}
+	for _, field := range jsonRPCTopLevelFields {
+		if v, ok := rpc.Result[field]; ok {
+			res[field] = v
+			delete(rpc.Result, field)
+		}
+	}
+	if res["status"] != "error" {
+		res["result"] = rpc.Result
+	}
+	t.logger.Debugf("HTTP response %s %v", method, res["status"])
+	return res, nil
+}
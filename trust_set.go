@@ -0,0 +1,110 @@
+package xrpl
+
+import "fmt"
+
+// TrustSetOption configures BuildTrustSet
+type TrustSetOption func(*trustSetOptions)
+
+type trustSetOptions struct {
+	flags      uint32
+	qualityIn  *uint32
+	qualityOut *uint32
+}
+
+// TrustSetNoRipple enables, TfSetNoRipple, or disables, TfClearNoRipple,
+// rippling through the trust line on the sender's side
+func TrustSetNoRipple(noRipple bool) TrustSetOption {
+	return func(o *trustSetOptions) {
+		o.flags &^= TfSetNoRipple | TfClearNoRipple
+		if noRipple {
+			o.flags |= TfSetNoRipple
+		} else {
+			o.flags |= TfClearNoRipple
+		}
+	}
+}
+
+// TrustSetAuthorize authorizes the other party to hold tokens issued by the
+// sender, TfSetfAuth. It applies to issuers requiring authorized trust lines.
+func TrustSetAuthorize() TrustSetOption {
+	return func(o *trustSetOptions) {
+		o.flags |= TfSetfAuth
+	}
+}
+
+// TrustSetFreeze freezes, TfSetFreeze, or unfreezes, TfClearFreeze, the
+// trust line
+func TrustSetFreeze(freeze bool) TrustSetOption {
+	return func(o *trustSetOptions) {
+		o.flags &^= TfSetFreeze | TfClearFreeze
+		if freeze {
+			o.flags |= TfSetFreeze
+		} else {
+			o.flags |= TfClearFreeze
+		}
+	}
+}
+
+// TrustSetQualityIn values incoming balances on the trust line at the ratio
+// quality to 1,000,000,000, e.g. 1010000000 for 1.01. 0 resets the default
+// of face value.
+func TrustSetQualityIn(quality uint32) TrustSetOption {
+	return func(o *trustSetOptions) {
+		o.qualityIn = &quality
+	}
+}
+
+// TrustSetQualityOut values outgoing balances on the trust line at the ratio
+// quality to 1,000,000,000. 0 resets the default of face value.
+func TrustSetQualityOut(quality uint32) TrustSetOption {
+	return func(o *trustSetOptions) {
+		o.qualityOut = &quality
+	}
+}
+
+// BuildTrustSet builds a TrustSet transaction from account, ready to be
+// autofilled and signed, that creates or modifies the trust line for the
+// token currency issued by issuer to hold at most limit. A limit of "0"
+// removes a trust line that has no balance and default settings.
+//
+// Example usage:
+//
+//	tx, err := xrpl.BuildTrustSet(
+//		wallet.ClassicAddress,
+//		"USD",
+//		"rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B",
+//		"1000",
+//		xrpl.TrustSetNoRipple(true))
+//	if err != nil {
+//		return err
+//	}
+//	res, err := client.SignAndSubmitRequest(tx, seed)
+func BuildTrustSet(account, currency, issuer, limit string, opts ...TrustSetOption) (map[string]interface{}, error) {
+	var options trustSetOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	limitAmount, err := NewTokenAmount(currency, issuer, limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trust line limit: %w", err)
+	}
+	if negative, _, _, _ := parseDecimal(limit); negative {
+		return nil, fmt.Errorf("invalid trust line limit %s: must not be negative", limit)
+	}
+
+	tx := map[string]interface{}{
+		"TransactionType": string(TxTypeTrustSet),
+		"Account":         account,
+		"LimitAmount":     limitAmount,
+	}
+	if options.flags != 0 {
+		tx["Flags"] = FlagsFor(TxTypeTrustSet).Set(options.flags)
+	}
+	if options.qualityIn != nil {
+		tx["QualityIn"] = *options.qualityIn
+	}
+	if options.qualityOut != nil {
+		tx["QualityOut"] = *options.qualityOut
+	}
+	return tx, nil
+}
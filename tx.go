@@ -0,0 +1,148 @@
+package xrpl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// TxOption configures a tx request
+type TxOption func(req BaseRequest)
+
+// TxBinary returns the transaction and its metadata as hex blobs, in the
+// TxBlob and MetaBlob fields of the result
+func TxBinary(binary bool) TxOption {
+	return func(req BaseRequest) {
+		req["binary"] = binary
+	}
+}
+
+// TxLedgerRange tells the server the transaction can only be in ledgers min
+// to max. When it is not found, the server then reports whether it has every
+// ledger of the range, in TxNotFoundError.SearchedAll. The range may span at
+// most 1000 ledgers.
+func TxLedgerRange(min, max uint32) TxOption {
+	return func(req BaseRequest) {
+		req["min_ledger"] = min
+		req["max_ledger"] = max
+	}
+}
+
+// TxNotFoundError is returned by Tx for a transaction the server does not
+// have. It matches ErrTxNotFound with errors.Is.
+type TxNotFoundError struct {
+	Hash string
+	// SearchedAll reports, for lookups with TxLedgerRange, that the server
+	// has every ledger of the range, so the transaction is in none of them.
+	// Otherwise, e.g. on a server with pruned history, it may still exist.
+	SearchedAll bool
+	Err         *XRPLError
+}
+
+func (e *TxNotFoundError) Error() string {
+	if e.SearchedAll {
+		return fmt.Sprintf("transaction %s not found in the ledger range", e.Hash)
+	}
+	return fmt.Sprintf("transaction %s not found", e.Hash)
+}
+
+func (e *TxNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// Retrieve a transaction by its ID, together with its metadata. The
+// transaction may be in a ledger that is not validated yet, as reported by
+// Validated, in which case its outcome is not final. A transaction the server
+// does not have fails with a *TxNotFoundError.
+//
+// Example usage:
+//
+//	tx, err := client.Tx(hash, xrpl.TxLedgerRange(minLedger, maxLedger))
+//	var notFound *xrpl.TxNotFoundError
+//	if errors.As(err, &notFound) && notFound.SearchedAll {
+//		// The transaction is not in any ledger of the range
+//	}
+//	if err != nil {
+//		return err
+//	}
+//	if tx.Validated {
+//		fmt.Println(tx.LedgerIndex, tx.Meta.TransactionResult)
+//	}
+func (c *Client) Tx(txid string, opts ...TxOption) (*TxWithMeta, error) {
+	req := BaseRequest{
+		"command":     "tx",
+		"transaction": txid,
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	res, err := c.RequestChecked(req)
+	var xrplErr *XRPLError
+	if errors.As(err, &xrplErr) && xrplErr.Err == "txnNotFound" {
+		return nil, &TxNotFoundError{Hash: txid, SearchedAll: searchedAll(xrplErr), Err: xrplErr}
+	}
+	if err != nil {
+		return nil, err
+	}
+	result, ok := res["result"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("response has no result")
+	}
+	tx, err := txLookupEntry(result)
+	if err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// txLookupEntry decodes the result of the tx command. API v1 lists the
+// transaction fields alongside hash, meta and validated, or the blobs as tx
+// and meta for binary requests; API v2 lists them as tx_json, or tx_blob and
+// meta_blob.
+func txLookupEntry(result map[string]interface{}) (TxWithMeta, error) {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return TxWithMeta{}, err
+	}
+	var fields struct {
+		accountTxEntry
+		Tx       json.RawMessage `json:"tx"`
+		MetaBlob string          `json:"meta_blob"`
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return TxWithMeta{}, fmt.Errorf("invalid tx result: %w", err)
+	}
+
+	entry := fields.accountTxEntry
+	switch {
+	case len(fields.Tx) > 0 && fields.Tx[0] == '"':
+		if err := json.Unmarshal(fields.Tx, &entry.TxBlob); err != nil {
+			return TxWithMeta{}, fmt.Errorf("invalid tx result: %w", err)
+		}
+	case entry.TxJSON == nil && entry.TxBlob == "":
+		entry.Tx = make(BaseResponse, len(result))
+		for k, v := range result {
+			switch k {
+			case "meta", "validated", "status", "warnings":
+				continue
+			}
+			entry.Tx[k] = v
+		}
+	}
+	if fields.MetaBlob != "" {
+		entry.Meta, _ = json.Marshal(fields.MetaBlob)
+	}
+	return entry.txWithMeta()
+}
+
+// searchedAll reports the searched_all field of a txnNotFound error, set
+// when the request gave a ledger range
+func searchedAll(e *XRPLError) bool {
+	if searched, ok := e.Response["searched_all"].(bool); ok {
+		return searched
+	}
+	result, _ := e.Response["result"].(map[string]interface{})
+	searched, _ := result["searched_all"].(bool)
+	return searched
+}
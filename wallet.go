@@ -0,0 +1,125 @@
+package xrpl
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Wallet holds an XRPL keypair together with the family seed it was derived
+// from and the classic address of the account it controls.
+type Wallet struct {
+	Seed           string  // base58 family seed
+	PublicKey      string  // hex encoded, as used in SigningPubKey
+	PrivateKey     []byte  // see DecodeSeed for the format of each key type
+	ClassicAddress string  // account address derived from PublicKey
+	KeyType        KeyType // signing algorithm of the keypair
+}
+
+// GenerateWallet creates a wallet for a new account from 16 bytes of random
+// entropy, using the signing algorithm of keyType.
+func GenerateWallet(keyType KeyType) (*Wallet, error) {
+	entropy := make([]byte, familySeedLength)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, fmt.Errorf("failed to generate seed entropy: %w", err)
+	}
+
+	seed, err := EncodeSeed(entropy, keyType)
+	if err != nil {
+		return nil, err
+	}
+	return WalletFromSeed(seed)
+}
+
+// WalletFromSeed derives the wallet of an existing family seed. The key type is
+// detected from the seed, see DecodeSeed.
+func WalletFromSeed(seed string) (*Wallet, error) {
+	privateKey, keyType, err := DecodeSeed(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey := publicKeyFor(privateKey, keyType)
+	address, err := DeriveAddress(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Wallet{
+		Seed:           seed,
+		PublicKey:      strings.ToUpper(hex.EncodeToString(publicKey)),
+		PrivateKey:     privateKey,
+		ClassicAddress: address,
+		KeyType:        keyType,
+	}, nil
+}
+
+// Sign signs tx with the wallet's keys and returns the signed transaction
+// blob in hex, ready for SubmitBlob, and its transaction ID. No network access
+// is needed, so every field must be set beforehand, as Autofill would. tx
+// itself is not modified. The wallet must hold the master or regular key of
+// the transaction's Account for the network to accept the signature.
+//
+// Example usage:
+//
+//	wallet, err := xrpl.WalletFromMnemonic(mnemonic, "", 0)
+//	if err != nil {
+//		return err
+//	}
+//	blob, txid, err := wallet.Sign(tx)
+func (w *Wallet) Sign(tx map[string]interface{}) (signedBlob string, txid string, err error) {
+	signed := make(map[string]interface{}, len(tx)+2)
+	for k, v := range tx {
+		signed[k] = v
+	}
+	blob, err := w.signInPlace(signed)
+	if err != nil {
+		return "", "", err
+	}
+	txid, err = HashSignedTx(blob)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(blob)), txid, nil
+}
+
+// SignMessage signs message with the wallet's private key and returns the
+// signature in hex, which Verify checks against the wallet's PublicKey.
+// ed25519 signs the message itself, secp256k1 its SHA-512Half hash.
+func (w *Wallet) SignMessage(message []byte) (string, error) {
+	if len(w.PrivateKey) == 0 {
+		return "", fmt.Errorf("wallet %s has no private key", w.ClassicAddress)
+	}
+	return strings.ToUpper(hex.EncodeToString(signWithKey(w.PrivateKey, w.KeyType, message))), nil
+}
+
+// signInPlace sets the SigningPubKey and TxnSignature fields of tx for the
+// wallet's keys and returns the serialized signed transaction
+func (w *Wallet) signInPlace(tx map[string]interface{}) ([]byte, error) {
+	if len(w.PrivateKey) == 0 {
+		return nil, fmt.Errorf("wallet %s has no private key", w.ClassicAddress)
+	}
+	return signTransactionWithKey(tx, w.PrivateKey, w.KeyType)
+}
+
+// EncodeSeed encodes 16 bytes of entropy as a family seed for keyType
+func EncodeSeed(entropy []byte, keyType KeyType) (string, error) {
+	if len(entropy) != familySeedLength {
+		return "", fmt.Errorf("seed entropy must be %d bytes", familySeedLength)
+	}
+
+	var prefix []byte
+	switch keyType {
+	case KeyTypeEd25519:
+		prefix = ed25519SeedPrefix
+	case KeyTypeSecp256k1:
+		prefix = familySeedPrefix
+	default:
+		return "", fmt.Errorf("unsupported key type %s", keyType)
+	}
+
+	b58 := NewBase58()
+	payload := append(append([]byte{}, prefix[1:]...), entropy...)
+	return b58.EncodeCheck(prefix[0], payload), nil
+}
@@ -0,0 +1,59 @@
+package xrpl
+
+// Warning IDs rippled reports in the warnings of a response
+const (
+	WarningUnsupportedMajority  = 1001 // an amendment the server does not support is close to activation
+	WarningAmendmentBlocked     = 1002 // the server is amendment blocked and cannot tell the state of the ledger
+	WarningExpiredValidatorList = 1003 // the server's validator list has expired
+	WarningReportingMode        = 1004 // the server is a reporting node, serving data that may be stale
+)
+
+// Warning is an entry of the warnings of a response, telling about the state
+// of the server rather than the request
+type Warning struct {
+	ID      int                    `json:"id"` // e.g. WarningAmendmentBlocked
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// Warnings returns the warnings the server attached to the response, or nil
+// if there are none or they cannot be parsed
+//
+// Example usage:
+//
+//	res, err := client.Request(xrpl.BaseRequest{"command": "server_info"})
+//	if err != nil {
+//		return err
+//	}
+//	for _, warning := range res.Warnings() {
+//		fmt.Println(warning.ID, warning.Message)
+//	}
+func (r BaseResponse) Warnings() []Warning {
+	raw, ok := r["warnings"].([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+	var warnings []Warning
+	if err := remarshal(raw, &warnings); err != nil {
+		return nil
+	}
+	return warnings
+}
+
+// WithWarningHandler calls handler with the warnings of every response that
+// carries some, e.g. to report a server that is amendment blocked. It is
+// called on the goroutine that sent the request, before the response is
+// returned, so it should return quickly.
+//
+// Example usage:
+//
+//	client := xrpl.NewClient(config, xrpl.WithWarningHandler(func(warnings []xrpl.Warning) {
+//		for _, warning := range warnings {
+//			log.Printf("rippled warning %d: %s", warning.ID, warning.Message)
+//		}
+//	}))
+func WithWarningHandler(handler func([]Warning)) ClientOption {
+	return func(c *Client) {
+		c.warningHandler = handler
+	}
+}
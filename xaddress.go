@@ -0,0 +1,90 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+/*
+ * X-address (tagged address) encoding
+ * https://github.com/XRPLF/XRPL-Standards/issues/6
+ */
+
+var (
+	xAddressMainnetPrefix = []byte{0x05, 0x44}
+	xAddressTestnetPrefix = []byte{0x04, 0x93}
+)
+
+// EncodeXAddress packs a classic address and an optional destination tag into
+// an X-address. A nil tag means no tag, which is distinct from a tag of zero.
+// Mainnet X-addresses start with 'X', test network ones with 'T'.
+func EncodeXAddress(classic string, tag *uint32, test bool) (string, error) {
+	accountID, err := decodeAccountID(classic)
+	if err != nil {
+		return "", err
+	}
+
+	prefix := xAddressMainnetPrefix
+	if test {
+		prefix = xAddressTestnetPrefix
+	}
+
+	// prefix (2) + account ID (20) + tag flag (1) + tag (4) + reserved (4)
+	payload := make([]byte, 0, 31)
+	payload = append(payload, prefix...)
+	payload = append(payload, accountID...)
+	tagBytes := make([]byte, 9)
+	if tag != nil {
+		tagBytes[0] = 1
+		binary.LittleEndian.PutUint32(tagBytes[1:5], *tag)
+	}
+	payload = append(payload, tagBytes...)
+
+	b58 := NewBase58()
+	return b58.EncodeCheck(payload[0], payload[1:]), nil
+}
+
+// DecodeXAddress unpacks an X-address into its classic address, destination
+// tag and network. The tag is nil when the X-address carries no tag.
+func DecodeXAddress(x string) (classic string, tag *uint32, test bool, err error) {
+	b58 := NewBase58()
+	decoded, err := b58.decodeChecked(x)
+	if err != nil {
+		return "", nil, false, fmt.Errorf("invalid X-address: %w", err)
+	}
+	if len(decoded) != 31 {
+		return "", nil, false, fmt.Errorf("invalid X-address length")
+	}
+
+	switch {
+	case bytes.HasPrefix(decoded, xAddressMainnetPrefix):
+		test = false
+	case bytes.HasPrefix(decoded, xAddressTestnetPrefix):
+		test = true
+	default:
+		return "", nil, false, fmt.Errorf("invalid X-address prefix")
+	}
+
+	accountID := decoded[2:22]
+	flag := decoded[22]
+	value := binary.LittleEndian.Uint64(decoded[23:31])
+	switch {
+	case flag == 0 && value == 0:
+		tag = nil
+	case flag == 1 && value <= 0xFFFFFFFF:
+		t := uint32(value)
+		tag = &t
+	default:
+		return "", nil, false, fmt.Errorf("invalid X-address tag")
+	}
+
+	classic = b58.EncodeCheck(accountIDPrefix[0], accountID)
+	return classic, tag, test, nil
+}
+
+// IsValidXAddress reports whether x is a well formed X-address
+func IsValidXAddress(x string) bool {
+	_, _, _, err := DecodeXAddress(x)
+	return err == nil
+}
@@ -0,0 +1,101 @@
+package xrpl
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestXAddress(t *testing.T) {
+	const classic = "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59"
+	tag := func(v uint32) *uint32 { return &v }
+	tests := []struct {
+		tag  *uint32
+		test bool
+		x    string // empty for tags checked by layout only
+	}{
+		{nil, false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ"},
+		{nil, true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ"},
+		{tag(22), false, "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGxLBw6rACm2heBxVn"},
+		{tag(22), true, "T719a5UwUCnEs54UsxG9CJYYDhwmFCvzHM39KcuJw6gp2gS"},
+		{tag(0), false, ""},
+		{tag(0), true, ""},
+		{tag(0xFFFFFFFF), false, ""},
+		{tag(0xFFFFFFFF), true, ""},
+	}
+	accountID, err := decodeAccountID(classic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		x, err := EncodeXAddress(classic, test.tag, test.test)
+		if err != nil {
+			t.Fatalf("EncodeXAddress(%v, %v): %v", test.tag, test.test, err)
+		}
+		if test.x != "" && x != test.x {
+			t.Errorf("EncodeXAddress(%v, %v) = %s, want %s", test.tag, test.test, x, test.x)
+		}
+
+		// prefix (2) + account ID (20) + tag flag (1) + tag (4) + reserved (4)
+		want := []byte{0x05, 0x44}
+		if test.test {
+			want = []byte{0x04, 0x93}
+		}
+		want = append(want, accountID...)
+		tagBytes := make([]byte, 9)
+		if test.tag != nil {
+			tagBytes[0] = 1
+			binary.LittleEndian.PutUint32(tagBytes[1:5], *test.tag)
+		}
+		want = append(want, tagBytes...)
+		if payload, err := NewBase58().decodeChecked(x); err != nil || !bytes.Equal(payload, want) {
+			t.Errorf("%s: payload %X, %v, want %X", x, payload, err, want)
+		}
+
+		decoded, decodedTag, isTest, err := DecodeXAddress(x)
+		if err != nil {
+			t.Errorf("DecodeXAddress(%s): %v", x, err)
+			continue
+		}
+		if decoded != classic || isTest != test.test {
+			t.Errorf("DecodeXAddress(%s) = %s, test %v", x, decoded, isTest)
+		}
+		switch {
+		case test.tag == nil && decodedTag != nil:
+			t.Errorf("DecodeXAddress(%s): tag %d, want none", x, *decodedTag)
+		case test.tag != nil && (decodedTag == nil || *decodedTag != *test.tag):
+			t.Errorf("DecodeXAddress(%s): tag %v, want %d", x, decodedTag, *test.tag)
+		}
+	}
+}
+
+func TestDecodeXAddressInvalid(t *testing.T) {
+	b58 := NewBase58()
+	accountID, err := decodeAccountID("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59")
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := func(prefix []byte, tag []byte) string {
+		data := append(append(append([]byte{}, prefix...), accountID...), tag...)
+		return b58.EncodeCheck(data[0], data[1:])
+	}
+	noTag := make([]byte, 9)
+
+	tests := map[string]string{
+		"checksum":              "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqY",
+		"prefix":                payload([]byte{0x05, 0x45}, noTag),
+		"length":                b58.EncodeCheck(0x05, append([]byte{0x44}, accountID...)),
+		"classic address":       "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59",
+		"tag without flag":      "T719a5UwUCnEs54UsxG9CJYYDhwmFgrRVXpDX5tdrUHz9j1",
+		"unknown tag flag":      payload(xAddressMainnetPrefix, []byte{2, 0, 0, 0, 0, 0, 0, 0, 0}),
+		"reserved bytes in use": payload(xAddressMainnetPrefix, []byte{1, 0, 0, 0, 0, 1, 0, 0, 0}),
+	}
+	for name, x := range tests {
+		if _, _, _, err := DecodeXAddress(x); err == nil {
+			t.Errorf("%s: %s decoded", name, x)
+		}
+		if IsValidXAddress(x) {
+			t.Errorf("%s: %s is valid", name, x)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package xrpltest_test
+
+import (
+	"fmt"
+
+	xrpl "github.com/andreimerlescu/xrpl-go"
+	"github.com/andreimerlescu/xrpl-go/xrpltest"
+)
+
+// pay is code under test: it depends on xrpl.ClientInterface, so it can be
+// given a MockClient
+func pay(client xrpl.ClientInterface, seed, account, destination string) (*xrpl.SubmitResult, error) {
+	info, err := client.AccountInfo(account)
+	if err != nil {
+		return nil, err
+	}
+	return client.SignAndSubmitRequest(xrpl.BaseRequest{
+		"tx_json": map[string]interface{}{
+			"TransactionType":    "Payment",
+			"Account":            account,
+			"Destination":        destination,
+			"Amount":             "1000000",
+			"Fee":                "12",
+			"Sequence":           info.Sequence,
+			"LastLedgerSequence": info.LedgerIndex + 20,
+		},
+	}, seed)
+}
+
+func ExampleMockClient() {
+	const account = "rU6K7V3Po4snVhBBaU29sesqs2qTQJWDw1"
+	mock := xrpltest.NewMockClient()
+	mock.Result("account_info", map[string]interface{}{
+		"account_data": map[string]interface{}{
+			"Account":  account,
+			"Balance":  "100000000",
+			"Sequence": 7,
+		},
+		"ledger_current_index": 1000,
+	})
+	mock.Result("submit", map[string]interface{}{
+		"engine_result":         "tesSUCCESS",
+		"engine_result_code":    0,
+		"engine_result_message": "The transaction was applied. Only final in a validated ledger.",
+		"accepted":              true,
+		"applied":               true,
+	})
+
+	res, err := pay(mock, "sp5fghtJtpUorTwvof1NpDXAzNwf5", account, "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(res.EngineResult)
+
+	submitted, err := xrpl.DecodeTxBlob(mock.RequestsFor("submit")[0]["tx_blob"].(string))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(submitted["Sequence"], submitted["LastLedgerSequence"])
+	// Output:
+	// tesSUCCESS
+	// 7 1020
+}
@@ -0,0 +1,199 @@
+// Package xrpltest provides an in-memory xrpl client for testing code that
+// uses package xrpl without a rippled server.
+//
+// A MockClient is a real *xrpl.Client whose requests are answered by canned
+// responses instead of a server, so every typed helper decodes them exactly
+// as it would a server's. Code under test depends on xrpl.ClientInterface
+// and is given the mock.
+//
+// Example usage:
+//
+//	func TestPay(t *testing.T) {
+//		mock := xrpltest.NewMockClient()
+//		mock.Result("account_info", map[string]interface{}{
+//			"account_data": map[string]interface{}{
+//				"Account":  "rG1QQv2nh2gr7RCZ1P8YYcBUKCCN633jCn",
+//				"Balance":  "100000000",
+//				"Sequence": 7,
+//			},
+//			"ledger_current_index": 1000,
+//		})
+//		mock.Result("submit", map[string]interface{}{
+//			"engine_result": "tesSUCCESS",
+//			"tx_json":       map[string]interface{}{"hash": "E08D6E9754025BA2534A78707605E0601F03ACE063687A0CA1BDDACFCD1698C7"},
+//		})
+//
+//		if err := pay(mock, "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"); err != nil {
+//			t.Fatal(err)
+//		}
+//		if n := len(mock.RequestsFor("submit")); n != 1 {
+//			t.Fatalf("submitted %d times", n)
+//		}
+//	}
+package xrpltest
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	xrpl "github.com/andreimerlescu/xrpl-go"
+)
+
+// Handler answers a request with a response in the websocket API's shape,
+// see Success and Error. A returned error fails the request as a transport
+// error would, e.g. xrpl.ErrDisconnected.
+type Handler func(req xrpl.BaseRequest) (xrpl.BaseResponse, error)
+
+// MockClient is an *xrpl.Client answering requests with the handlers
+// registered on it. Requests without a handler fail with an unknownCmd
+// error response, except subscribe and unsubscribe, which succeed. Stream
+// messages are never delivered.
+type MockClient struct {
+	*xrpl.Client
+	transport *mockTransport
+}
+
+var _ xrpl.ClientInterface = (*MockClient)(nil)
+
+// NewMockClient returns a MockClient without handlers. opts configure the
+// underlying client as for xrpl.NewClient.
+func NewMockClient(opts ...xrpl.ClientOption) *MockClient {
+	transport := &mockTransport{}
+	client := xrpl.NewClient(xrpl.ClientConfig{URL: "mock://xrpltest", Transport: transport}, opts...)
+	return &MockClient{Client: client, transport: transport}
+}
+
+// Handle answers requests for command with h
+func (m *MockClient) Handle(command string, h Handler) {
+	m.HandleMatch(func(req xrpl.BaseRequest) bool {
+		return req["command"] == command
+	}, h)
+}
+
+// HandleMatch answers requests for which match reports true with h. Handlers
+// registered later take precedence, so a test can override a default one.
+func (m *MockClient) HandleMatch(match func(req xrpl.BaseRequest) bool, h Handler) {
+	m.transport.mutex.Lock()
+	defer m.transport.mutex.Unlock()
+	m.transport.routes = append(m.transport.routes, route{match: match, handler: h})
+}
+
+// Result answers every request for command with a success response holding
+// result
+func (m *MockClient) Result(command string, result map[string]interface{}) {
+	m.Handle(command, func(xrpl.BaseRequest) (xrpl.BaseResponse, error) {
+		return Success(result), nil
+	})
+}
+
+// Fail answers every request for command with the error response err, e.g.
+// "actNotFound"
+func (m *MockClient) Fail(command, err string) {
+	m.Handle(command, func(xrpl.BaseRequest) (xrpl.BaseResponse, error) {
+		return Error(err, ""), nil
+	})
+}
+
+// Requests returns the requests sent so far, in order, as the server would
+// have received them
+func (m *MockClient) Requests() []xrpl.BaseRequest {
+	m.transport.mutex.Lock()
+	defer m.transport.mutex.Unlock()
+	return append([]xrpl.BaseRequest(nil), m.transport.requests...)
+}
+
+// RequestsFor returns the requests sent so far for command
+func (m *MockClient) RequestsFor(command string) []xrpl.BaseRequest {
+	var reqs []xrpl.BaseRequest
+	for _, req := range m.Requests() {
+		if req["command"] == command {
+			reqs = append(reqs, req)
+		}
+	}
+	return reqs
+}
+
+// Success returns a success response holding result
+func Success(result map[string]interface{}) xrpl.BaseResponse {
+	return xrpl.BaseResponse{
+		"status": "success",
+		"type":   "response",
+		"result": result,
+	}
+}
+
+// Error returns an error response for err, e.g. "txnNotFound", with an
+// optional error_message
+func Error(err, message string) xrpl.BaseResponse {
+	res := xrpl.BaseResponse{
+		"status": "error",
+		"type":   "response",
+		"error":  err,
+	}
+	if message != "" {
+		res["error_message"] = message
+	}
+	return res
+}
+
+type route struct {
+	match   func(req xrpl.BaseRequest) bool
+	handler Handler
+}
+
+// mockTransport answers requests with the handlers of a MockClient. Requests
+// and responses are passed through JSON, so that handlers and helpers see
+// them as they would travel over the wire.
+type mockTransport struct {
+	mutex    sync.Mutex
+	routes   []route
+	requests []xrpl.BaseRequest
+}
+
+func (t *mockTransport) Send(ctx context.Context, req xrpl.BaseRequest) (xrpl.BaseResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var sent xrpl.BaseRequest
+	if err := roundTrip(req, &sent); err != nil {
+		return nil, err
+	}
+
+	t.mutex.Lock()
+	t.requests = append(t.requests, sent)
+	var handler Handler
+	for i := len(t.routes) - 1; i >= 0; i-- {
+		if t.routes[i].match(sent) {
+			handler = t.routes[i].handler
+			break
+		}
+	}
+	t.mutex.Unlock()
+
+	if handler == nil {
+		switch sent["command"] {
+		case "subscribe", "unsubscribe":
+			return Success(map[string]interface{}{}), nil
+		}
+		return Error("unknownCmd", "no mock response for this command"), nil
+	}
+	res, err := handler(sent)
+	if err != nil {
+		return nil, err
+	}
+	var received xrpl.BaseResponse
+	if err := roundTrip(res, &received); err != nil {
+		return nil, err
+	}
+	return received, nil
+}
+
+// roundTrip converts in to out through its JSON encoding
+func roundTrip(in, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}